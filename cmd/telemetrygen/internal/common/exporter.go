@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	grpcZap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const httpTimeout = 10 * time.Second
+
+// CreateLogger creates the logger used by the telemetry generators, which is
+// also used to log the messages of the gRPC library.
+func CreateLogger() (*zap.Logger, error) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain logger: %w", err)
+	}
+	grpcZap.ReplaceGrpcLoggerV2(logger.WithOptions(
+		zap.AddCallerSkip(3),
+	))
+	return logger, nil
+}
+
+// Exporter sends OTLP metrics and logs to the configured endpoint, either
+// over gRPC or over HTTP using the protobuf encoding.
+type Exporter struct {
+	cfg        *Config
+	conn       *grpc.ClientConn
+	httpClient *http.Client
+}
+
+// NewExporter creates an Exporter for the given configuration.
+func NewExporter(cfg *Config) (*Exporter, error) {
+	if cfg.UseHTTP {
+		return &Exporter{cfg: cfg, httpClient: &http.Client{Timeout: httpTimeout}}, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if !cfg.Insecure {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.Dial(cfg.OTLPEndpoint(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.OTLPEndpoint(), err)
+	}
+	return &Exporter{cfg: cfg, conn: conn}, nil
+}
+
+// ExportMetrics sends the metrics in a single OTLP request.
+func (e *Exporter) ExportMetrics(ctx context.Context, md pmetric.Metrics) error {
+	req := pmetricotlp.NewExportRequestFromMetrics(md)
+	if e.httpClient != nil {
+		body, err := req.MarshalProto()
+		if err != nil {
+			return err
+		}
+		return e.post(ctx, "/v1/metrics", body)
+	}
+	_, err := pmetricotlp.NewGRPCClient(e.conn).Export(e.outgoingContext(ctx), req)
+	return err
+}
+
+// ExportLogs sends the logs in a single OTLP request.
+func (e *Exporter) ExportLogs(ctx context.Context, ld plog.Logs) error {
+	req := plogotlp.NewExportRequestFromLogs(ld)
+	if e.httpClient != nil {
+		body, err := req.MarshalProto()
+		if err != nil {
+			return err
+		}
+		return e.post(ctx, "/v1/logs", body)
+	}
+	_, err := plogotlp.NewGRPCClient(e.conn).Export(e.outgoingContext(ctx), req)
+	return err
+}
+
+// Shutdown closes the connection to the endpoint.
+func (e *Exporter) Shutdown() error {
+	if e.conn != nil {
+		return e.conn.Close()
+	}
+	return nil
+}
+
+func (e *Exporter) outgoingContext(ctx context.Context) context.Context {
+	if len(e.cfg.Headers) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.New(e.cfg.Headers))
+}
+
+func (e *Exporter) post(ctx context.Context, path string, body []byte) error {
+	scheme := "https://"
+	if e.cfg.Insecure {
+		scheme = "http://"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheme+e.cfg.OTLPEndpoint()+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send request to %s: %s", path, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestExporterHTTP(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		switch r.URL.Path {
+		case "/v1/metrics":
+			req := pmetricotlp.NewExportRequest()
+			assert.NoError(t, req.UnmarshalProto(body))
+			assert.Equal(t, 1, req.Metrics().DataPointCount())
+		case "/v1/logs":
+			req := plogotlp.NewExportRequest()
+			assert.NoError(t, req.UnmarshalProto(body))
+			assert.Equal(t, 1, req.Logs().LogRecordCount())
+		}
+	}))
+	defer server.Close()
+
+	exp, err := NewExporter(&Config{
+		Endpoint: strings.TrimPrefix(server.URL, "http://"),
+		Insecure: true,
+		UseHTTP:  true,
+		Headers:  KeyValue{"X-Token": "secret"},
+	})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, exp.Shutdown()) }()
+
+	require.NoError(t, exp.ExportMetrics(context.Background(), testMetrics()))
+	require.NoError(t, exp.ExportLogs(context.Background(), testLogs()))
+	assert.Equal(t, []string{"/v1/metrics", "/v1/logs"}, paths)
+}
+
+func TestExporterHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exp, err := NewExporter(&Config{
+		Endpoint: strings.TrimPrefix(server.URL, "http://"),
+		Insecure: true,
+		UseHTTP:  true,
+	})
+	require.NoError(t, err)
+	assert.Error(t, exp.ExportLogs(context.Background(), testLogs()))
+}
+
+func TestExporterGRPC(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	logsServer := &mockLogsServer{}
+	plogotlp.RegisterGRPCServer(srv, logsServer)
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Stop()
+
+	exp, err := NewExporter(&Config{
+		Endpoint: ln.Addr().String(),
+		Insecure: true,
+		Headers:  KeyValue{"x-token": "secret"},
+	})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, exp.Shutdown()) }()
+
+	require.NoError(t, exp.ExportLogs(context.Background(), testLogs()))
+	assert.Equal(t, 1, logsServer.count)
+	assert.Equal(t, []string{"secret"}, logsServer.md.Get("x-token"))
+}
+
+type mockLogsServer struct {
+	count int
+	md    metadata.MD
+}
+
+func (s *mockLogsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	s.count += req.Logs().LogRecordCount()
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	return plogotlp.NewExportResponse(), nil
+}
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("gen.gauge")
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	return md
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("test")
+	return ld
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	running            *atomic.Bool        // pointer to shared flag that indicates it's time to stop the test
+	numLogs            int                 // how many logs the worker has to generate (only when duration==0)
+	body               string              // body of the log records
+	severityText       string              // severity text of the log records
+	severityNumber     plog.SeverityNumber // severity number of the log records
+	serviceName        string              // service.name resource attribute
+	resourceAttributes common.KeyValue     // custom resource attributes
+	limitPerSecond     rate.Limit          // how many logs per second to generate
+	wg                 *sync.WaitGroup     // notify when done
+	exporter           exporter            // sends the generated logs
+	logger             *zap.Logger
+}
+
+func (w worker) simulateLogs() {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	var i int
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		if err := w.exporter.ExportLogs(context.Background(), w.generateLogs()); err != nil {
+			w.logger.Error("failed to export logs", zap.Error(err))
+		}
+
+		i++
+		if w.numLogs != 0 {
+			if i >= w.numLogs {
+				break
+			}
+		}
+	}
+	w.logger.Info("logs generated", zap.Int("logs", i))
+	w.wg.Done()
+}
+
+func (w worker) generateLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	// may be overridden by `--otlp-attributes service.name="foo"`
+	rl.Resource().Attributes().PutStr("service.name", w.serviceName)
+	for k, v := range w.resourceAttributes {
+		rl.Resource().Attributes().PutStr(k, v)
+	}
+
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("telemetrygen")
+	record := sl.LogRecords().AppendEmpty()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetSeverityText(w.severityText)
+	record.SetSeverityNumber(w.severityNumber)
+	record.Body().SetStr(w.body)
+	return ld
+}
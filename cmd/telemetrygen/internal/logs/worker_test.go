@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func TestFixedNumberOfLogs(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 2,
+			ServiceName: "telemetrygen",
+		},
+		NumLogs:        3,
+		Body:           "the message",
+		SeverityText:   "Warn",
+		SeverityNumber: int32(plog.SeverityNumberWarn),
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	require.Len(t, exp.logs, 6)
+	rl := exp.logs[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "telemetrygen"}, rl.Resource().Attributes().AsRaw())
+	record := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "the message", record.Body().Str())
+	assert.Equal(t, "Warn", record.SeverityText())
+	assert.Equal(t, plog.SeverityNumberWarn, record.SeverityNumber())
+	assert.NotZero(t, record.Timestamp())
+}
+
+func TestRateOfLogs(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		SeverityNumber: int32(plog.SeverityNumberInfo),
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, len(exp.logs) >= 3, "there should have been at least 3 logs, had %d", len(exp.logs))
+	// the maximum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, len(exp.logs) <= 10, "there should have been at most 10 logs, had %d", len(exp.logs))
+}
+
+func TestInvalidSeverityNumber(t *testing.T) {
+	cfg := &Config{
+		Config:         common.Config{WorkerCount: 1},
+		NumLogs:        1,
+		SeverityNumber: 25,
+	}
+	assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+}
+
+type mockExporter struct {
+	mu   sync.Mutex
+	logs []plog.Logs
+}
+
+func (m *mockExporter) ExportLogs(_ context.Context, ld plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, ld)
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+const (
+	metricTypeCounter   = "counter"
+	metricTypeGauge     = "gauge"
+	metricTypeHistogram = "histogram"
+)
+
+// Config describes the test scenario.
+type Config struct {
+	common.Config
+	NumMetrics  int
+	MetricTypes []string
+	Cardinality int
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.CommonFlags(fs)
+	fs.IntVar(&c.NumMetrics, "metrics", 1, "Number of times each worker reports its metrics (ignored if duration is provided)")
+	fs.StringSliceVar(&c.MetricTypes, "metric-types", []string{metricTypeCounter, metricTypeGauge, metricTypeHistogram},
+		"Types of metrics to generate, any of counter, gauge and histogram")
+	fs.IntVar(&c.Cardinality, "cardinality", 1, "Number of time series, i.e. distinct attribute sets, each worker generates per metric")
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type exporter interface {
+	ExportMetrics(context.Context, pmetric.Metrics) error
+}
+
+// Start sets up the OTLP exporter and runs the test scenario.
+func Start(cfg *Config) error {
+	logger, err := common.CreateLogger()
+	if err != nil {
+		return err
+	}
+
+	exp, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if err = exp.Shutdown(); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
+
+	return Run(cfg, exp, logger)
+}
+
+// Run executes the test scenario.
+func Run(c *Config, exp exporter, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumMetrics = 0
+	} else if c.NumMetrics <= 0 {
+		return fmt.Errorf("either `metrics` or `duration` must be greater than 0")
+	}
+	if c.Cardinality <= 0 {
+		return fmt.Errorf("`cardinality` must be greater than 0")
+	}
+	for _, metricType := range c.MetricTypes {
+		switch metricType {
+		case metricTypeCounter, metricTypeGauge, metricTypeHistogram:
+		default:
+			return fmt.Errorf("unknown metric type %q", metricType)
+		}
+	}
+
+	limit := rate.Limit(c.Rate)
+	if c.Rate == 0 {
+		limit = rate.Inf
+		logger.Info("generation of metrics isn't being throttled")
+	} else {
+		logger.Info("generation of metrics is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	wg := sync.WaitGroup{}
+	running := atomic.NewBool(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			id:                 i,
+			numMetrics:         c.NumMetrics,
+			metricTypes:        c.MetricTypes,
+			cardinality:        c.Cardinality,
+			serviceName:        c.ServiceName,
+			resourceAttributes: c.ResourceAttributes,
+			limitPerSecond:     limit,
+			running:            running,
+			wg:                 &wg,
+			exporter:           exp,
+			logger:             logger.With(zap.Int("worker", i)),
+		}
+
+		go w.simulateMetrics()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	id                 int             // identifies the worker, reported as data point attribute
+	running            *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numMetrics         int             // how many times the worker has to report its metrics (only when duration==0)
+	metricTypes        []string        // which metrics to generate
+	cardinality        int             // how many time series to generate per metric
+	serviceName        string          // service.name resource attribute
+	resourceAttributes common.KeyValue // custom resource attributes
+	limitPerSecond     rate.Limit      // how many times per second to report the metrics
+	wg                 *sync.WaitGroup // notify when done
+	exporter           exporter        // sends the generated metrics
+	logger             *zap.Logger
+}
+
+// histogramBounds are the explicit bucket bounds of the generated histogram.
+var histogramBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000}
+
+func (w worker) simulateMetrics() {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	start := pcommon.NewTimestampFromTime(time.Now())
+	previous := start
+	var i int
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		i++
+		now := pcommon.NewTimestampFromTime(time.Now())
+		if err := w.exporter.ExportMetrics(context.Background(), w.generateMetrics(start, previous, now, i)); err != nil {
+			w.logger.Error("failed to export metrics", zap.Error(err))
+		}
+		previous = now
+
+		if w.numMetrics != 0 {
+			if i >= w.numMetrics {
+				break
+			}
+		}
+	}
+	w.logger.Info("metrics generated", zap.Int("metrics", i))
+	w.wg.Done()
+}
+
+// generateMetrics creates the metrics reported in the given iteration. Counters
+// are cumulative since start, histograms report the delta since the previous
+// iteration.
+func (w worker) generateMetrics(start, previous, now pcommon.Timestamp, iteration int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	// may be overridden by `--otlp-attributes service.name="foo"`
+	rm.Resource().Attributes().PutStr("service.name", w.serviceName)
+	for k, v := range w.resourceAttributes {
+		rm.Resource().Attributes().PutStr(k, v)
+	}
+
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("telemetrygen")
+	for _, metricType := range w.metricTypes {
+		metric := sm.Metrics().AppendEmpty()
+		switch metricType {
+		case metricTypeCounter:
+			metric.SetName("gen.counter")
+			metric.SetUnit("1")
+			sum := metric.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for s := 0; s < w.cardinality; s++ {
+				dp := sum.DataPoints().AppendEmpty()
+				w.setAttributes(dp.Attributes(), s)
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(now)
+				dp.SetIntValue(int64(iteration))
+			}
+		case metricTypeGauge:
+			metric.SetName("gen.gauge")
+			metric.SetUnit("1")
+			gauge := metric.SetEmptyGauge()
+			for s := 0; s < w.cardinality; s++ {
+				dp := gauge.DataPoints().AppendEmpty()
+				w.setAttributes(dp.Attributes(), s)
+				dp.SetTimestamp(now)
+				dp.SetDoubleValue(float64((iteration + s) % 100))
+			}
+		case metricTypeHistogram:
+			metric.SetName("gen.histogram")
+			metric.SetUnit("ms")
+			histogram := metric.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for s := 0; s < w.cardinality; s++ {
+				dp := histogram.DataPoints().AppendEmpty()
+				w.setAttributes(dp.Attributes(), s)
+				dp.SetStartTimestamp(previous)
+				dp.SetTimestamp(now)
+
+				value := float64((iteration*7 + s*13) % 1200)
+				counts := make([]uint64, len(histogramBounds)+1)
+				counts[sort.SearchFloat64s(histogramBounds, value)] = 1
+				dp.SetCount(1)
+				dp.SetSum(value)
+				dp.SetMin(value)
+				dp.SetMax(value)
+				dp.BucketCounts().FromRaw(counts)
+				dp.ExplicitBounds().FromRaw(histogramBounds)
+			}
+		}
+	}
+	return md
+}
+
+func (w worker) setAttributes(attrs pcommon.Map, series int) {
+	attrs.PutInt("worker", int64(w.id))
+	attrs.PutStr("series", fmt.Sprintf("series-%d", series))
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func TestFixedNumberOfMetrics(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+			ServiceName: "telemetrygen",
+			ResourceAttributes: common.KeyValue{
+				"deployment.environment": "benchmark",
+			},
+		},
+		NumMetrics:  2,
+		MetricTypes: []string{metricTypeCounter, metricTypeGauge, metricTypeHistogram},
+		Cardinality: 3,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	require.Len(t, exp.metrics, 2)
+	md := exp.metrics[1]
+	assert.Equal(t, 9, md.DataPointCount())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":           "telemetrygen",
+		"deployment.environment": "benchmark",
+	}, rm.Resource().Attributes().AsRaw())
+
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	counter := metrics.At(0)
+	assert.Equal(t, "gen.counter", counter.Name())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, counter.Sum().AggregationTemporality())
+	assert.Equal(t, int64(2), counter.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]interface{}{"worker": int64(0), "series": "series-2"}, counter.Sum().DataPoints().At(2).Attributes().AsRaw())
+
+	assert.Equal(t, "gen.gauge", metrics.At(1).Name())
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics.At(1).Type())
+
+	histogram := metrics.At(2)
+	assert.Equal(t, "gen.histogram", histogram.Name())
+	dp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.Len(t, dp.BucketCounts().AsRaw(), len(histogramBounds)+1)
+}
+
+func TestRateOfMetrics(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		MetricTypes: []string{metricTypeGauge},
+		Cardinality: 1,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of reports for the rate of 10/sec for half a second
+	assert.True(t, len(exp.metrics) >= 3, "there should have been at least 3 reports, had %d", len(exp.metrics))
+	// the maximum acceptable number of reports for the rate of 10/sec for half a second
+	assert.True(t, len(exp.metrics) <= 10, "there should have been at most 10 reports, had %d", len(exp.metrics))
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := map[string]*Config{
+		"no metrics and no duration": {
+			Config:      common.Config{WorkerCount: 1},
+			MetricTypes: []string{metricTypeGauge},
+			Cardinality: 1,
+		},
+		"zero cardinality": {
+			Config:      common.Config{WorkerCount: 1},
+			NumMetrics:  1,
+			MetricTypes: []string{metricTypeGauge},
+		},
+		"unknown metric type": {
+			Config:      common.Config{WorkerCount: 1},
+			NumMetrics:  1,
+			MetricTypes: []string{"summary"},
+			Cardinality: 1,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+		})
+	}
+}
+
+type mockExporter struct {
+	mu      sync.Mutex
+	metrics []pmetric.Metrics
+}
+
+func (m *mockExporter) ExportMetrics(_ context.Context, md pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, md)
+	return nil
+}
@@ -13,30 +13,38 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package telemetrygen // import "github.com/open-telemetry/opentelemetry-collector-contrib/telemetrygen/internal/telemetrygen"
+package telemetrygen // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/telemetrygen"
 
 import (
-	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+)
+
+var (
+	tracesCfg  = new(traces.Config)
+	metricsCfg = new(metrics.Config)
+	logsCfg    = new(logs.Config)
 )
 
 // rootCmd is the root command on which will be run children commands
 var rootCmd = &cobra.Command{
 	Use:     "telemetrygen",
-	Short:   "Telemetrygen simulates a client generating traces and metrics",
-	Example: "telemetrygen metrics\ntelemetrygen traces",
+	Short:   "Telemetrygen simulates a client generating traces, metrics and logs",
+	Example: "telemetrygen traces\ntelemetrygen metrics\ntelemetrygen logs",
 }
 
 // tracesCmd is the command responsible for sending traces
 var tracesCmd = &cobra.Command{
 	Use:     "traces",
 	Short:   "Simulates a client generating traces",
-	Example: "telemetrygen traces",
+	Example: "telemetrygen traces --otlp-insecure --duration 5s",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := errors.New("[WIP] The 'traces' command is a work in progress, come back later")
-		return err
+		return traces.Start(tracesCfg)
 	},
 }
 
@@ -44,15 +52,28 @@ var tracesCmd = &cobra.Command{
 var metricsCmd = &cobra.Command{
 	Use:     "metrics",
 	Short:   "Simulates a client generating metrics",
-	Example: "telemetrygen metrics",
+	Example: "telemetrygen metrics --otlp-insecure --duration 5s --cardinality 10",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return metrics.Start(metricsCfg)
+	},
+}
+
+// logsCmd is the command responsible for sending logs
+var logsCmd = &cobra.Command{
+	Use:     "logs",
+	Short:   "Simulates a client generating logs",
+	Example: "telemetrygen logs --otlp-insecure --duration 5s",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := errors.New("[WIP] The 'metrics' command is a work in progress, come back later")
-		return err
+		return logs.Start(logsCfg)
 	},
 }
 
 func init() {
-	rootCmd.AddCommand(tracesCmd, metricsCmd)
+	rootCmd.AddCommand(tracesCmd, metricsCmd, logsCmd)
+
+	tracesCfg.Flags(tracesCmd.Flags())
+	metricsCfg.Flags(metricsCmd.Flags())
+	logsCfg.Flags(logsCmd.Flags())
 
 	// Disabling completion command for end user
 	// https://github.com/spf13/cobra/blob/master/shell_completions.md
@@ -63,8 +84,6 @@ func init() {
 // Execute tries to run the input command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		// TODO: Uncomment the line below when using Run instead of RunE in the xxxCmd functions
-		// fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/confmap/provider/httpsprovider"
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	schemeName = "https"
+
+	// defaultPollInterval is the interval between the checks for changes of the watched configurations.
+	defaultPollInterval = 30 * time.Second
+)
+
+type provider struct {
+	client       *http.Client
+	pollInterval time.Duration
+
+	mu       sync.Mutex
+	pollers  map[*poller]struct{}
+	shutdown bool
+}
+
+// Option configures the provider returned by New.
+type Option func(*provider)
+
+// WithTLSConfig sets the TLS configuration used to connect to the configuration server, e.g. to
+// trust a private certificate authority or to authenticate with a client certificate.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(p *provider) {
+		p.client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+}
+
+// WithPollInterval sets the interval between the checks for changes of the configurations. The
+// changes are not watched if the interval is zero or negative.
+func WithPollInterval(interval time.Duration) Option {
+	return func(p *provider) {
+		p.pollInterval = interval
+	}
+}
+
+// New returns a new confmap.Provider that reads the configuration from an HTTPS server.
+//
+// This Provider supports "https" scheme, and can be called with a "uri" that follows:
+//
+//	https-uri : https://[HOST][:PORT]/[PATH][?QUERY]
+//
+// The configuration is polled for changes, detected with the ETag returned by the server or the
+// content of the response when there is none, and the watcher is notified of the first change.
+//
+// Examples:
+// `https://config.example.com/collector/agent.yaml` - (unix, windows)
+func New(opts ...Option) confmap.Provider {
+	p := &provider{
+		client:       &http.Client{},
+		pollInterval: defaultPollInterval,
+		pollers:      map[*poller]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	resp, err := p.get(ctx, uri, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to download the file via HTTPS: %w", err)
+	}
+	if resp.statusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.statusCode)
+	}
+
+	var rawConf interface{}
+	if err = yaml.Unmarshal(resp.body, &rawConf); err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration from uri %q: %w", uri, err)
+	}
+
+	if watcher == nil || p.pollInterval <= 0 {
+		return confmap.NewRetrieved(rawConf)
+	}
+
+	w := newPoller(p, uri, resp, watcher)
+	p.mu.Lock()
+	if p.shutdown {
+		p.mu.Unlock()
+		w.cancel()
+		return nil, errors.New("provider is shut down")
+	}
+	p.pollers[w] = struct{}{}
+	p.mu.Unlock()
+	go w.run()
+
+	return confmap.NewRetrieved(rawConf, confmap.WithRetrievedClose(func(context.Context) error {
+		p.stopPoller(w)
+		return nil
+	}))
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (p *provider) Shutdown(context.Context) error {
+	p.mu.Lock()
+	p.shutdown = true
+	pollers := p.pollers
+	p.pollers = map[*poller]struct{}{}
+	p.mu.Unlock()
+	for w := range pollers {
+		w.close()
+	}
+	return nil
+}
+
+func (p *provider) stopPoller(w *poller) {
+	p.mu.Lock()
+	delete(p.pollers, w)
+	p.mu.Unlock()
+	w.close()
+}
+
+type response struct {
+	statusCode int
+	etag       string
+	body       []byte
+}
+
+// get downloads the uri, only if its ETag doesn't match the given one if not empty.
+func (p *provider) get(ctx context.Context, uri string, etag string) (response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return response{}, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+
+	var body bytes.Buffer
+	if _, err = io.Copy(&body, resp.Body); err != nil {
+		return response{}, err
+	}
+	return response{statusCode: resp.StatusCode, etag: resp.Header.Get("ETag"), body: body.Bytes()}, nil
+}
+
+// poller periodically checks whether the configuration at uri changed, and notifies the watcher
+// of the first change. The changes are detected with the ETag of the configuration, or the hash
+// of its content if the server doesn't return one.
+type poller struct {
+	provider *provider
+	uri      string
+	etag     string
+	hash     [sha256.Size]byte
+	watcher  confmap.WatcherFunc
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPoller(p *provider, uri string, resp response, watcher confmap.WatcherFunc) *poller {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &poller{
+		provider: p,
+		uri:      uri,
+		etag:     resp.etag,
+		hash:     sha256.Sum256(resp.body),
+		watcher:  watcher,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+func (w *poller) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.provider.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if w.changed() {
+				w.watcher(&confmap.ChangeEvent{})
+				return
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// changed returns whether the configuration changed. The errors are ignored, as the configuration
+// server may be temporarily unavailable, and a change is only reported once it can be retrieved.
+func (w *poller) changed() bool {
+	resp, err := w.provider.get(w.ctx, w.uri, w.etag)
+	if err != nil || resp.statusCode != http.StatusOK {
+		return false
+	}
+	if resp.etag != "" || w.etag != "" {
+		return resp.etag != w.etag
+	}
+	return sha256.Sum256(resp.body) != w.hash
+}
+
+func (w *poller) close() {
+	w.cancel()
+	<-w.done
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsprovider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// configServer serves a configuration which can be updated, with or without ETag.
+type configServer struct {
+	mu       sync.Mutex
+	content  []byte
+	etag     string
+	requests int
+}
+
+func (s *configServer) set(content []byte, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content = content
+	s.etag = etag
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.content == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if s.etag != "" {
+		if r.Header.Get("If-None-Match") == s.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", s.etag)
+	}
+	_, _ = w.Write(s.content)
+}
+
+func newTestServer(t *testing.T) (*configServer, *httptest.Server, *tls.Config) {
+	cs := &configServer{}
+	ts := httptest.NewTLSServer(cs)
+	t.Cleanup(ts.Close)
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	return cs, ts, &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+}
+
+func readConfig(t *testing.T) []byte {
+	content, err := os.ReadFile("./testdata/otel-config.yaml")
+	require.NoError(t, err)
+	return content
+}
+
+func TestRetrieve(t *testing.T) {
+	cs, ts, tlsConfig := newTestServer(t)
+	cs.set(readConfig(t), "")
+	p := New(WithTLSConfig(tlsConfig))
+
+	ret, err := p.Retrieve(context.Background(), ts.URL+"/otel-config.yaml", nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.True(t, retMap.IsSet("service::pipelines::traces"))
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieveErrors(t *testing.T) {
+	cs, ts, tlsConfig := newTestServer(t)
+	p := New(WithTLSConfig(tlsConfig))
+
+	_, err := p.Retrieve(context.Background(), "http://example.com/config.yaml", nil)
+	assert.EqualError(t, err, `"http://example.com/config.yaml" uri is not supported by "https" provider`)
+
+	_, err = p.Retrieve(context.Background(), ts.URL+"/missing.yaml", nil)
+	assert.EqualError(t, err, `failed to load resource from uri "`+ts.URL+`/missing.yaml". status code: 404`)
+
+	cs.set([]byte("[invalid"), "")
+	_, err = p.Retrieve(context.Background(), ts.URL+"/invalid.yaml", nil)
+	assert.ErrorContains(t, err, "failed to parse the configuration")
+
+	// The certificate of the server is not trusted by default.
+	_, err = New().Retrieve(context.Background(), ts.URL+"/invalid.yaml", nil)
+	assert.ErrorContains(t, err, "unable to download the file via HTTPS")
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestWatch(t *testing.T) {
+	for _, etag := range []string{"", `"v1"`} {
+		t.Run("etag="+etag, func(t *testing.T) {
+			cs, ts, tlsConfig := newTestServer(t)
+			cs.set(readConfig(t), etag)
+			p := New(WithTLSConfig(tlsConfig), WithPollInterval(10*time.Millisecond))
+
+			events := make(chan *confmap.ChangeEvent, 1)
+			ret, err := p.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) {
+				events <- event
+			})
+			require.NoError(t, err)
+
+			// Wait for a few polls of the unchanged configuration.
+			assert.Eventually(t, func() bool {
+				cs.mu.Lock()
+				defer cs.mu.Unlock()
+				return cs.requests > 3
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.Empty(t, events)
+
+			newEtag := ""
+			if etag != "" {
+				newEtag = `"v2"`
+			}
+			cs.set(append(readConfig(t), []byte("\nextensions:\n  health_check:\n")...), newEtag)
+			select {
+			case event := <-events:
+				assert.NoError(t, event.Error)
+			case <-time.After(5 * time.Second):
+				t.Fatal("the change was not notified")
+			}
+
+			assert.NoError(t, ret.Close(context.Background()))
+			assert.NoError(t, p.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestShutdownStopsWatching(t *testing.T) {
+	cs, ts, tlsConfig := newTestServer(t)
+	cs.set(readConfig(t), `"v1"`)
+	p := New(WithTLSConfig(tlsConfig), WithPollInterval(time.Hour))
+
+	_, err := p.Retrieve(context.Background(), ts.URL, func(*confmap.ChangeEvent) {})
+	require.NoError(t, err)
+	assert.NoError(t, p.Shutdown(context.Background()))
+
+	_, err = p.Retrieve(context.Background(), ts.URL, func(*confmap.ChangeEvent) {})
+	assert.EqualError(t, err, "provider is shut down")
+}
+
+func TestScheme(t *testing.T) {
+	assert.Equal(t, "https", New().Scheme())
+}
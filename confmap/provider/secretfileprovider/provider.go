@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretfileprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/confmap/provider/secretfileprovider"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const schemeName = "secretfile"
+
+type provider struct{}
+
+// New returns a new confmap.Provider that reads a single value, such as a password or a token, from a file.
+//
+// This Provider supports "secretfile" scheme, and can be called with a selector:
+// `secretfile:/path/to/file`
+//
+// Unlike the "file" scheme, the content of the file is not parsed as YAML but returned as a string,
+// without its trailing line break, so that it can be used in a configuration value:
+// `password: ${secretfile:/var/run/secrets/db/password}`
+//
+// Examples:
+// `secretfile:/var/run/secrets/db/password` - (unix)
+// `secretfile:C:\secrets\password` - (windows)
+func New() confmap.Provider {
+	return &provider{}
+}
+
+func (*provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	// Clean the path before using it.
+	content, err := os.ReadFile(filepath.Clean(uri[len(schemeName)+1:]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the secret file %v: %w", uri, err)
+	}
+
+	return confmap.NewRetrieved(strings.TrimRight(string(content), "\r\n"))
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
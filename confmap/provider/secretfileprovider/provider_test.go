@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretfileprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieve(t *testing.T) {
+	p := New()
+	ret, err := p.Retrieve(context.Background(), schemeName+":"+filepath.Join("testdata", "secret"), nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieveNotParsed(t *testing.T) {
+	ret, err := New().Retrieve(context.Background(), schemeName+":"+filepath.Join("testdata", "yaml-secret"), nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "key: value", raw)
+}
+
+func TestRetrieveWindowsLineBreak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("token\r\n"), 0600))
+	ret, err := New().Retrieve(context.Background(), schemeName+":"+path, nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "token", raw)
+}
+
+func TestRetrieveErrors(t *testing.T) {
+	p := New()
+	_, err := p.Retrieve(context.Background(), "file:"+filepath.Join("testdata", "secret"), nil)
+	assert.EqualError(t, err, `"file:testdata/secret" uri is not supported by "secretfile" provider`)
+
+	_, err = p.Retrieve(context.Background(), schemeName+":"+filepath.Join("testdata", "missing"), nil)
+	assert.ErrorContains(t, err, "unable to read the secret file")
+}
+
+func TestScheme(t *testing.T) {
+	assert.Equal(t, "secretfile", New().Scheme())
+}
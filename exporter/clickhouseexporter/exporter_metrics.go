@@ -0,0 +1,442 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2" // For register database driver.
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+// metricTable describes the table holding the data points of one metric type.
+type metricTable struct {
+	metricType pmetric.MetricType
+	// suffix is appended to the configured metrics table name.
+	suffix string
+	// columns are the type specific column definitions.
+	columns string
+	// insertColumns are the type specific columns, in the order their values are appended.
+	insertColumns []string
+}
+
+var metricTables = []metricTable{
+	{
+		metricType: pmetric.MetricTypeGauge,
+		suffix:     "_gauge",
+		columns: `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),` + exemplarsColumns,
+		insertColumns: append([]string{"Value", "Flags"}, exemplarsInsertColumns...),
+	},
+	{
+		metricType: pmetric.MetricTypeSum,
+		suffix:     "_sum",
+		columns: `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),` + exemplarsColumns + `
+     AggTemp Int32 CODEC(ZSTD(1)),
+     IsMonotonic Boolean CODEC(Delta, ZSTD(1)),`,
+		insertColumns: append(append([]string{"Value", "Flags"}, exemplarsInsertColumns...), "AggTemp", "IsMonotonic"),
+	},
+	{
+		metricType: pmetric.MetricTypeHistogram,
+		suffix:     "_histogram",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     ExplicitBounds Array(Float64) CODEC(ZSTD(1)),` + exemplarsColumns + `
+     Flags UInt32 CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`,
+		insertColumns: append(append([]string{"Count", "Sum", "BucketCounts", "ExplicitBounds"}, exemplarsInsertColumns...),
+			"Flags", "Min", "Max", "AggTemp"),
+	},
+	{
+		metricType: pmetric.MetricTypeExponentialHistogram,
+		suffix:     "_exponential_histogram",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     Scale Int32 CODEC(ZSTD(1)),
+     ZeroCount UInt64 CODEC(ZSTD(1)),
+     PositiveOffset Int32 CODEC(ZSTD(1)),
+     PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     NegativeOffset Int32 CODEC(ZSTD(1)),
+     NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),` + exemplarsColumns + `
+     Flags UInt32 CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`,
+		insertColumns: append(append([]string{"Count", "Sum", "Scale", "ZeroCount",
+			"PositiveOffset", "PositiveBucketCounts", "NegativeOffset", "NegativeBucketCounts"}, exemplarsInsertColumns...),
+			"Flags", "Min", "Max", "AggTemp"),
+	},
+	{
+		metricType: pmetric.MetricTypeSummary,
+		suffix:     "_summary",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     ValueAtQuantiles Nested(
+         Quantile Float64,
+         Value Float64
+     ) CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),`,
+		insertColumns: []string{"Count", "Sum", "ValueAtQuantiles.Quantile", "ValueAtQuantiles.Value", "Flags"},
+	},
+}
+
+type metricsExporter struct {
+	client *sql.DB
+	// insertSQL holds the insert statement of every metric type.
+	insertSQL map[pmetric.MetricType]string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+
+	if err := createDatabase(cfg); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createMetricsTables(cfg, client); err != nil {
+		return nil, err
+	}
+
+	insertSQL := make(map[pmetric.MetricType]string, len(metricTables))
+	for _, table := range metricTables {
+		insertSQL[table.metricType] = renderInsertMetricSQL(cfg, table)
+	}
+
+	return &metricsExporter{
+		client:    client,
+		insertSQL: insertSQL,
+		logger:    logger,
+		cfg:       cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	start := time.Now()
+	rows := metricsToRows(md)
+	err := doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+		for _, table := range metricTables {
+			if len(rows[table.metricType]) == 0 {
+				continue
+			}
+			if err := e.insertRows(ctx, tx, e.insertSQL[table.metricType], rows[table.metricType]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	duration := time.Since(start)
+	e.logger.Info("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	return err
+}
+
+func (e *metricsExporter) insertRows(ctx context.Context, tx *sql.Tx, insertSQL string, rows [][]interface{}) error {
+	statement, err := tx.PrepareContext(ctx, insertSQL)
+	if err != nil {
+		return fmt.Errorf("PrepareContext:%w", err)
+	}
+	defer func() {
+		_ = statement.Close()
+	}()
+	for _, row := range rows {
+		if _, err = statement.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("ExecContext:%w", err)
+		}
+	}
+	return nil
+}
+
+// metricsToRows converts every data point to the values of a table row, grouped by metric type.
+func metricsToRows(md pmetric.Metrics) map[pmetric.MetricType][][]interface{} {
+	rows := make(map[pmetric.MetricType][][]interface{})
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		metrics := md.ResourceMetrics().At(i)
+		res := metrics.Resource()
+		resAttr := attributesToMap(res.Attributes())
+		var serviceName string
+		if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+			serviceName = v.Str()
+		}
+		for j := 0; j < metrics.ScopeMetrics().Len(); j++ {
+			sm := metrics.ScopeMetrics().At(j)
+			scope := sm.Scope()
+			scopeAttr := attributesToMap(scope.Attributes())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				common := func(attrs pcommon.Map, startTime, timestamp pcommon.Timestamp) []interface{} {
+					return []interface{}{
+						resAttr,
+						metrics.SchemaUrl(),
+						scope.Name(),
+						scope.Version(),
+						scopeAttr,
+						scope.DroppedAttributesCount(),
+						sm.SchemaUrl(),
+						serviceName,
+						m.Name(),
+						m.Description(),
+						m.Unit(),
+						attributesToMap(attrs),
+						startTime.AsTime(),
+						timestamp.AsTime(),
+					}
+				}
+
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+							numberValue(dp), uint32(dp.Flags()))
+						rows[m.Type()] = append(rows[m.Type()], append(row, convertExemplars(dp.Exemplars())...))
+					}
+				case pmetric.MetricTypeSum:
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+							numberValue(dp), uint32(dp.Flags()))
+						row = append(row, convertExemplars(dp.Exemplars())...)
+						rows[m.Type()] = append(rows[m.Type()], append(row,
+							int32(m.Sum().AggregationTemporality()),
+							m.Sum().IsMonotonic(),
+						))
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+							dp.Count(),
+							dp.Sum(),
+							dp.BucketCounts().AsRaw(),
+							dp.ExplicitBounds().AsRaw(),
+						)
+						row = append(row, convertExemplars(dp.Exemplars())...)
+						rows[m.Type()] = append(rows[m.Type()], append(row,
+							uint32(dp.Flags()),
+							dp.Min(),
+							dp.Max(),
+							int32(m.Histogram().AggregationTemporality()),
+						))
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+							dp.Count(),
+							dp.Sum(),
+							dp.Scale(),
+							dp.ZeroCount(),
+							dp.Positive().Offset(),
+							dp.Positive().BucketCounts().AsRaw(),
+							dp.Negative().Offset(),
+							dp.Negative().BucketCounts().AsRaw(),
+						)
+						row = append(row, convertExemplars(dp.Exemplars())...)
+						rows[m.Type()] = append(rows[m.Type()], append(row,
+							uint32(dp.Flags()),
+							dp.Min(),
+							dp.Max(),
+							int32(m.ExponentialHistogram().AggregationTemporality()),
+						))
+					}
+				case pmetric.MetricTypeSummary:
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						quantiles, values := convertValueAtQuantiles(dp.QuantileValues())
+						rows[m.Type()] = append(rows[m.Type()], append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+							dp.Count(),
+							dp.Sum(),
+							quantiles,
+							values,
+							uint32(dp.Flags()),
+						))
+					}
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func convertExemplars(exemplars pmetric.ExemplarSlice) []interface{} {
+	var (
+		attrs    []map[string]string
+		times    []time.Time
+		values   []float64
+		spanIDs  []string
+		traceIDs []string
+	)
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		attrs = append(attrs, attributesToMap(exemplar.FilteredAttributes()))
+		times = append(times, exemplar.Timestamp().AsTime())
+		if exemplar.ValueType() == pmetric.ExemplarValueTypeInt {
+			values = append(values, float64(exemplar.IntValue()))
+		} else {
+			values = append(values, exemplar.DoubleValue())
+		}
+		spanIDs = append(spanIDs, traceutil.SpanIDToHexOrEmptyString(exemplar.SpanID()))
+		traceIDs = append(traceIDs, traceutil.TraceIDToHexOrEmptyString(exemplar.TraceID()))
+	}
+	return []interface{}{attrs, times, values, spanIDs, traceIDs}
+}
+
+func convertValueAtQuantiles(valueAtQuantiles pmetric.SummaryDataPointValueAtQuantileSlice) ([]float64, []float64) {
+	var (
+		quantiles []float64
+		values    []float64
+	)
+	for i := 0; i < valueAtQuantiles.Len(); i++ {
+		value := valueAtQuantiles.At(i)
+		quantiles = append(quantiles, value.Quantile())
+		values = append(values, value.Value())
+	}
+	return quantiles, values
+}
+
+const (
+	// language=ClickHouse SQL
+	createMetricTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (
+     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ResourceSchemaUrl String CODEC(ZSTD(1)),
+     ScopeName String CODEC(ZSTD(1)),
+     ScopeVersion String CODEC(ZSTD(1)),
+     ScopeAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ScopeDroppedAttrCount UInt32 CODEC(ZSTD(1)),
+     ScopeSchemaUrl String CODEC(ZSTD(1)),
+     ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+     MetricName String CODEC(ZSTD(1)),
+     MetricDescription String CODEC(ZSTD(1)),
+     MetricUnit String CODEC(ZSTD(1)),
+     Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),%s
+     INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_scope_attr_key mapKeys(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_scope_attr_value mapValues(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (ServiceName, MetricName, toUnixTimestamp64Nano(TimeUnix))
+SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
+`
+	exemplarsColumns = `
+     Exemplars Nested (
+         FilteredAttributes Map(LowCardinality(String), String),
+         TimeUnix DateTime64(9),
+         Value Float64,
+         SpanId String,
+         TraceId String
+     ) CODEC(ZSTD(1)),`
+)
+
+var (
+	metricInsertColumns = []string{
+		"ResourceAttributes",
+		"ResourceSchemaUrl",
+		"ScopeName",
+		"ScopeVersion",
+		"ScopeAttributes",
+		"ScopeDroppedAttrCount",
+		"ScopeSchemaUrl",
+		"ServiceName",
+		"MetricName",
+		"MetricDescription",
+		"MetricUnit",
+		"Attributes",
+		"StartTimeUnix",
+		"TimeUnix",
+	}
+	exemplarsInsertColumns = []string{
+		"Exemplars.FilteredAttributes",
+		"Exemplars.TimeUnix",
+		"Exemplars.Value",
+		"Exemplars.SpanId",
+		"Exemplars.TraceId",
+	}
+)
+
+func createMetricsTables(cfg *Config, db *sql.DB) error {
+	for _, table := range metricTables {
+		if _, err := db.Exec(renderCreateMetricTableSQL(cfg, table)); err != nil {
+			return fmt.Errorf("exec create %s table sql: %w", strings.TrimPrefix(table.suffix, "_"), err)
+		}
+	}
+	return nil
+}
+
+func renderCreateMetricTableSQL(cfg *Config, table metricTable) string {
+	var ttlExpr string
+	if cfg.TTLDays > 0 {
+		ttlExpr = fmt.Sprintf(`TTL toDateTime(TimeUnix) + toIntervalDay(%d)`, cfg.TTLDays)
+	}
+	return fmt.Sprintf(createMetricTableSQL, cfg.MetricsTableName+table.suffix, table.columns, ttlExpr)
+}
+
+func renderInsertMetricSQL(cfg *Config, table metricTable) string {
+	columns := append(append([]string{}, metricInsertColumns...), table.insertColumns...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		cfg.MetricsTableName+table.suffix, strings.Join(columns, ", "), placeholders)
+}
@@ -0,0 +1,179 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := make(map[string]int)
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			t.Logf("%v, values:%+v", items, values)
+			if strings.HasPrefix(query, "INSERT") {
+				table := strings.Fields(query)[2]
+				items[table]++
+				require.Equal(t, strings.Count(query, "?"), len(values))
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("only insert into tables of present types", func(t *testing.T) {
+		var queries []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				queries = append(queries, query)
+			}
+			return nil
+		})
+
+		metrics := pmetric.NewMetrics()
+		m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, metrics)
+
+		require.Len(t, queries, 1)
+		require.True(t, strings.HasPrefix(queries[0], "INSERT INTO otel_metrics_gauge "))
+	})
+}
+
+func TestMetricsTablesTTL(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+
+	newTestMetricsExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.MetricsTableName = "metrics"
+		cfg.TTLDays = 3
+	})
+
+	var created []string
+	for _, query := range queries {
+		if strings.Contains(query, "CREATE TABLE") {
+			created = append(created, strings.Fields(query)[5])
+			require.Contains(t, query, "TTL toDateTime(TimeUnix) + toIntervalDay(3)")
+		}
+	}
+	require.Equal(t, []string{
+		"metrics_gauge",
+		"metrics_sum",
+		"metrics_histogram",
+		"metrics_exponential_histogram",
+		"metrics_summary",
+	}, created)
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "demo")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("Scope name 1")
+	sm.Scope().Attributes().PutStr("lib", "clickhouse")
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	for i := 0; i < count; i++ {
+		gauge := sm.Metrics().AppendEmpty()
+		gauge.SetName("gauge metrics")
+		dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetDoubleValue(1.5)
+		dp.SetTimestamp(timestamp)
+		dp.Attributes().PutStr("gauge_label_1", "1")
+		exemplar := dp.Exemplars().AppendEmpty()
+		exemplar.SetIntValue(54)
+		exemplar.SetTimestamp(timestamp)
+		exemplar.SetTraceID([16]byte{1, 2, 3, byte(i)})
+		exemplar.SetSpanID([8]byte{1, 2, 3, byte(i)})
+
+		sum := sm.Metrics().AppendEmpty()
+		sum.SetName("sum metrics")
+		sum.SetEmptySum().SetIsMonotonic(true)
+		sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sdp := sum.Sum().DataPoints().AppendEmpty()
+		sdp.SetIntValue(11)
+		sdp.SetTimestamp(timestamp)
+
+		histogram := sm.Metrics().AppendEmpty()
+		histogram.SetName("histogram metrics")
+		hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+		hdp.SetCount(3)
+		hdp.SetSum(6)
+		hdp.SetMin(1)
+		hdp.SetMax(4)
+		hdp.BucketCounts().FromRaw([]uint64{1, 2})
+		hdp.ExplicitBounds().FromRaw([]float64{2})
+		hdp.SetTimestamp(timestamp)
+
+		expHistogram := sm.Metrics().AppendEmpty()
+		expHistogram.SetName("exponential histogram metrics")
+		edp := expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+		edp.SetCount(3)
+		edp.SetScale(2)
+		edp.SetZeroCount(1)
+		edp.Positive().SetOffset(1)
+		edp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+		edp.SetTimestamp(timestamp)
+
+		summary := sm.Metrics().AppendEmpty()
+		summary.SetName("summary metrics")
+		qdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+		qdp.SetCount(2)
+		qdp.SetSum(3)
+		qv := qdp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(0.5)
+		qv.SetValue(1)
+		qdp.SetTimestamp(timestamp)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
@@ -58,6 +58,9 @@ type Config struct {
 	// This setting is required when traces pipelines used.
 	TracesIndex string `mapstructure:"traces_index"`
 
+	// This setting is required when metrics pipelines used.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -65,10 +68,11 @@ type Config struct {
 	Pipeline string `mapstructure:"pipeline"`
 
 	HTTPClientSettings `mapstructure:",squash"`
-	Discovery          DiscoverySettings `mapstructure:"discover"`
-	Retry              RetrySettings     `mapstructure:"retry"`
-	Flush              FlushSettings     `mapstructure:"flush"`
-	Mapping            MappingsSettings  `mapstructure:"mapping"`
+	Discovery          DiscoverySettings  `mapstructure:"discover"`
+	Retry              RetrySettings      `mapstructure:"retry"`
+	Flush              FlushSettings      `mapstructure:"flush"`
+	Mapping            MappingsSettings   `mapstructure:"mapping"`
+	DataStream         DataStreamSettings `mapstructure:"data_stream"`
 }
 
 type HTTPClientSettings struct {
@@ -166,17 +170,37 @@ type MappingsSettings struct {
 	Dedot bool `mapstructure:"dedot"`
 }
 
+// DataStreamSettings defines settings for routing events to Elasticsearch
+// data streams. Events are indexed into data streams named
+// `<type>-<dataset>-<namespace>`, where type is one of logs, metrics or traces.
+//
+// https://www.elastic.co/guide/en/fleet/current/data-streams.html#data-streams-naming-scheme
+type DataStreamSettings struct {
+	// Enabled routes events to data streams instead of the configured
+	// logs_index, metrics_index and traces_index.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dataset is used if an event does not carry the `data_stream.dataset` attribute.
+	Dataset string `mapstructure:"dataset"`
+
+	// Namespace is used if an event does not carry the `data_stream.namespace` attribute.
+	Namespace string `mapstructure:"namespace"`
+}
+
 type MappingMode int
 
 // Enum values for MappingMode.
 const (
 	MappingNone MappingMode = iota
 	MappingECS
+	MappingECSLogs
 )
 
 var (
 	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
 	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
+	errConfigNoDataset     = errors.New("data_stream.dataset must be specified")
+	errConfigNoNamespace   = errors.New("data_stream.namespace must be specified")
 )
 
 func (m MappingMode) String() string {
@@ -185,6 +209,8 @@ func (m MappingMode) String() string {
 		return ""
 	case MappingECS:
 		return "ecs"
+	case MappingECSLogs:
+		return "ecs_logs"
 	default:
 		return ""
 	}
@@ -195,6 +221,7 @@ var mappingModes = func() map[string]MappingMode {
 	for _, m := range []MappingMode{
 		MappingNone,
 		MappingECS,
+		MappingECSLogs,
 	} {
 		table[strings.ToLower(m.String())] = m
 	}
@@ -226,5 +253,19 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown mapping mode %v", cfg.Mapping.Mode)
 	}
 
+	if cfg.DataStream.Enabled {
+		if cfg.DataStream.Dataset == "" {
+			return errConfigNoDataset
+		}
+		if cfg.DataStream.Namespace == "" {
+			return errConfigNoNamespace
+		}
+	}
+
 	return nil
 }
+
+// MappingMode returns the configured mapping mode.
+func (cfg *Config) MappingMode() MappingMode {
+	return mappingModes[cfg.Mapping.Mode]
+}
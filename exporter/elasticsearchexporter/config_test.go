@@ -43,6 +43,7 @@ func TestLoad_DeprecatedIndexConfigOption(t *testing.T) {
 		Index:            "my_log_index",
 		LogsIndex:        "logs-generic-default",
 		TracesIndex:      "traces-generic-default",
+		MetricsIndex:     "metrics-generic-default",
 		Pipeline:         "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
@@ -72,6 +73,10 @@ func TestLoad_DeprecatedIndexConfigOption(t *testing.T) {
 			Dedup: true,
 			Dedot: true,
 		},
+		DataStream: DataStreamSettings{
+			Dataset:   "generic",
+			Namespace: "default",
+		},
 	})
 }
 
@@ -101,6 +106,7 @@ func TestLoadConfig(t *testing.T) {
 				Index:            "",
 				LogsIndex:        "logs-generic-default",
 				TracesIndex:      "trace_index",
+				MetricsIndex:     "metrics-generic-default",
 				Pipeline:         "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
@@ -130,6 +136,10 @@ func TestLoadConfig(t *testing.T) {
 					Dedup: true,
 					Dedot: true,
 				},
+				DataStream: DataStreamSettings{
+					Dataset:   "generic",
+					Namespace: "default",
+				},
 			},
 		},
 		{
@@ -141,6 +151,7 @@ func TestLoadConfig(t *testing.T) {
 				Index:            "",
 				LogsIndex:        "my_log_index",
 				TracesIndex:      "traces-generic-default",
+				MetricsIndex:     "metrics-generic-default",
 				Pipeline:         "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
@@ -170,8 +181,24 @@ func TestLoadConfig(t *testing.T) {
 					Dedup: true,
 					Dedot: true,
 				},
+				DataStream: DataStreamSettings{
+					Dataset:   "generic",
+					Namespace: "default",
+				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "datastream"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://localhost:9200"}
+				cfg.Mapping.Mode = "ecs_logs"
+				cfg.DataStream = DataStreamSettings{
+					Enabled:   true,
+					Dataset:   "otel",
+					Namespace: "prod",
+				}
+			}),
+		},
 	}
 
 	for _, tt := range tests {
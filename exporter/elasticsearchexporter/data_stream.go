@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	dataStreamTypeLogs    = "logs"
+	dataStreamTypeMetrics = "metrics"
+	dataStreamTypeTraces  = "traces"
+
+	dataStreamTypeField      = "data_stream.type"
+	dataStreamDatasetField   = "data_stream.dataset"
+	dataStreamNamespaceField = "data_stream.namespace"
+
+	// maxDataStreamFieldLength is the maximum length of a data stream dataset
+	// or namespace. Longer values are truncated.
+	maxDataStreamFieldLength = 100
+)
+
+// dataStream identifies the Elasticsearch data stream an event is indexed in.
+type dataStream struct {
+	typ       string
+	dataset   string
+	namespace string
+}
+
+// routeDataStream returns the data stream an event of the given type is routed to.
+// The dataset and namespace are read from the first attribute map that sets
+// `data_stream.dataset` or `data_stream.namespace`, falling back to the configured
+// defaults.
+func routeDataStream(typ string, settings DataStreamSettings, attrs ...pcommon.Map) dataStream {
+	return dataStream{
+		typ:       typ,
+		dataset:   dataStreamField(dataStreamDatasetField, settings.Dataset, attrs),
+		namespace: dataStreamField(dataStreamNamespaceField, settings.Namespace, attrs),
+	}
+}
+
+// index returns the name of the data stream, `<type>-<dataset>-<namespace>`.
+func (ds dataStream) index() string {
+	return ds.typ + "-" + ds.dataset + "-" + ds.namespace
+}
+
+func dataStreamField(key, fallback string, attrs []pcommon.Map) string {
+	for _, am := range attrs {
+		if v, ok := am.Get(key); ok {
+			if s := sanitizeDataStreamField(v.AsString()); s != "" {
+				return s
+			}
+		}
+	}
+	return sanitizeDataStreamField(fallback)
+}
+
+// sanitizeDataStreamField lowercases the value and replaces characters that are not
+// allowed in data stream names. The `-` separator is replaced as well, so that the
+// data stream name can always be split into its type, dataset and namespace.
+func sanitizeDataStreamField(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', '/', '*', '?', '"', '<', '>', '|', ' ', ',', '#', ':', '-':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(value))
+	if len(value) > maxDataStreamFieldLength {
+		value = value[:maxDataStreamFieldLength]
+	}
+	return value
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRouteDataStream(t *testing.T) {
+	settings := DataStreamSettings{Enabled: true, Dataset: "generic", Namespace: "default"}
+
+	tests := map[string]struct {
+		record   map[string]interface{}
+		resource map[string]interface{}
+		want     string
+	}{
+		"defaults": {
+			want: "logs-generic-default",
+		},
+		"from resource": {
+			resource: map[string]interface{}{"data_stream.dataset": "nginx.access", "data_stream.namespace": "prod"},
+			want:     "logs-nginx.access-prod",
+		},
+		"record overrides resource": {
+			record:   map[string]interface{}{"data_stream.dataset": "nginx.error"},
+			resource: map[string]interface{}{"data_stream.dataset": "nginx.access"},
+			want:     "logs-nginx.error-default",
+		},
+		"sanitized": {
+			resource: map[string]interface{}{"data_stream.dataset": "My-App/Access", "data_stream.namespace": "us east"},
+			want:     "logs-my_app_access-us_east",
+		},
+		"empty attribute falls back to default": {
+			resource: map[string]interface{}{"data_stream.dataset": ""},
+			want:     "logs-generic-default",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			record, resource := pcommon.NewMap(), pcommon.NewMap()
+			assert.NoError(t, record.FromRaw(test.record))
+			assert.NoError(t, resource.FromRaw(test.resource))
+
+			ds := routeDataStream(dataStreamTypeLogs, settings, record, resource)
+			assert.Equal(t, test.want, ds.index())
+		})
+	}
+}
@@ -26,9 +26,12 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr            = "elasticsearch"
-	defaultLogsIndex   = "logs-generic-default"
-	defaultTracesIndex = "traces-generic-default"
+	typeStr             = "elasticsearch"
+	defaultLogsIndex    = "logs-generic-default"
+	defaultTracesIndex  = "traces-generic-default"
+	defaultMetricsIndex = "metrics-generic-default"
+	defaultDataset      = "generic"
+	defaultNamespace    = "default"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
 )
@@ -40,6 +43,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		component.WithLogsExporter(createLogsExporter, stability),
 		component.WithTracesExporter(createTracesExporter, stability),
+		component.WithMetricsExporter(createMetricsExporter, stability),
 	)
 }
 
@@ -49,9 +53,10 @@ func createDefaultConfig() component.Config {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index:       "",
-		LogsIndex:   defaultLogsIndex,
-		TracesIndex: defaultTracesIndex,
+		Index:        "",
+		LogsIndex:    defaultLogsIndex,
+		TracesIndex:  defaultTracesIndex,
+		MetricsIndex: defaultMetricsIndex,
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -63,6 +68,10 @@ func createDefaultConfig() component.Config {
 			Dedup: true,
 			Dedot: true,
 		},
+		DataStream: DataStreamSettings{
+			Dataset:   defaultDataset,
+			Namespace: defaultNamespace,
+		},
 	}
 }
 
@@ -103,3 +112,15 @@ func createTracesExporter(ctx context.Context,
 	return exporterhelper.NewTracesExporter(ctx, set, cfg, exporter.pushTraceData,
 		exporterhelper.WithShutdown(exporter.Shutdown))
 }
+
+func createMetricsExporter(ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg component.Config) (component.MetricsExporter, error) {
+
+	exporter, err := newMetricsExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+	return exporterhelper.NewMetricsExporter(ctx, set, cfg, exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown))
+}
@@ -30,6 +30,7 @@ type elasticsearchLogsExporter struct {
 	logger *zap.Logger
 
 	index       string
+	dataStream  DataStreamSettings
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -61,8 +62,8 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		maxAttempts = cfg.Retry.MaxRequests
 	}
 
-	// TODO: Apply encoding and field mapping settings.
-	model := &encodeModel{dedup: true, dedot: false}
+	// TODO: Apply dedup, dedot and field mapping settings.
+	model := &encodeModel{dedup: true, dedot: false, mode: cfg.MappingMode()}
 
 	indexStr := cfg.LogsIndex
 	if cfg.Index != "" {
@@ -73,6 +74,7 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		client:      client,
 		bulkIndexer: bulkIndexer,
 		index:       indexStr,
+		dataStream:  cfg.DataStream,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}
@@ -109,9 +111,16 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 }
 
 func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord) error {
-	document, err := e.model.encodeLog(resource, record)
+	index := e.index
+	var ds *dataStream
+	if e.dataStream.Enabled {
+		route := routeDataStream(dataStreamTypeLogs, e.dataStream, record.Attributes(), resource.Attributes())
+		index, ds = route.index(), &route
+	}
+
+	document, err := e.model.encodeLog(resource, record, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+type elasticsearchMetricsExporter struct {
+	logger *zap.Logger
+
+	index       string
+	dataStream  DataStreamSettings
+	maxAttempts int
+
+	client      *esClientCurrent
+	bulkIndexer esBulkIndexerCurrent
+	model       mappingModel
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchMetricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newElasticsearchClient(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkIndexer, err := newBulkIndexer(logger, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := 1
+	if cfg.Retry.Enabled {
+		maxAttempts = cfg.Retry.MaxRequests
+	}
+
+	// TODO: Apply dedup, dedot and field mapping settings.
+	model := &encodeModel{dedup: true, dedot: false, mode: cfg.MappingMode()}
+
+	return &elasticsearchMetricsExporter{
+		logger:      logger,
+		client:      client,
+		bulkIndexer: bulkIndexer,
+
+		index:       cfg.MetricsIndex,
+		dataStream:  cfg.DataStream,
+		maxAttempts: maxAttempts,
+		model:       model,
+	}, nil
+}
+
+func (e *elasticsearchMetricsExporter) Shutdown(ctx context.Context) error {
+	return e.bulkIndexer.Close(ctx)
+}
+
+func (e *elasticsearchMetricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	var errs []error
+	resourceMetrics := md.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		resource := rm.Resource()
+		scopeMetrics := rm.ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			scope := scopeMetrics.At(j).Scope()
+			metrics := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				for _, dp := range metricDataPoints(metric) {
+					if err := e.pushDataPoint(ctx, resource, scope, metric, dp); err != nil {
+						if cerr := ctx.Err(); cerr != nil {
+							return cerr
+						}
+						errs = append(errs, err)
+					}
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchMetricsExporter) pushDataPoint(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric, dp dataPoint) error {
+	index := e.index
+	var ds *dataStream
+	if e.dataStream.Enabled {
+		route := routeDataStream(dataStreamTypeMetrics, e.dataStream, dp.Attributes(), resource.Attributes())
+		index, ds = route.index(), &route
+	}
+
+	document, err := e.model.encodeDataPoint(resource, scope, metric, dp, ds)
+	if err != nil {
+		return fmt.Errorf("Failed to encode metric data point: %w", err)
+	}
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
+}
+
+// metricDataPoints returns the data points of the metric, independent of its type.
+func metricDataPoints(metric pmetric.Metric) []dataPoint {
+	var dps []dataPoint
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Gauge().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Sum().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Histogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			dps = append(dps, metric.ExponentialHistogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Summary().DataPoints().At(i))
+		}
+	}
+	return dps
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	tests := map[string]struct {
+		config *Config
+		want   error
+	}{
+		"create from default with endpoints": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+			}),
+		},
+		"create with data streams": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DataStream.Enabled = true
+			}),
+		},
+		"fail if data stream dataset is empty": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Dataset = ""
+			}),
+			want: errConfigNoDataset,
+		},
+		"fail if data stream namespace is empty": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Namespace = ""
+			}),
+			want: errConfigNoNamespace,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(defaultElasticsearchEnvName, "")
+
+			exporter, err := newMetricsExporter(zap.NewNop(), test.config)
+			if test.want != nil {
+				require.Nil(t, exporter)
+				require.True(t, errors.Is(err, test.want), "expected error %v, got %v", test.want, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, exporter)
+			require.NoError(t, exporter.Shutdown(context.TODO()))
+		})
+	}
+}
+
+func TestExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+
+	newMetrics := func() pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("data_stream.dataset", "nginx")
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		gauge := metrics.AppendEmpty()
+		gauge.SetName("connections")
+		dps := gauge.SetEmptyGauge().DataPoints()
+		dps.AppendEmpty().SetIntValue(1)
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(2)
+		dp.Attributes().PutStr("data_stream.namespace", "prod")
+		return md
+	}
+
+	t.Run("publish to metrics index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), newMetrics()))
+
+		rec.WaitItems(2)
+		for _, item := range rec.Items() {
+			assert.Equal(t, "metrics-generic-default", actionIndex(t, item))
+		}
+	})
+
+	t.Run("publish to data streams", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.DataStream.Enabled = true
+		})
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), newMetrics()))
+
+		rec.WaitItems(2)
+		var indices []string
+		for _, item := range rec.Items() {
+			indices = append(indices, actionIndex(t, item))
+		}
+		assert.ElementsMatch(t, []string{"metrics-nginx-default", "metrics-nginx-prod"}, indices)
+	})
+}
+
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchMetricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+	return exporter
+}
+
+func actionIndex(t *testing.T, item itemRequest) string {
+	var action map[string]struct {
+		Index string `json:"_index"`
+	}
+	require.NoError(t, json.Unmarshal(item.Action, &action))
+	return action["create"].Index
+}
@@ -20,26 +20,42 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 )
 
+// mappingModel encodes events into documents. If the event is routed to a
+// data stream, the data stream fields are added to the document.
 type mappingModel interface {
-	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
-	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	encodeLog(pcommon.Resource, plog.LogRecord, *dataStream) ([]byte, error)
+	encodeSpan(pcommon.Resource, ptrace.Span, *dataStream) ([]byte, error)
+	encodeDataPoint(pcommon.Resource, pcommon.InstrumentationScope, pmetric.Metric, dataPoint, *dataStream) ([]byte, error)
+}
+
+// dataPoint is implemented by all metric data point types.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+	Flags() pmetric.DataPointFlags
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
 // No fields will be mapped by default.
 //
+// With the ecs_logs mapping mode, log records are encoded using the Elastic Common Schema
+// instead, which is understood by the Kibana Logs UI.
+//
 // Field deduplication and dedotting of attributes is supported by the encodeModel.
 //
 // See: https://github.com/open-telemetry/oteps/blob/master/text/logs/0097-log-data-model.md
 type encodeModel struct {
 	dedup bool
 	dedot bool
+	mode  MappingMode
 }
 
 const (
@@ -48,7 +64,35 @@ const (
 	attributeField = "attribute"
 )
 
-func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord) ([]byte, error) {
+// resourceAttrsConversionMap maps OpenTelemetry resource attributes to their ECS
+// counterparts. Attributes not listed here, for example service.name, host.name or
+// cloud.region, already use the ECS field name.
+//
+// See: https://www.elastic.co/guide/en/ecs/current/ecs-otel-alignment-details.html
+var resourceAttrsConversionMap = map[string]string{
+	"service.instance.id":     "service.node.name",
+	"deployment.environment":  "service.environment",
+	"telemetry.sdk.name":      "agent.name",
+	"telemetry.sdk.version":   "agent.version",
+	"host.arch":               "host.architecture",
+	"os.type":                 "host.os.platform",
+	"os.description":          "host.os.full",
+	"os.name":                 "host.os.name",
+	"os.version":              "host.os.version",
+	"k8s.namespace.name":      "kubernetes.namespace",
+	"k8s.node.name":           "kubernetes.node.name",
+	"k8s.pod.name":            "kubernetes.pod.name",
+	"k8s.pod.uid":             "kubernetes.pod.uid",
+	"k8s.deployment.name":     "kubernetes.deployment.name",
+	"process.executable.path": "process.executable",
+	"process.command_line":    "process.command_line",
+}
+
+func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord, ds *dataStream) ([]byte, error) {
+	if m.mode == MappingECSLogs {
+		return m.encodeLogECSMode(resource, record, ds)
+	}
+
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", record.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddTraceID("TraceId", record.TraceID())
@@ -59,19 +103,50 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	document.AddAttribute("Body", record.Body())
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
+	addDataStreamFields(&document, ds)
 
-	if m.dedup {
-		document.Dedup()
-	} else if m.dedot {
-		document.Sort()
+	return m.serialize(&document)
+}
+
+// encodeLogECSMode encodes the log record using the Elastic Common Schema. Attributes
+// are added as top level fields, with well known resource attributes renamed to
+// their ECS counterparts.
+func (m *encodeModel) encodeLogECSMode(resource pcommon.Resource, record plog.LogRecord, ds *dataStream) ([]byte, error) {
+	var document objmodel.Document
+	encodeAttributesECSMode(&document, resource.Attributes(), resourceAttrsConversionMap)
+	encodeAttributesECSMode(&document, record.Attributes(), nil)
+
+	timestamp := record.Timestamp()
+	if timestamp == 0 {
+		timestamp = record.ObservedTimestamp()
+	}
+	document.AddTimestamp("@timestamp", timestamp)
+	document.AddString("message", record.Body().AsString())
+	document.AddString("log.level", record.SeverityText())
+	if record.SeverityNumber() != plog.SeverityNumberUnspecified {
+		document.AddInt("event.severity", int64(record.SeverityNumber()))
+	}
+	document.AddTraceID("trace.id", record.TraceID())
+	document.AddSpanID("span.id", record.SpanID())
+	if ds != nil {
+		document.AddString("event.dataset", ds.dataset)
 	}
+	addDataStreamFields(&document, ds)
 
-	var buf bytes.Buffer
-	err := document.Serialize(&buf, m.dedot)
-	return buf.Bytes(), err
+	return m.serialize(&document)
+}
+
+func encodeAttributesECSMode(document *objmodel.Document, attrs pcommon.Map, conversionMap map[string]string) {
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if ecsKey, ok := conversionMap[k]; ok {
+			k = ecsKey
+		}
+		document.AddAttribute(k, v)
+		return true
+	})
 }
 
-func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([]byte, error) {
+func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span, ds *dataStream) ([]byte, error) {
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddTimestamp("EndTimestamp", span.EndTimestamp())
@@ -84,7 +159,95 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	document.AddString("Link", spanLinksToString(span.Links()))
 	document.AddAttributes("Attributes", span.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
+	addDataStreamFields(&document, ds)
 
+	return m.serialize(&document)
+}
+
+// encodeDataPoint encodes a single metric data point. Each data point is indexed
+// as a separate document, carrying the metric name, type and unit.
+func (m *encodeModel) encodeDataPoint(resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric, dp dataPoint, ds *dataStream) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", dp.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream metrics template is used.
+	if dp.StartTimestamp() != 0 {
+		document.AddTimestamp("StartTimestamp", dp.StartTimestamp())
+	}
+	document.AddString("Name", metric.Name())
+	document.AddString("Unit", metric.Unit())
+	document.AddString("Type", metric.Type().String())
+	document.AddInt("Flags", int64(dp.Flags()))
+
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		document.Add("IsMonotonic", objmodel.BoolValue(metric.Sum().IsMonotonic()))
+		document.AddString("AggregationTemporality", metric.Sum().AggregationTemporality().String())
+	case pmetric.MetricTypeHistogram:
+		document.AddString("AggregationTemporality", metric.Histogram().AggregationTemporality().String())
+	case pmetric.MetricTypeExponentialHistogram:
+		document.AddString("AggregationTemporality", metric.ExponentialHistogram().AggregationTemporality().String())
+	}
+
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			document.AddInt("Value", dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			document.Add("Value", objmodel.DoubleValue(dp.DoubleValue()))
+		}
+	case pmetric.HistogramDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		if dp.HasSum() {
+			document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+		}
+		if dp.HasMin() {
+			document.Add("Min", objmodel.DoubleValue(dp.Min()))
+		}
+		if dp.HasMax() {
+			document.Add("Max", objmodel.DoubleValue(dp.Max()))
+		}
+		document.Add("BucketCounts", uintArrValue(dp.BucketCounts()))
+		document.Add("ExplicitBounds", doubleArrValue(dp.ExplicitBounds().AsRaw()))
+	case pmetric.ExponentialHistogramDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		if dp.HasSum() {
+			document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+		}
+		if dp.HasMin() {
+			document.Add("Min", objmodel.DoubleValue(dp.Min()))
+		}
+		if dp.HasMax() {
+			document.Add("Max", objmodel.DoubleValue(dp.Max()))
+		}
+		document.AddInt("Scale", int64(dp.Scale()))
+		document.AddInt("ZeroCount", int64(dp.ZeroCount()))
+		document.AddInt("Positive.Offset", int64(dp.Positive().Offset()))
+		document.Add("Positive.BucketCounts", uintArrValue(dp.Positive().BucketCounts()))
+		document.AddInt("Negative.Offset", int64(dp.Negative().Offset()))
+		document.Add("Negative.BucketCounts", uintArrValue(dp.Negative().BucketCounts()))
+	case pmetric.SummaryDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+		quantiles := make([]float64, dp.QuantileValues().Len())
+		values := make([]float64, dp.QuantileValues().Len())
+		for i := 0; i < dp.QuantileValues().Len(); i++ {
+			quantiles[i] = dp.QuantileValues().At(i).Quantile()
+			values[i] = dp.QuantileValues().At(i).Value()
+		}
+		document.Add("Quantiles", doubleArrValue(quantiles))
+		document.Add("QuantileValues", doubleArrValue(values))
+	}
+
+	document.AddAttributes("Attributes", dp.Attributes())
+	document.AddAttributes("Resource", resource.Attributes())
+	document.AddString("Scope.Name", scope.Name())
+	document.AddString("Scope.Version", scope.Version())
+	addDataStreamFields(&document, ds)
+
+	return m.serialize(&document)
+}
+
+func (m *encodeModel) serialize(document *objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -96,6 +259,33 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	return buf.Bytes(), err
 }
 
+// addDataStreamFields adds the data_stream fields to the document. Elasticsearch
+// requires them to match the data stream the document is indexed in.
+func addDataStreamFields(document *objmodel.Document, ds *dataStream) {
+	if ds == nil {
+		return
+	}
+	document.AddString(dataStreamTypeField, ds.typ)
+	document.AddString(dataStreamDatasetField, ds.dataset)
+	document.AddString(dataStreamNamespaceField, ds.namespace)
+}
+
+func uintArrValue(s pcommon.UInt64Slice) objmodel.Value {
+	values := make([]objmodel.Value, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		values[i] = objmodel.IntValue(int64(s.At(i)))
+	}
+	return objmodel.ArrValue(values...)
+}
+
+func doubleArrValue(s []float64) objmodel.Value {
+	values := make([]objmodel.Value, len(s))
+	for i, v := range s {
+		values[i] = objmodel.DoubleValue(v)
+	}
+	return objmodel.ArrValue(values...)
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]interface{}, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
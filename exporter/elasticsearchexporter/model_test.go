@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var testTimestamp = pcommon.NewTimestampFromTime(time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC))
+
+func newTestLogRecord() (pcommon.Resource, plog.LogRecord) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("service.instance.id", "checkout-1")
+	resource.Attributes().PutStr("k8s.pod.name", "checkout-5d8f")
+
+	record := plog.NewLogRecord()
+	record.SetTimestamp(testTimestamp)
+	record.SetSeverityText("ERROR")
+	record.SetSeverityNumber(plog.SeverityNumberError)
+	record.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	record.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	record.Body().SetStr("payment failed")
+	record.Attributes().PutStr("http.method", "POST")
+	return resource, record
+}
+
+func TestEncodeLog(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: false}
+	resource, record := newTestLogRecord()
+	ds := &dataStream{typ: dataStreamTypeLogs, dataset: "checkout", namespace: "default"}
+
+	doc, err := model.encodeLog(resource, record, ds)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2022-12-01T10:00:00.000000000Z",
+		"Attributes.http.method": "POST",
+		"Body": "payment failed",
+		"Resource.k8s.pod.name": "checkout-5d8f",
+		"Resource.service.instance.id": "checkout-1",
+		"Resource.service.name": "checkout",
+		"SeverityNumber": 17,
+		"SeverityText": "ERROR",
+		"SpanId": "0102030405060708",
+		"TraceFlags": 0,
+		"TraceId": "0102030405060708090a0b0c0d0e0f10",
+		"data_stream.dataset": "checkout",
+		"data_stream.namespace": "default",
+		"data_stream.type": "logs"
+	}`, string(doc))
+}
+
+func TestEncodeLogECSMode(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: true, mode: MappingECSLogs}
+	resource, record := newTestLogRecord()
+	record.Attributes().PutStr("message", "overwritten by the body")
+
+	doc, err := model.encodeLog(resource, record, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2022-12-01T10:00:00.000000000Z",
+		"event": {"severity": 17},
+		"http": {"method": "POST"},
+		"kubernetes": {"pod": {"name": "checkout-5d8f"}},
+		"log": {"level": "ERROR"},
+		"message": "payment failed",
+		"service": {"name": "checkout", "node": {"name": "checkout-1"}},
+		"span": {"id": "0102030405060708"},
+		"trace": {"id": "0102030405060708090a0b0c0d0e0f10"}
+	}`, string(doc))
+}
+
+func TestEncodeLogECSModeObservedTimestamp(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: false, mode: MappingECSLogs}
+	record := plog.NewLogRecord()
+	record.SetObservedTimestamp(testTimestamp)
+	ds := &dataStream{typ: dataStreamTypeLogs, dataset: "generic", namespace: "default"}
+
+	doc, err := model.encodeLog(pcommon.NewResource(), record, ds)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2022-12-01T10:00:00.000000000Z",
+		"event.dataset": "generic",
+		"data_stream.dataset": "generic",
+		"data_stream.namespace": "default",
+		"data_stream.type": "logs"
+	}`, string(doc))
+}
+
+func TestEncodeDataPoint(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: false}
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("otelcol/hostmetricsreceiver")
+
+	t.Run("sum", func(t *testing.T) {
+		metric := pmetric.NewMetric()
+		metric.SetName("http.requests")
+		metric.SetUnit("1")
+		metric.SetEmptySum().SetIsMonotonic(true)
+		metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(testTimestamp)
+		dp.SetTimestamp(testTimestamp)
+		dp.SetIntValue(42)
+		dp.Attributes().PutStr("http.method", "GET")
+
+		doc, err := model.encodeDataPoint(resource, scope, metric, dp, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"@timestamp": "2022-12-01T10:00:00.000000000Z",
+			"AggregationTemporality": "Cumulative",
+			"Attributes.http.method": "GET",
+			"Flags": 0,
+			"IsMonotonic": true,
+			"Name": "http.requests",
+			"Resource.service.name": "checkout",
+			"Scope.Name": "otelcol/hostmetricsreceiver",
+			"StartTimestamp": "2022-12-01T10:00:00.000000000Z",
+			"Type": "Sum",
+			"Unit": "1",
+			"Value": 42
+		}`, string(doc))
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		metric := pmetric.NewMetric()
+		metric.SetName("http.duration")
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.SetTimestamp(testTimestamp)
+		dp.SetCount(3)
+		dp.SetSum(6.5)
+		dp.SetMin(0.5)
+		dp.SetMax(4)
+		dp.BucketCounts().FromRaw([]uint64{1, 2})
+		dp.ExplicitBounds().FromRaw([]float64{1})
+		ds := &dataStream{typ: dataStreamTypeMetrics, dataset: "http", namespace: "default"}
+
+		doc, err := model.encodeDataPoint(resource, scope, metric, dp, ds)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"@timestamp": "2022-12-01T10:00:00.000000000Z",
+			"AggregationTemporality": "Delta",
+			"BucketCounts": [1, 2],
+			"Count": 3,
+			"ExplicitBounds": [1],
+			"Flags": 0,
+			"Max": 4,
+			"Min": 0.5,
+			"Name": "http.duration",
+			"Resource.service.name": "checkout",
+			"Scope.Name": "otelcol/hostmetricsreceiver",
+			"Sum": 6.5,
+			"Type": "Histogram",
+			"data_stream.dataset": "http",
+			"data_stream.namespace": "default",
+			"data_stream.type": "metrics"
+		}`, string(doc))
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		metric := pmetric.NewMetric()
+		metric.SetName("rpc.duration")
+		dp := metric.SetEmptySummary().DataPoints().AppendEmpty()
+		dp.SetTimestamp(testTimestamp)
+		dp.SetCount(10)
+		dp.SetSum(25)
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(0.99)
+		qv.SetValue(4.5)
+
+		doc, err := model.encodeDataPoint(resource, scope, metric, dp, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"@timestamp": "2022-12-01T10:00:00.000000000Z",
+			"Count": 10,
+			"Flags": 0,
+			"Name": "rpc.duration",
+			"QuantileValues": [4.5],
+			"Quantiles": [0.99],
+			"Resource.service.name": "checkout",
+			"Scope.Name": "otelcol/hostmetricsreceiver",
+			"Sum": 25,
+			"Type": "Summary"
+		}`, string(doc))
+	})
+}
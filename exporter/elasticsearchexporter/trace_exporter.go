@@ -30,6 +30,7 @@ type elasticsearchTracesExporter struct {
 	logger *zap.Logger
 
 	index       string
+	dataStream  DataStreamSettings
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -57,8 +58,8 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		maxAttempts = cfg.Retry.MaxRequests
 	}
 
-	// TODO: Apply encoding and field mapping settings.
-	model := &encodeModel{dedup: true, dedot: false}
+	// TODO: Apply dedup, dedot and field mapping settings.
+	model := &encodeModel{dedup: true, dedot: false, mode: cfg.MappingMode()}
 
 	return &elasticsearchTracesExporter{
 		logger:      logger,
@@ -66,6 +67,7 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		bulkIndexer: bulkIndexer,
 
 		index:       cfg.TracesIndex,
+		dataStream:  cfg.DataStream,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}, nil
@@ -102,9 +104,16 @@ func (e *elasticsearchTracesExporter) pushTraceData(
 }
 
 func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, span ptrace.Span) error {
-	document, err := e.model.encodeSpan(resource, span)
+	index := e.index
+	var ds *dataStream
+	if e.dataStream.Enabled {
+		route := routeDataStream(dataStreamTypeTraces, e.dataStream, span.Attributes(), resource.Attributes())
+		index, ds = route.index(), &route
+	}
+
+	document, err := e.model.encodeSpan(resource, span, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode trace record: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
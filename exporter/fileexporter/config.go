@@ -34,6 +34,13 @@ type Config struct {
 	// Path of the file to write to. Path is relative to current directory.
 	Path string `mapstructure:"path"`
 
+	// PathTemplate makes Path a template containing the time directives %Y,
+	// %m, %d, %H, %M and %S, replaced with the current UTC time, and
+	// %{attribute} placeholders, replaced with the value of a resource
+	// attribute. Data is then written to one file per rendered path, and files
+	// are closed once the time they are for is over. Use %% for a literal %.
+	PathTemplate bool `mapstructure:"path_template"`
+
 	// Rotation defines an option about rotation of telemetry files
 	Rotation *Rotation `mapstructure:"rotation"`
 
@@ -78,6 +85,11 @@ func (cfg *Config) Validate() error {
 	if cfg.Path == "" {
 		return errors.New("path must be non-empty")
 	}
+	if cfg.PathTemplate {
+		if _, err := parsePathTemplate(cfg.Path); err != nil {
+			return err
+		}
+	}
 	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
 		return errors.New("format type is not supported")
 	}
@@ -88,6 +88,27 @@ func TestLoadConfig(t *testing.T) {
 				FormatType: formatTypeJSON,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "path_template"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				Path:             "./data/%Y/%m/%d/%H/%{service.name}/traces.json",
+				PathTemplate:     true,
+				FormatType:       formatTypeJSON,
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "path_template_error"),
+			errorMessage: `path "./data/%Q/traces.json" has an unsupported directive %Q`,
+		},
+		{
+			id: component.NewIDWithName(typeStr, "literal_percent"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				Path:             "./data/%Q/traces.json",
+				FormatType:       formatTypeJSON,
+			},
+		},
 		{
 			id:           component.NewIDWithName(typeStr, "compression_error"),
 			errorMessage: "compression is not supported",
@@ -18,10 +18,12 @@ import (
 	"context"
 	"io"
 	"os"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
@@ -68,7 +70,7 @@ func createTracesExporter(
 	cfg component.Config,
 ) (component.TracesExporter, error) {
 	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	writer, partitions, err := buildWriters(conf)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +79,7 @@ func createTracesExporter(
 			path:            conf.Path,
 			formatType:      conf.FormatType,
 			file:            writer,
+			partitions:      partitions,
 			tracesMarshaler: tracesMarshalers[conf.FormatType],
 			exporter:        buildExportFunc(conf),
 			compression:     conf.Compression,
@@ -99,7 +102,7 @@ func createMetricsExporter(
 	cfg component.Config,
 ) (component.MetricsExporter, error) {
 	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	writer, partitions, err := buildWriters(conf)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +111,7 @@ func createMetricsExporter(
 			path:             conf.Path,
 			formatType:       conf.FormatType,
 			file:             writer,
+			partitions:       partitions,
 			metricsMarshaler: metricsMarshalers[conf.FormatType],
 			exporter:         buildExportFunc(conf),
 			compression:      conf.Compression,
@@ -130,7 +134,7 @@ func createLogsExporter(
 	cfg component.Config,
 ) (component.LogsExporter, error) {
 	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	writer, partitions, err := buildWriters(conf)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +143,7 @@ func createLogsExporter(
 			path:          conf.Path,
 			formatType:    conf.FormatType,
 			file:          writer,
+			partitions:    partitions,
 			logsMarshaler: logsMarshalers[conf.FormatType],
 			exporter:      buildExportFunc(conf),
 			compression:   conf.Compression,
@@ -155,6 +160,26 @@ func createLogsExporter(
 	)
 }
 
+// buildWriters returns the file to write to, or the partitioned writer when
+// the path is a template.
+func buildWriters(cfg *Config) (io.WriteCloser, *partitionedWriter, error) {
+	if cfg.PathTemplate {
+		template, err := parsePathTemplate(cfg.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !template.isStatic() {
+			return nil, newPartitionedWriter(template, cfg.Rotation), nil
+		}
+		// the template only has escaped %, write to the path it renders
+		path := template.render(time.Time{}, pcommon.NewResource())
+		writer, err := buildFileWriter(&Config{Path: path, Rotation: cfg.Rotation})
+		return writer, nil, err
+	}
+	writer, err := buildFileWriter(cfg)
+	return writer, nil, err
+}
+
 func buildFileWriter(cfg *Config) (io.WriteCloser, error) {
 	if cfg.Rotation == nil {
 		return os.OpenFile(cfg.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,6 +75,21 @@ func TestCreateTracesExporter(t *testing.T) {
 	require.NotNil(t, exp)
 }
 
+func TestCreateTracesExporterWithPathTemplate(t *testing.T) {
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+		FormatType:       formatTypeJSON,
+		Path:             filepath.Join(t.TempDir(), "%Y", "%{service.name}.json"),
+		PathTemplate:     true,
+	}
+	exp, err := createTracesExporter(
+		context.Background(),
+		componenttest.NewNopExporterCreateSettings(),
+		cfg)
+	assert.NoError(t, err)
+	require.NotNil(t, exp)
+}
+
 func TestCreateTracesExporterError(t *testing.T) {
 	cfg := &Config{
 		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
@@ -181,3 +197,32 @@ func TestBuildFileWriter(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildWritersStaticPath(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name:     "literal path",
+			cfg:      &Config{Path: filepath.Join(dir, "100%Y.json")},
+			expected: filepath.Join(dir, "100%Y.json"),
+		},
+		{
+			name:     "path template with escaped percent",
+			cfg:      &Config{Path: filepath.Join(dir, "50%%.json"), PathTemplate: true},
+			expected: filepath.Join(dir, "50%.json"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer, partitions, err := buildWriters(tt.cfg)
+			require.NoError(t, err)
+			assert.Nil(t, partitions)
+			require.NoError(t, writer.Close())
+			assert.FileExists(t, tt.expected)
+		})
+	}
+}
@@ -22,9 +22,11 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 )
 
 // Marshaler configuration used for marhsaling Protobuf
@@ -50,6 +52,9 @@ type fileExporter struct {
 	file  io.WriteCloser
 	mutex sync.Mutex
 
+	// partitions is set instead of file when the path is a template.
+	partitions *partitionedWriter
+
 	tracesMarshaler  ptrace.Marshaler
 	metricsMarshaler pmetric.Marshaler
 	logsMarshaler    plog.Marshaler
@@ -66,6 +71,9 @@ func (e *fileExporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	if e.partitions != nil {
+		return e.consumePartitionedTraces(td)
+	}
 	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
@@ -75,6 +83,9 @@ func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if e.partitions != nil {
+		return e.consumePartitionedMetrics(md)
+	}
 	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
@@ -84,6 +95,9 @@ func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) err
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	if e.partitions != nil {
+		return e.consumePartitionedLogs(ld)
+	}
 	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
@@ -92,6 +106,74 @@ func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
 	return e.exporter(e, buf)
 }
 
+// consumePartitionedTraces writes the data of each resource to the file
+// rendered from the path template.
+func (e *fileExporter) consumePartitionedTraces(td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	return e.consumePartitioned(rss.Len(),
+		func(i int) pcommon.Resource { return rss.At(i).Resource() },
+		func(indexes []int) ([]byte, error) {
+			partition := ptrace.NewTraces()
+			for _, i := range indexes {
+				rss.At(i).CopyTo(partition.ResourceSpans().AppendEmpty())
+			}
+			return e.tracesMarshaler.MarshalTraces(partition)
+		})
+}
+
+// consumePartitionedMetrics writes the data of each resource to the file
+// rendered from the path template.
+func (e *fileExporter) consumePartitionedMetrics(md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	return e.consumePartitioned(rms.Len(),
+		func(i int) pcommon.Resource { return rms.At(i).Resource() },
+		func(indexes []int) ([]byte, error) {
+			partition := pmetric.NewMetrics()
+			for _, i := range indexes {
+				rms.At(i).CopyTo(partition.ResourceMetrics().AppendEmpty())
+			}
+			return e.metricsMarshaler.MarshalMetrics(partition)
+		})
+}
+
+// consumePartitionedLogs writes the data of each resource to the file
+// rendered from the path template.
+func (e *fileExporter) consumePartitionedLogs(ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	return e.consumePartitioned(rls.Len(),
+		func(i int) pcommon.Resource { return rls.At(i).Resource() },
+		func(indexes []int) ([]byte, error) {
+			partition := plog.NewLogs()
+			for _, i := range indexes {
+				rls.At(i).CopyTo(partition.ResourceLogs().AppendEmpty())
+			}
+			return e.logsMarshaler.MarshalLogs(partition)
+		})
+}
+
+// consumePartitioned groups the count resources of a batch by the path they
+// render to, and writes each group marshaled by marshal to its file.
+func (e *fileExporter) consumePartitioned(count int, resource func(i int) pcommon.Resource, marshal func(indexes []int) ([]byte, error)) error {
+	now := e.partitions.now()
+	partitions := map[string][]int{}
+	for i := 0; i < count; i++ {
+		path := e.partitions.template.render(now, resource(i))
+		partitions[path] = append(partitions[path], i)
+	}
+
+	bucket := e.partitions.template.bucket(now)
+	var errs error
+	for path, indexes := range partitions {
+		buf, err := marshal(indexes)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		errs = multierr.Append(errs, e.partitions.export(path, bucket, e.compressor(buf), e.exporter))
+	}
+	return errs
+}
+
 func exportMessageAsLine(e *fileExporter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
@@ -121,11 +203,17 @@ func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
 }
 
 func (e *fileExporter) Start(context.Context, component.Host) error {
+	if e.partitions != nil {
+		e.partitions.start()
+	}
 	return nil
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (e *fileExporter) Shutdown(context.Context) error {
+	if e.partitions != nil {
+		return e.partitions.shutdown()
+	}
 	return e.file.Close()
 }
 
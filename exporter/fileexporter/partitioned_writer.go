@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// partitionCheckInterval is how often files belonging to a past time bucket
+// are closed, even if no more data is written.
+const partitionCheckInterval = time.Second
+
+// partitionFile is a file the data of a single partition is written to.
+type partitionFile struct {
+	bucket string
+	// writer holds the file, so that the same export functions can be used
+	// as for a single file.
+	writer *fileExporter
+}
+
+// partitionedWriter writes data to the files rendered from a path template,
+// closing them once their time bucket is over.
+type partitionedWriter struct {
+	template *pathTemplate
+	rotation *Rotation
+	now      func() time.Time
+
+	mutex sync.Mutex
+	files map[string]*partitionFile
+
+	stopCh     chan struct{}
+	shutdownWg sync.WaitGroup
+}
+
+func newPartitionedWriter(template *pathTemplate, rotation *Rotation) *partitionedWriter {
+	return &partitionedWriter{
+		template: template,
+		rotation: rotation,
+		now:      func() time.Time { return time.Now().UTC() },
+		files:    map[string]*partitionFile{},
+		stopCh:   make(chan struct{}),
+	}
+}
+
+func (w *partitionedWriter) start() {
+	w.shutdownWg.Add(1)
+	go func() {
+		defer w.shutdownWg.Done()
+		ticker := time.NewTicker(partitionCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.mutex.Lock()
+				// there is no export to report a failure to close to
+				_ = w.closeStale(w.template.bucket(w.now()))
+				w.mutex.Unlock()
+			case <-w.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// export writes buf to the file at path using the given export function.
+func (w *partitionedWriter) export(path string, bucket string, buf []byte, export exportFunc) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	err := w.closeStale(bucket)
+	file, ok := w.files[path]
+	if !ok {
+		writer, openErr := w.open(path)
+		if openErr != nil {
+			return multierr.Append(err, openErr)
+		}
+		file = &partitionFile{bucket: bucket, writer: writer}
+		w.files[path] = file
+	}
+	return multierr.Append(err, export(file.writer, buf))
+}
+
+func (w *partitionedWriter) open(path string) (*fileExporter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := buildFileWriter(&Config{Path: path, Rotation: w.rotation})
+	if err != nil {
+		return nil, err
+	}
+	return &fileExporter{path: path, file: file}, nil
+}
+
+// closeStale closes the files of any time bucket other than the current one.
+// It must be called with the lock held.
+func (w *partitionedWriter) closeStale(bucket string) error {
+	var errs error
+	for path, file := range w.files {
+		if file.bucket != bucket {
+			errs = multierr.Append(errs, file.writer.file.Close())
+			delete(w.files, path)
+		}
+	}
+	return errs
+}
+
+// shutdown stops closing stale files and closes all the open ones.
+func (w *partitionedWriter) shutdown() error {
+	close(w.stopCh)
+	w.shutdownWg.Wait()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	var errs error
+	for path, file := range w.files {
+		errs = multierr.Append(errs, file.writer.file.Close())
+		delete(w.files, path)
+	}
+	return errs
+}
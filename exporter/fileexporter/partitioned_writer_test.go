@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// fakeClock is a settable clock for the partitioned writer.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func newPartitionedFileExporter(t *testing.T, conf *Config, clock *fakeClock) *fileExporter {
+	writer, partitions, err := buildWriters(conf)
+	require.NoError(t, err)
+	require.Nil(t, writer)
+	require.NotNil(t, partitions)
+	partitions.now = clock.Now
+	return &fileExporter{
+		path:             conf.Path,
+		formatType:       conf.FormatType,
+		partitions:       partitions,
+		tracesMarshaler:  tracesMarshalers[conf.FormatType],
+		metricsMarshaler: metricsMarshalers[conf.FormatType],
+		logsMarshaler:    logsMarshalers[conf.FormatType],
+		exporter:         buildExportFunc(conf),
+		compression:      conf.Compression,
+		compressor:       buildCompressor(conf.Compression),
+	}
+}
+
+func tracesWithServices(services ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range services {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func readJSONLines(t *testing.T, path string) [][]byte {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var lines [][]byte
+	br := bufio.NewReader(f)
+	for {
+		buf, isEnd, err := readJSONMessage(br)
+		require.NoError(t, err)
+		if isEnd {
+			return lines
+		}
+		lines = append(lines, append([]byte(nil), buf...))
+	}
+}
+
+func TestPartitionedTracesByResourceAttribute(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:         filepath.Join(dir, "%Y", "%m", "%d", "%H", "%{service.name}", "traces.json"),
+		PathTemplate: true,
+		FormatType:   formatTypeJSON,
+	}
+	clock := &fakeClock{now: time.Date(2022, time.December, 5, 7, 30, 0, 0, time.UTC)}
+	fe := newPartitionedFileExporter(t, conf, clock)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, fe.ConsumeTraces(context.Background(), tracesWithServices("checkout", "cart", "checkout")))
+	require.NoError(t, fe.ConsumeTraces(context.Background(), tracesWithServices("cart")))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	unmarshaler := &ptrace.JSONUnmarshaler{}
+	checkout := readJSONLines(t, filepath.Join(dir, "2022", "12", "05", "07", "checkout", "traces.json"))
+	require.Len(t, checkout, 1)
+	td, err := unmarshaler.UnmarshalTraces(checkout[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+
+	cart := readJSONLines(t, filepath.Join(dir, "2022", "12", "05", "07", "cart", "traces.json"))
+	require.Len(t, cart, 2)
+	for _, line := range cart {
+		td, err = unmarshaler.UnmarshalTraces(line)
+		require.NoError(t, err)
+		assert.Equal(t, 1, td.ResourceSpans().Len())
+	}
+}
+
+func TestPartitionedFilesClosedOnTimeBoundary(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:         filepath.Join(dir, "%H", "logs.json"),
+		PathTemplate: true,
+		FormatType:   formatTypeJSON,
+	}
+	clock := &fakeClock{now: time.Date(2022, time.December, 5, 7, 59, 59, 0, time.UTC)}
+	fe := newPartitionedFileExporter(t, conf, clock)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, fe.Shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	require.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+
+	fe.partitions.mutex.Lock()
+	assert.Len(t, fe.partitions.files, 1)
+	fe.partitions.mutex.Unlock()
+
+	// once the hour is over, the file of the previous hour gets closed even
+	// without any new data
+	clock.Set(clock.Now().Add(time.Second))
+	assert.Eventually(t, func() bool {
+		fe.partitions.mutex.Lock()
+		defer fe.partitions.mutex.Unlock()
+		return len(fe.partitions.files) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, readJSONLines(t, filepath.Join(dir, "07", "logs.json")), 1)
+
+	require.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	fe.partitions.mutex.Lock()
+	assert.Contains(t, fe.partitions.files, filepath.Join(dir, "08", "logs.json"))
+	fe.partitions.mutex.Unlock()
+}
+
+func TestPartitionedMetricsWithRotationAndCompression(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:         filepath.Join(dir, "%Y%m%d", "metrics"),
+		PathTemplate: true,
+		FormatType:   formatTypeProto,
+		Compression:  compressionZSTD,
+		Rotation:     &Rotation{MaxMegabytes: 1, MaxBackups: defaultMaxBackups},
+	}
+	clock := &fakeClock{now: time.Date(2022, time.December, 5, 7, 30, 0, 0, time.UTC)}
+	fe := newPartitionedFileExporter(t, conf, clock)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	require.NoError(t, fe.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	f, err := os.Open(filepath.Join(dir, "20221205", "metrics"))
+	require.NoError(t, err)
+	defer f.Close()
+	buf, isEnd, err := readMessageFromStream(bufio.NewReader(f))
+	require.NoError(t, err)
+	require.False(t, isEnd)
+	buf, err = decompress(buf)
+	require.NoError(t, err)
+	got, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// missingAttributeValue replaces the resource attributes referenced in the
+// path that are not set on a resource.
+const missingAttributeValue = "unknown"
+
+// timeDirectives maps the supported strftime-like directives to their Go
+// time layout.
+var timeDirectives = map[byte]string{
+	'Y': "2006",
+	'm': "01",
+	'd': "02",
+	'H': "15",
+	'M': "04",
+	'S': "05",
+}
+
+type tokenKind int
+
+const (
+	literalToken tokenKind = iota
+	timeToken
+	attributeToken
+)
+
+type pathToken struct {
+	kind tokenKind
+	// value is the literal text, the Go time layout or the attribute name,
+	// depending on the kind of the token.
+	value string
+}
+
+// pathTemplate is a path containing time directives, like %Y or %H, and
+// resource attribute placeholders, like %{service.name}.
+type pathTemplate struct {
+	tokens []pathToken
+}
+
+// parsePathTemplate parses the path, using %% to escape a literal %.
+func parsePathTemplate(path string) (*pathTemplate, error) {
+	tmpl := &pathTemplate{}
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			tmpl.tokens = append(tmpl.tokens, pathToken{kind: literalToken, value: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(path); i++ {
+		if path[i] != '%' {
+			literal.WriteByte(path[i])
+			continue
+		}
+		if i+1 == len(path) {
+			return nil, fmt.Errorf("path %q ends with an incomplete directive", path)
+		}
+		i++
+		switch c := path[i]; {
+		case c == '%':
+			literal.WriteByte('%')
+		case c == '{':
+			end := strings.IndexByte(path[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unterminated resource attribute placeholder", path)
+			}
+			name := path[i+1 : i+end]
+			if name == "" {
+				return nil, fmt.Errorf("path %q has an empty resource attribute placeholder", path)
+			}
+			flushLiteral()
+			tmpl.tokens = append(tmpl.tokens, pathToken{kind: attributeToken, value: name})
+			i += end
+		default:
+			layout, ok := timeDirectives[c]
+			if !ok {
+				return nil, fmt.Errorf("path %q has an unsupported directive %%%c", path, c)
+			}
+			flushLiteral()
+			tmpl.tokens = append(tmpl.tokens, pathToken{kind: timeToken, value: layout})
+		}
+	}
+	flushLiteral()
+	return tmpl, nil
+}
+
+// isStatic returns whether the template always renders the same path.
+func (t *pathTemplate) isStatic() bool {
+	for _, token := range t.tokens {
+		if token.kind != literalToken {
+			return false
+		}
+	}
+	return true
+}
+
+// render returns the path for data of the given resource at the given time.
+func (t *pathTemplate) render(now time.Time, resource pcommon.Resource) string {
+	var sb strings.Builder
+	for _, token := range t.tokens {
+		switch token.kind {
+		case literalToken:
+			sb.WriteString(token.value)
+		case timeToken:
+			sb.WriteString(now.Format(token.value))
+		case attributeToken:
+			sb.WriteString(attributePathValue(resource, token.value))
+		}
+	}
+	return sb.String()
+}
+
+// bucket returns the time bucket the given time falls in, that is the part of
+// the path that only depends on time.
+func (t *pathTemplate) bucket(now time.Time) string {
+	var sb strings.Builder
+	for _, token := range t.tokens {
+		if token.kind == timeToken {
+			sb.WriteString(now.Format(token.value))
+			sb.WriteByte('/')
+		}
+	}
+	return sb.String()
+}
+
+// attributePathValue returns the value of the resource attribute, made safe to
+// be used as part of a path.
+func attributePathValue(resource pcommon.Resource, name string) string {
+	v, ok := resource.Attributes().Get(name)
+	if !ok || v.AsString() == "" {
+		return missingAttributeValue
+	}
+	value := strings.NewReplacer("/", "_", "\\", "_").Replace(v.AsString())
+	if value == "." || value == ".." {
+		return strings.Repeat("_", len(value))
+	}
+	return value
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestPathTemplateRender(t *testing.T) {
+	now := time.Date(2022, time.December, 5, 7, 8, 9, 0, time.UTC)
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("k8s.namespace.name", "../shop")
+	resource.Attributes().PutInt("shard", 3)
+
+	tests := []struct {
+		path     string
+		expected string
+		bucket   string
+		static   bool
+	}{
+		{
+			path:     "./foo.json",
+			expected: "./foo.json",
+			static:   true,
+		},
+		{
+			path:     "/data/100%%/foo.json",
+			expected: "/data/100%/foo.json",
+			static:   true,
+		},
+		{
+			path:     "/data/%Y/%m/%d/%H/traces.json",
+			expected: "/data/2022/12/05/07/traces.json",
+			bucket:   "2022/12/05/07/",
+		},
+		{
+			path:     "/data/%Y%m%dT%H%M%S.json",
+			expected: "/data/20221205T070809.json",
+			bucket:   "2022/12/05/07/08/09/",
+		},
+		{
+			path:     "/data/%{service.name}/%H/%{shard}.json",
+			expected: "/data/checkout/07/3.json",
+			bucket:   "07/",
+		},
+		{
+			path:     "/data/%{k8s.namespace.name}/%{host.name}.json",
+			expected: "/data/.._shop/unknown.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			tmpl, err := parsePathTemplate(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.static, tmpl.isStatic())
+			assert.Equal(t, tt.expected, tmpl.render(now, resource))
+			assert.Equal(t, tt.bucket, tmpl.bucket(now))
+		})
+	}
+}
+
+func TestParsePathTemplateErrors(t *testing.T) {
+	tests := []struct {
+		path         string
+		errorMessage string
+	}{
+		{
+			path:         "/data/%",
+			errorMessage: `path "/data/%" ends with an incomplete directive`,
+		},
+		{
+			path:         "/data/%y.json",
+			errorMessage: `path "/data/%y.json" has an unsupported directive %y`,
+		},
+		{
+			path:         "/data/%{service.name.json",
+			errorMessage: `path "/data/%{service.name.json" has an unterminated resource attribute placeholder`,
+		},
+		{
+			path:         "/data/%{}.json",
+			errorMessage: `path "/data/%{}.json" has an empty resource attribute placeholder`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := parsePathTemplate(tt.path)
+			assert.EqualError(t, err, tt.errorMessage)
+		})
+	}
+}
+
+func TestAttributePathValue(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("empty", "")
+	resource.Attributes().PutStr("dot", ".")
+	resource.Attributes().PutStr("windows", `c:\temp`)
+
+	assert.Equal(t, missingAttributeValue, attributePathValue(resource, "empty"))
+	assert.Equal(t, missingAttributeValue, attributePathValue(resource, "missing"))
+	assert.Equal(t, "_", attributePathValue(resource, "dot"))
+	assert.Equal(t, "c:_temp", attributePathValue(resource, "windows"))
+}
@@ -50,6 +50,19 @@ type Config struct {
 
 	// Authentication defines used authentication mechanism.
 	Authentication Authentication `mapstructure:"auth"`
+
+	// PartitionTracesByID splits traces so that each message holds the spans of
+	// a single trace, keyed by the trace ID, so that all spans of a trace land in
+	// the same partition.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
+	// PartitionMetricsByResourceAttribute, when set, splits metrics per value of
+	// the given resource attribute and uses that value as the message key.
+	PartitionMetricsByResourceAttribute string `mapstructure:"partition_metrics_by_resource_attribute"`
+
+	// PartitionLogsByResourceAttribute, when set, splits logs per value of
+	// the given resource attribute and uses that value as the message key.
+	PartitionLogsByResourceAttribute string `mapstructure:"partition_logs_by_resource_attribute"`
 }
 
 // Metadata defines configuration for retrieving metadata from the broker.
@@ -57,9 +57,12 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
+				Topic:                               "spans",
+				Encoding:                            "otlp_proto",
+				PartitionTracesByID:                 true,
+				PartitionMetricsByResourceAttribute: "service.name",
+				PartitionLogsByResourceAttribute:    "service.name",
+				Brokers:                             []string{"foo:123", "bar:456"},
 				Authentication: Authentication{
 					PlainText: &PlainTextConfig{
 						Username: "jdoe",
@@ -182,7 +182,8 @@ func (f *kafkaExporterFactory) createMetricsExporter(
 		set,
 		&oCfg,
 		exp.metricsDataPusher,
-		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Partitioning by resource attribute moves the resources into new batches.
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: oCfg.PartitionMetricsByResourceAttribute != ""}),
 		// Disable exporterhelper Timeout, because we cannot pass a Context to the Producer,
 		// and will rely on the sarama Producer Timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -212,7 +213,8 @@ func (f *kafkaExporterFactory) createLogsExporter(
 		set,
 		&oCfg,
 		exp.logsDataPusher,
-		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Partitioning by resource attribute moves the resources into new batches.
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: oCfg.PartitionLogsByResourceAttribute != ""}),
 		// Disable exporterhelper Timeout, because we cannot pass a Context to the Producer,
 		// and will rely on the sarama Producer Timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -21,21 +21,27 @@ import (
 
 	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
 var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer            sarama.SyncProducer
+	topic               string
+	marshaler           TracesMarshaler
+	partitionTracesByID bool
+	logger              *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -48,21 +54,26 @@ func (ke kafkaErrors) Error() string {
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	if !e.partitionTracesByID {
+		messages, err := e.marshaler.Marshal(td, e.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		return sendMessages(e.producer, messages)
 	}
-	err = e.producer.SendMessages(messages)
-	if err != nil {
-		var prodErr sarama.ProducerErrors
-		if errors.As(err, &prodErr) {
-			if len(prodErr) > 0 {
-				return kafkaErrors{len(prodErr), prodErr[0].Err.Error()}
-			}
+
+	var messages []*sarama.ProducerMessage
+	for _, trace := range batchpersignal.SplitTraces(td) {
+		msgs, err := e.marshaler.Marshal(trace, e.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
 		}
-		return err
+		// SplitTraces returns batches with at least one span, all sharing the same trace ID.
+		traceID := trace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+		setMessagesKey(msgs, traceID.String())
+		messages = append(messages, msgs...)
 	}
-	return nil
+	return sendMessages(e.producer, messages)
 }
 
 func (e *kafkaTracesProducer) Close(context.Context) error {
@@ -74,25 +85,35 @@ type kafkaMetricsProducer struct {
 	producer  sarama.SyncProducer
 	topic     string
 	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	// partitionAttribute is the resource attribute used as the message key, if any.
+	partitionAttribute string
+	logger             *zap.Logger
+}
+
+var _ consumer.Metrics = (*kafkaMetricsProducer)(nil)
+
+func (e *kafkaMetricsProducer) metricsDataPusher(ctx context.Context, md pmetric.Metrics) error {
+	if e.partitionAttribute == "" {
+		return e.ConsumeMetrics(ctx, md)
+	}
+	// Split the batch so that each message only holds resources sharing the same attribute value.
+	return batchperresourceattr.NewBatchPerResourceMetrics(e.partitionAttribute, e).ConsumeMetrics(ctx, md)
 }
 
-func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
+func (e *kafkaMetricsProducer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeMetrics sends the metrics to kafka, keyed by the partition attribute if configured.
+func (e *kafkaMetricsProducer) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
 	messages, err := e.marshaler.Marshal(md, e.topic)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	err = e.producer.SendMessages(messages)
-	if err != nil {
-		var prodErr sarama.ProducerErrors
-		if errors.As(err, &prodErr) {
-			if len(prodErr) > 0 {
-				return kafkaErrors{len(prodErr), prodErr[0].Err.Error()}
-			}
-		}
-		return err
+	if e.partitionAttribute != "" && md.ResourceMetrics().Len() > 0 {
+		setMessagesKey(messages, resourceAttributeKey(md.ResourceMetrics().At(0).Resource(), e.partitionAttribute))
 	}
-	return nil
+	return sendMessages(e.producer, messages)
 }
 
 func (e *kafkaMetricsProducer) Close(context.Context) error {
@@ -104,15 +125,43 @@ type kafkaLogsProducer struct {
 	producer  sarama.SyncProducer
 	topic     string
 	marshaler LogsMarshaler
-	logger    *zap.Logger
+	// partitionAttribute is the resource attribute used as the message key, if any.
+	partitionAttribute string
+	logger             *zap.Logger
 }
 
-func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
+var _ consumer.Logs = (*kafkaLogsProducer)(nil)
+
+func (e *kafkaLogsProducer) logsDataPusher(ctx context.Context, ld plog.Logs) error {
+	if e.partitionAttribute == "" {
+		return e.ConsumeLogs(ctx, ld)
+	}
+	// Split the batch so that each message only holds resources sharing the same attribute value.
+	return batchperresourceattr.NewBatchPerResourceLogs(e.partitionAttribute, e).ConsumeLogs(ctx, ld)
+}
+
+func (e *kafkaLogsProducer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs sends the logs to kafka, keyed by the partition attribute if configured.
+func (e *kafkaLogsProducer) ConsumeLogs(_ context.Context, ld plog.Logs) error {
 	messages, err := e.marshaler.Marshal(ld, e.topic)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	err = e.producer.SendMessages(messages)
+	if e.partitionAttribute != "" && ld.ResourceLogs().Len() > 0 {
+		setMessagesKey(messages, resourceAttributeKey(ld.ResourceLogs().At(0).Resource(), e.partitionAttribute))
+	}
+	return sendMessages(e.producer, messages)
+}
+
+func (e *kafkaLogsProducer) Close(context.Context) error {
+	return e.producer.Close()
+}
+
+func sendMessages(producer sarama.SyncProducer, messages []*sarama.ProducerMessage) error {
+	err := producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -125,8 +174,27 @@ func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) erro
 	return nil
 }
 
-func (e *kafkaLogsProducer) Close(context.Context) error {
-	return e.producer.Close()
+// setMessagesKey sets the key of the messages that were not already keyed by the marshaler.
+// An empty key leaves the messages unkeyed, so that they are spread across partitions.
+func setMessagesKey(messages []*sarama.ProducerMessage, key string) {
+	if key == "" {
+		return
+	}
+	for _, message := range messages {
+		if message.Key == nil {
+			message.Key = sarama.StringEncoder(key)
+		}
+	}
+}
+
+// resourceAttributeKey returns the value of the attribute of the resource, or an empty string
+// if the resource does not have it. Like batchperresourceattr, only string values are considered.
+func resourceAttributeKey(resource pcommon.Resource, attribute string) string {
+	value, ok := resource.Attributes().Get(attribute)
+	if !ok {
+		return ""
+	}
+	return value.Str()
 }
 
 func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
@@ -179,10 +247,11 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 	}
 
 	return &kafkaMetricsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:           producer,
+		topic:              config.Topic,
+		marshaler:          marshaler,
+		partitionAttribute: config.PartitionMetricsByResourceAttribute,
+		logger:             set.Logger,
 	}, nil
 
 }
@@ -198,10 +267,11 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:            producer,
+		topic:               config.Topic,
+		marshaler:           marshaler,
+		partitionTracesByID: config.PartitionTracesByID,
+		logger:              set.Logger,
 	}, nil
 }
 
@@ -216,10 +286,11 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 	}
 
 	return &kafkaLogsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:           producer,
+		topic:              config.Topic,
+		marshaler:          marshaler,
+		partitionAttribute: config.PartitionLogsByResourceAttribute,
+		logger:             set.Logger,
 	}, nil
 
 }
@@ -151,6 +151,44 @@ func TestTracesPusher(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTracesPusher_partitionByID(t *testing.T) {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	spans.AppendEmpty().SetTraceID([16]byte{2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	spans.AppendEmpty().SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	keys := map[string]int{}
+	checker := func(msg *sarama.ProducerMessage) error {
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		trace, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(value)
+		require.NoError(t, err)
+		keys[string(key)] = trace.SpanCount()
+		return nil
+	}
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+
+	p := kafkaTracesProducer{
+		producer:            producer,
+		marshaler:           newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+		partitionTracesByID: true,
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.tracesPusher(context.Background(), td))
+	assert.Equal(t, map[string]int{
+		"0102030405060708090a0b0c0d0e0f10": 2,
+		"0202030405060708090a0b0c0d0e0f10": 1,
+	}, keys)
+}
+
 func TestTracesPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
@@ -198,6 +236,49 @@ func TestMetricsDataPusher(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMetricsDataPusher_partitionByResourceAttribute(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"foo", "bar", "foo", ""} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if service != "" {
+			rm.Resource().Attributes().PutStr("service.name", service)
+		}
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	keys := map[string]int{}
+	checker := func(msg *sarama.ProducerMessage) error {
+		key := "<nil>"
+		if msg.Key != nil {
+			bts, err := msg.Key.Encode()
+			require.NoError(t, err)
+			key = string(bts)
+		}
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		metrics, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(value)
+		require.NoError(t, err)
+		keys[key] = metrics.ResourceMetrics().Len()
+		return nil
+	}
+	for i := 0; i < 3; i++ {
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	}
+
+	p := kafkaMetricsProducer{
+		producer:           producer,
+		marshaler:          newPdataMetricsMarshaler(&pmetric.ProtoMarshaler{}, defaultEncoding),
+		partitionAttribute: "service.name",
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.metricsDataPusher(context.Background(), md))
+	assert.Equal(t, map[string]int{"foo": 2, "bar": 1, "<nil>": 1}, keys)
+}
+
 func TestMetricsDataPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
@@ -245,6 +326,42 @@ func TestLogsDataPusher(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLogsDataPusher_partitionByResourceAttribute(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, service := range []string{"foo", "bar", "foo"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	}
+
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	keys := map[string]int{}
+	checker := func(msg *sarama.ProducerMessage) error {
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		logs, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(value)
+		require.NoError(t, err)
+		keys[string(key)] = logs.LogRecordCount()
+		return nil
+	}
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+
+	p := kafkaLogsProducer{
+		producer:           producer,
+		marshaler:          newPdataLogsMarshaler(&plog.ProtoMarshaler{}, defaultEncoding),
+		partitionAttribute: "service.name",
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.logsDataPusher(context.Background(), ld))
+	assert.Equal(t, map[string]int{"foo": 2, "bar": 1}, keys)
+}
+
 func TestLogsDataPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
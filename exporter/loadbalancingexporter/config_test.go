@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -34,3 +35,20 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestLoadK8sResolverConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(typeStr, "4").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	lbCfg := cfg.(*Config)
+	assert.Equal(t, "resource", lbCfg.RoutingKey)
+	require.NotNil(t, lbCfg.Resolver.K8s)
+	assert.Equal(t, "lb-svc.lb-ns", lbCfg.Resolver.K8s.Service)
+	assert.Equal(t, []int32{15317, 16317}, lbCfg.Resolver.K8s.Ports)
+}
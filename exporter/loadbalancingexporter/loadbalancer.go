@@ -23,6 +23,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -62,7 +64,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg component.Config, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -84,6 +86,24 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg component.Conf
 		}
 	}
 
+	if oCfg.Resolver.K8s != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s service"))
+
+		apiCfg := oCfg.Resolver.K8s.APIConfig
+		if apiCfg.AuthType == "" {
+			apiCfg.AuthType = k8sconfig.AuthTypeServiceAccount
+		}
+		client, err := k8sconfig.MakeClient(apiCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = newK8sResolver(k8sLogger, client, oCfg.Resolver.K8s.Service, oCfg.Resolver.K8s.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -96,6 +116,20 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg component.Conf
 	}, nil
 }
 
+func countResolvers(settings ResolverSettings) int {
+	count := 0
+	if settings.Static != nil {
+		count++
+	}
+	if settings.DNS != nil {
+		count++
+	}
+	if settings.K8s != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
@@ -170,9 +204,9 @@ func endpointFound(endpoint string, endpoints []string) bool {
 	return false
 }
 
-func (lb *loadBalancerImp) Shutdown(context.Context) error {
+func (lb *loadBalancerImp) Shutdown(ctx context.Context) error {
 	lb.stopped = true
-	return nil
+	return lb.res.shutdown(ctx)
 }
 
 func (lb *loadBalancerImp) Endpoint(identifier []byte) string {
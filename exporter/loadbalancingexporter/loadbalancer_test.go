@@ -152,6 +152,26 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestMultipleResolversWithK8s(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			DNS: &DNSResolver{
+				Hostname: "service-1",
+			},
+			K8s: &K8sSvcResolver{
+				Service: "lb-svc.lb-ns",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -187,6 +207,30 @@ func TestLoadBalancerShutdown(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestLoadBalancerShutdownStopsResolver(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	shutdownCalled := false
+	expectedErr := errors.New("some expected error")
+	p.res = &mockResolver{
+		onShutdown: func(context.Context) error {
+			shutdownCalled = true
+			return expectedErr
+		},
+	}
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.True(t, shutdownCalled)
+	assert.Equal(t, expectedErr, res)
+}
+
 func TestOnBackendChanges(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
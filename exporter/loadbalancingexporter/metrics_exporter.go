@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg component.Config) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Component, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: resourceRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "service":
+		metricExporter.routingKey = svcRouting
+	case "metric":
+		metricExporter.routingKey = metricNameRouting
+	case "resource", "":
+	default:
+		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(ctx context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return e.loadBalancer.Shutdown(ctx)
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	batches, err := splitMetrics(md, e.routingKey)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for rid, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, rid, batch))
+	}
+
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, rid string, md pmetric.Metrics) error {
+	endpoint := e.loadBalancer.Endpoint([]byte(rid))
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// splitMetrics groups the metrics by their routing identifier, so that each
+// group can be sent to a single backend.
+func splitMetrics(md pmetric.Metrics, key routingKey) (map[string]pmetric.Metrics, error) {
+	if key == metricNameRouting {
+		return splitMetricsByName(md), nil
+	}
+
+	batches := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+
+		var rid string
+		if key == svcRouting {
+			svc, ok := rm.Resource().Attributes().Get("service.name")
+			if !ok {
+				return nil, errors.New("unable to get service name")
+			}
+			rid = svc.Str()
+		} else {
+			rid = resourceIdentifier(rm.Resource())
+		}
+
+		batch, ok := batches[rid]
+		if !ok {
+			batch = pmetric.NewMetrics()
+			batches[rid] = batch
+		}
+		rm.CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+	return batches, nil
+}
+
+// splitMetricsByName creates one batch per metric name, keeping the resource
+// and scope of each metric.
+func splitMetricsByName(md pmetric.Metrics) map[string]pmetric.Metrics {
+	type nameBatch struct {
+		md pmetric.Metrics
+		// the resource and scope metrics of the last metric added to this batch,
+		// so that metrics from the same scope end up under the same parent
+		rm       pmetric.ResourceMetrics
+		sm       pmetric.ScopeMetrics
+		rmIdx    int
+		smIdx    int
+		hasScope bool
+	}
+
+	batches := make(map[string]*nameBatch)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+
+				batch, ok := batches[m.Name()]
+				if !ok {
+					batch = &nameBatch{md: pmetric.NewMetrics()}
+					batches[m.Name()] = batch
+				}
+				if !batch.hasScope || batch.rmIdx != i {
+					batch.rm = batch.md.ResourceMetrics().AppendEmpty()
+					rm.Resource().CopyTo(batch.rm.Resource())
+					batch.rm.SetSchemaUrl(rm.SchemaUrl())
+					batch.rmIdx, batch.hasScope = i, false
+				}
+				if !batch.hasScope || batch.smIdx != j {
+					batch.sm = batch.rm.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(batch.sm.Scope())
+					batch.sm.SetSchemaUrl(sm.SchemaUrl())
+					batch.smIdx, batch.hasScope = j, true
+				}
+				m.CopyTo(batch.sm.Metrics().AppendEmpty())
+			}
+		}
+	}
+
+	result := make(map[string]pmetric.Metrics, len(batches))
+	for name, batch := range batches {
+		result[name] = batch.md
+	}
+	return result
+}
+
+// resourceIdentifier builds a stable identifier out of the resource attributes,
+// regardless of the order in which they were added.
+func resourceIdentifier(res pcommon.Resource) string {
+	attrs := make([]string, 0, res.Attributes().Len())
+	res.Attributes().Range(func(k string, v pcommon.Value) bool {
+		attrs = append(attrs, k+"="+v.AsString())
+		return true
+	})
+	sort.Strings(attrs)
+	return strings.Join(attrs, ";")
+}
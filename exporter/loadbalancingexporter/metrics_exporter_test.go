@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+			},
+			errNoResolver,
+		},
+		{
+			"unsupported routing key",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "traceID"
+				return cfg
+			}(),
+			errors.New("unsupported routing_key: traceID"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterStart(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NoError(t, err)
+
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), nil)
+	require.NoError(t, err)
+	lb.res = &mockResolver{
+		onStart: func(context.Context) error {
+			return errors.New("some expected err")
+		},
+	}
+	p.loadBalancer = lb
+
+	// test
+	res := p.Start(context.Background(), componenttest.NewNopHost())
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	require.Equal(t, errors.New("some expected err"), res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestMetricsExporter(t, simpleConfig(), newMockMetricsExporter(sink.ConsumeMetrics))
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoResourcesMetrics())
+
+	// verify
+	assert.Nil(t, res)
+	assert.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, 4, sink.DataPointCount())
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	p := newTestMetricsExporter(t, simpleConfig(), newNopMockExporter())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoResourcesMetrics())
+
+	// verify
+	assert.Error(t, res)
+	assert.Contains(t, res.Error(), fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func TestConsumeMetricsServiceBasedWithoutServiceName(t *testing.T) {
+	cfg := serviceBasedRoutingConfig()
+	p := newTestMetricsExporter(t, cfg, newMockMetricsExporter(nil))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	assert.EqualError(t, res, "unable to get service name")
+}
+
+func TestSplitMetricsByResource(t *testing.T) {
+	md := twoResourcesMetrics()
+	// same attributes as the first resource, in a different order
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("k8s.pod.name", "pod-1")
+	rm.Resource().Attributes().PutStr("service.name", "svc-1")
+	appendGauge(rm.ScopeMetrics().AppendEmpty(), "requests")
+
+	// test
+	batches, err := splitMetrics(md, resourceRouting)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	first := batches[resourceIdentifier(md.ResourceMetrics().At(0).Resource())]
+	assert.Equal(t, 2, first.ResourceMetrics().Len())
+	second := batches[resourceIdentifier(md.ResourceMetrics().At(1).Resource())]
+	assert.Equal(t, 1, second.ResourceMetrics().Len())
+}
+
+func TestSplitMetricsByService(t *testing.T) {
+	md := twoResourcesMetrics()
+	md.ResourceMetrics().At(1).Resource().Attributes().PutStr("service.name", "svc-1")
+
+	// test
+	batches, err := splitMetrics(md, svcRouting)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	assert.Equal(t, 2, batches["svc-1"].ResourceMetrics().Len())
+}
+
+func TestSplitMetricsByName(t *testing.T) {
+	md := twoResourcesMetrics()
+
+	// test
+	batches, err := splitMetrics(md, metricNameRouting)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+
+	requests := batches["requests"]
+	require.Equal(t, 2, requests.ResourceMetrics().Len())
+	for i := 0; i < requests.ResourceMetrics().Len(); i++ {
+		rm := requests.ResourceMetrics().At(i)
+		require.Equal(t, 1, rm.ScopeMetrics().Len())
+		assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+		assert.Equal(t, 1, rm.ScopeMetrics().At(0).Metrics().Len())
+	}
+	svc, _ := requests.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "svc-1", svc.Str())
+
+	latency := batches["latency"]
+	require.Equal(t, 2, latency.ResourceMetrics().Len())
+	assert.Equal(t, "latency", latency.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestResourceIdentifierIsStable(t *testing.T) {
+	first := pmetric.NewResourceMetrics()
+	first.Resource().Attributes().PutStr("a", "1")
+	first.Resource().Attributes().PutInt("b", 2)
+
+	second := pmetric.NewResourceMetrics()
+	second.Resource().Attributes().PutInt("b", 2)
+	second.Resource().Attributes().PutStr("a", "1")
+
+	assert.Equal(t, resourceIdentifier(first.Resource()), resourceIdentifier(second.Resource()))
+	assert.Equal(t, "a=1;b=2", resourceIdentifier(first.Resource()))
+}
+
+func newTestMetricsExporter(t *testing.T, cfg *Config, exp component.Component) *metricExporterImp {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return exp, nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(context.Background(), []string{"endpoint-1"})
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	})
+	return p
+}
+
+// twoResourcesMetrics returns metrics for two resources, each with a
+// "requests" and a "latency" gauge in a single scope.
+func twoResourcesMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, pod := range []string{"pod-1", "pod-2"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", "svc-1")
+		rm.Resource().Attributes().PutStr("k8s.pod.name", pod)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("scope")
+		appendGauge(sm, "requests")
+		appendGauge(sm, "latency")
+	}
+	md.ResourceMetrics().At(1).Resource().Attributes().Remove("service.name")
+	return md
+}
+
+func appendGauge(sm pmetric.ScopeMetrics, name string) {
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
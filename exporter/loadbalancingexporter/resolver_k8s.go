@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace = "default"
+	defaultSyncTimeout  = 5 * time.Second
+)
+
+var (
+	errNoSvc = errors.New("no service specified to resolve the backends")
+
+	k8sResolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "k8s")
+
+	k8sResolverSuccessTrueMutators  = []tag.Mutator{k8sResolverMutator, successTrueMutator}
+	k8sResolverSuccessFalseMutators = []tag.Mutator{k8sResolverMutator, successFalseMutator}
+)
+
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName     string
+	svcNs       string
+	ports       []int32
+	informer    cache.SharedInformer
+	syncTimeout time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan (struct{})
+	stopped            bool
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(logger *zap.Logger, client kubernetes.Interface, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	// the service is expected in the form "name.namespace", anything after
+	// the namespace (like ".svc.cluster.local") is ignored
+	parts := strings.Split(service, ".")
+	name, namespace := parts[0], defaultK8sNamespace
+	if len(parts) > 1 && len(parts[1]) > 0 {
+		namespace = parts[1]
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return client.CoreV1().Endpoints(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return client.CoreV1().Endpoints(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	r := &k8sResolver{
+		logger:      logger,
+		svcName:     name,
+		svcNs:       namespace,
+		ports:       ports,
+		informer:    cache.NewSharedInformer(lw, &corev1.Endpoints{}, 0),
+		syncTimeout: defaultSyncTimeout,
+		stopCh:      make(chan struct{}),
+	}
+
+	// the informer updates its store before notifying the handlers, so a
+	// resolution triggered by any change sees the latest state
+	onEvent := func(interface{}) {
+		if _, err := r.resolve(context.Background()); err != nil {
+			r.logger.Warn("failed to resolve", zap.Error(err))
+		}
+	}
+	r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onEvent,
+		UpdateFunc: func(_, obj interface{}) { onEvent(obj) },
+		DeleteFunc: onEvent,
+	})
+
+	return r, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	r.shutdownWg.Add(1)
+	go func() {
+		defer r.shutdownWg.Done()
+		r.informer.Run(r.stopCh)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), r.informer.HasSynced) {
+		r.logger.Warn("timed out waiting for the initial list of endpoints, backends will be added once available")
+	}
+
+	r.logger.Debug("K8s service resolver started",
+		zap.String("service", r.svcName), zap.String("namespace", r.svcNs),
+		zap.Int32s("ports", r.ports))
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	// resolutions triggered by the informer after this point are no-ops, so
+	// no new work is added to the wait group while we wait on it
+	r.updateLock.Lock()
+	r.stopped = true
+	r.updateLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.updateLock.Lock()
+	if r.stopped {
+		r.updateLock.Unlock()
+		return nil, nil
+	}
+	r.shutdownWg.Add(1)
+	r.updateLock.Unlock()
+	defer r.shutdownWg.Done()
+
+	var backends []string
+	for _, obj := range r.informer.GetStore().List() {
+		eps, ok := obj.(*corev1.Endpoints)
+		if !ok {
+			_ = stats.RecordWithTags(ctx, k8sResolverSuccessFalseMutators, mNumResolutions.M(1))
+			return nil, fmt.Errorf("unexpected object in the endpoints store: %T", obj)
+		}
+		if eps.Name != r.svcName {
+			continue
+		}
+		backends = append(backends, r.backendsFor(eps)...)
+	}
+
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	r.updateLock.Lock()
+	if equalStringSlice(r.endpoints, backends) {
+		r.updateLock.Unlock()
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+// backendsFor returns one backend per ready address of the endpoints and
+// configured port. Without ports, the load balancer adds the default one.
+func (r *k8sResolver) backendsFor(eps *corev1.Endpoints) []string {
+	var backends []string
+	for _, subset := range eps.Subsets {
+		for _, addr := range subset.Addresses {
+			if len(r.ports) == 0 {
+				backend := addr.IP
+				if strings.Contains(backend, ":") {
+					// it's an IPv6 address
+					backend = fmt.Sprintf("[%s]", backend)
+				}
+				backends = append(backends, backend)
+				continue
+			}
+			for _, port := range r.ports {
+				backends = append(backends, net.JoinHostPort(addr.IP, fmt.Sprint(port)))
+			}
+		}
+	}
+	return backends
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
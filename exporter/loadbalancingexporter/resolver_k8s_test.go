@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sResolverErrNoService(t *testing.T) {
+	res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), "", nil)
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sResolverServiceName(t *testing.T) {
+	for _, tt := range []struct {
+		service   string
+		name      string
+		namespace string
+	}{
+		{"lb", "lb", "default"},
+		{"lb.monitoring", "lb", "monitoring"},
+		{"lb.monitoring.svc.cluster.local", "lb", "monitoring"},
+	} {
+		t.Run(tt.service, func(t *testing.T) {
+			res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), tt.service, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, res.svcName)
+			assert.Equal(t, tt.namespace, res.svcNs)
+		})
+	}
+}
+
+func TestK8sResolverInitialResolution(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(
+		newEndpoints("lb", "monitoring", "10.0.0.2", "10.0.0.1"),
+		newEndpoints("other", "monitoring", "10.0.0.3"),
+	)
+	res, err := newK8sResolver(zap.NewNop(), client, "lb.monitoring", []int32{4317, 55690})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		resolved = endpoints
+		mu.Unlock()
+	})
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(resolved) == 4
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.1:55690", "10.0.0.2:4317", "10.0.0.2:55690"}, resolved)
+	mu.Unlock()
+}
+
+func TestK8sResolverOnChange(t *testing.T) {
+	// prepare
+	eps := newEndpoints("lb", "default", "10.0.0.1")
+	client := fake.NewSimpleClientset(eps)
+	res, err := newK8sResolver(zap.NewNop(), client, "lb", nil)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		resolved = endpoints
+		mu.Unlock()
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	current := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+	assert.Eventually(t, func() bool {
+		return equalStringSlice([]string{"10.0.0.1"}, current())
+	}, time.Second, 10*time.Millisecond)
+
+	// test: a new pod becomes ready
+	updated := newEndpoints("lb", "default", "10.0.0.1", "fd00::1")
+	_, err = client.CoreV1().Endpoints("default").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return equalStringSlice([]string{"10.0.0.1", "[fd00::1]"}, current())
+	}, time.Second, 10*time.Millisecond)
+
+	// test: the service is removed
+	err = client.CoreV1().Endpoints("default").Delete(context.Background(), "lb", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(current()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolverShutdownClearsCallbacks(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), "lb", nil)
+	require.NoError(t, err)
+	res.onChange(func(s []string) {})
+	require.NoError(t, res.start(context.Background()))
+
+	// test
+	require.NoError(t, res.shutdown(context.Background()))
+
+	// verify
+	assert.Len(t, res.onChangeCallbacks, 0)
+}
+
+func TestK8sResolverResolveAfterShutdown(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), "lb", nil)
+	require.NoError(t, err)
+	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.shutdown(context.Background()))
+
+	// test
+	backends, err := res.resolve(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Empty(t, backends)
+}
+
+func newEndpoints(name, namespace string, ips ...string) *corev1.Endpoints {
+	addresses := make([]corev1.EndpointAddress, len(ips))
+	for i, ip := range ips {
+		addresses[i] = corev1.EndpointAddress{IP: ip}
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []corev1.EndpointSubset{{Addresses: addresses}},
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the directory the Parquet files are written to. Each signal
+	// writes its own files, prefixed with the signal name.
+	Path string `mapstructure:"path"`
+
+	// MaxFileSizeMiB is the approximate size in mebibytes after which the
+	// current file is closed and a new one is started.
+	MaxFileSizeMiB int `mapstructure:"max_file_size_mib"`
+
+	// RolloverInterval is the maximum amount of time a file is kept open
+	// before it is closed and a new one is started. Zero disables time based
+	// rollover.
+	RolloverInterval time.Duration `mapstructure:"rollover_interval"`
+
+	// Compression is the codec used for the column chunks.
+	// Options: none, snappy[default], gzip, zstd.
+	Compression string `mapstructure:"compression"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if cfg.MaxFileSizeMiB <= 0 {
+		return errors.New("max_file_size_mib must be positive")
+	}
+	if cfg.RolloverInterval < 0 {
+		return errors.New("rollover_interval must not be negative")
+	}
+	switch cfg.Compression {
+	case compressionNone, compressionSnappy, compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	return nil
+}
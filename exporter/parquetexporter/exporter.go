@@ -16,33 +16,54 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
+const (
+	tracesPrefix  = "traces"
+	metricsPrefix = "metrics"
+	logsPrefix    = "logs"
+)
+
+// parquetExporter writes the data of a single signal to Parquet files.
 type parquetExporter struct {
-	path string
+	path   string
+	writer *rollingWriter
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, prefix string, schema interface{}, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{
+		path:   cfg.Path,
+		writer: newRollingWriter(cfg, prefix, schema, logger),
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(ctx context.Context, host component.Host) error {
+	if err := os.MkdirAll(e.path, 0750); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", e.path, err)
+	}
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func (e *parquetExporter) shutdown(ctx context.Context) error {
+	return e.writer.close()
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return e.writer.write(metricsToRows(md))
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.writer.write(tracesToRows(td))
+}
+
+func (e *parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	return e.writer.write(logsToRows(ld))
 }
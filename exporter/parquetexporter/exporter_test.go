@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newTestConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	cfg.RolloverInterval = 0
+	return cfg
+}
+
+func generateTraces(spanCount int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "test-service")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("test-scope")
+	ss.Scope().SetVersion("1.0.0")
+	for i := 0; i < spanCount; i++ {
+		span := ss.Spans().AppendEmpty()
+		span.SetName("operation")
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, byte(i)})
+		span.SetStartTimestamp(pcommon.Timestamp(1000))
+		span.SetEndTimestamp(pcommon.Timestamp(2000))
+		span.Attributes().PutInt("http.status_code", 200)
+		span.Status().SetCode(ptrace.StatusCodeOk)
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutStr("exception.type", "Error")
+	}
+	return td
+}
+
+func generateLogs(logCount int) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "test-service")
+	sl := rl.ScopeLogs().AppendEmpty()
+	for i := 0; i < logCount; i++ {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.Timestamp(1000))
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText("INFO")
+		lr.Body().SetStr("hello world")
+		lr.Attributes().PutBool("sampled", true)
+	}
+	return ld
+}
+
+func generateMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test-service")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("sum")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(10)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("histogram")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(6)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{2})
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("exponential_histogram")
+	edp := expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetCount(3)
+	edp.SetScale(2)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("summary")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetCount(1)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(1)
+
+	return md
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var files []string
+	for _, entry := range entries {
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	return files
+}
+
+func readRows[T any](t *testing.T, path string, schema *T) []T {
+	fr, err := local.NewLocalFileReader(path)
+	require.NoError(t, err)
+	defer fr.Close()
+	pr, err := reader.NewParquetReader(fr, schema, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	rows := make([]T, pr.GetNumRows())
+	require.NoError(t, pr.Read(&rows))
+	return rows
+}
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := newParquetExporter(cfg, tracesPrefix, new(spanRow), zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.consumeTraces(context.Background(), generateTraces(3)))
+
+	// The file is only visible under its final name once it is complete.
+	files := listFiles(t, cfg.Path)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0], inProgressSuffix))
+
+	require.NoError(t, exp.shutdown(context.Background()))
+	files = listFiles(t, cfg.Path)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasPrefix(filepath.Base(files[0]), tracesPrefix+"-"))
+	assert.True(t, strings.HasSuffix(files[0], fileSuffix))
+
+	rows := readRows(t, files[0], new(spanRow))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "operation", rows[0].Name)
+	assert.Equal(t, "Server", rows[0].Kind)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", rows[0].TraceID)
+	assert.Equal(t, map[string]string{"service.name": "test-service"}, rows[0].ResourceAttributes)
+	assert.Equal(t, "test-scope", rows[0].ScopeName)
+	assert.Equal(t, map[string]string{"http.status_code": "200"}, rows[0].Attributes)
+	require.Len(t, rows[0].Events, 1)
+	assert.Equal(t, "exception", rows[0].Events[0].Name)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := newParquetExporter(cfg, logsPrefix, new(logRow), zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.consumeLogs(context.Background(), generateLogs(2)))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := listFiles(t, cfg.Path)
+	require.Len(t, files, 1)
+	rows := readRows(t, files[0], new(logRow))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "hello world", rows[0].Body)
+	assert.Equal(t, "INFO", rows[0].SeverityText)
+	assert.Equal(t, int32(plog.SeverityNumberInfo), rows[0].SeverityNumber)
+	assert.Equal(t, map[string]string{"sampled": "true"}, rows[0].Attributes)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := newParquetExporter(cfg, metricsPrefix, new(metricRow), zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.consumeMetrics(context.Background(), generateMetrics()))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := listFiles(t, cfg.Path)
+	require.Len(t, files, 1)
+	rows := readRows(t, files[0], new(metricRow))
+	require.Len(t, rows, 5)
+
+	assert.Equal(t, "gauge", rows[0].MetricName)
+	require.NotNil(t, rows[0].DoubleValue)
+	assert.Equal(t, 1.5, *rows[0].DoubleValue)
+	assert.Nil(t, rows[0].IntValue)
+
+	assert.Equal(t, "sum", rows[1].MetricName)
+	require.NotNil(t, rows[1].IntValue)
+	assert.Equal(t, int64(10), *rows[1].IntValue)
+	require.NotNil(t, rows[1].IsMonotonic)
+	assert.True(t, *rows[1].IsMonotonic)
+
+	assert.Equal(t, "histogram", rows[2].MetricName)
+	assert.Equal(t, []int64{1, 2}, rows[2].BucketCounts)
+	assert.Equal(t, []float64{2}, rows[2].ExplicitBounds)
+
+	assert.Equal(t, "exponential_histogram", rows[3].MetricName)
+	require.NotNil(t, rows[3].Scale)
+	assert.Equal(t, int32(2), *rows[3].Scale)
+	assert.Equal(t, []int64{1, 2}, rows[3].PositiveBucketCounts)
+
+	assert.Equal(t, "summary", rows[4].MetricName)
+	assert.Equal(t, []float64{0.5}, rows[4].Quantiles)
+	assert.Equal(t, []float64{1}, rows[4].QuantileValues)
+}
+
+func TestRolloverBySize(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.MaxFileSizeMiB = 1
+	exp := newParquetExporter(cfg, logsPrefix, new(logRow), zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := generateLogs(100)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr(strings.Repeat("a", 2*1024*1024))
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := listFiles(t, cfg.Path)
+	require.Len(t, files, 2)
+	for _, file := range files {
+		assert.True(t, strings.HasSuffix(file, fileSuffix))
+	}
+}
+
+func TestRolloverByInterval(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.RolloverInterval = 10 * time.Millisecond
+	exp := newParquetExporter(cfg, logsPrefix, new(logRow), zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.consumeLogs(context.Background(), generateLogs(1)))
+
+	assert.Eventually(t, func() bool {
+		files := listFiles(t, cfg.Path)
+		return len(files) == 1 && strings.HasSuffix(files[0], fileSuffix)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, exp.consumeLogs(context.Background(), generateLogs(1)))
+	require.NoError(t, exp.shutdown(context.Background()))
+	assert.Len(t, listFiles(t, cfg.Path), 2)
+}
+
+func TestShutdownWithoutData(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := newParquetExporter(cfg, tracesPrefix, new(spanRow), zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.shutdown(context.Background()))
+	assert.Empty(t, listFiles(t, cfg.Path))
+}
@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,12 +28,10 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelDevelopment
-)
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
+	defaultMaxFileSizeMiB   = 128
+	defaultRolloverInterval = 5 * time.Minute
+)
 
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
@@ -47,6 +46,9 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+		MaxFileSizeMiB:   defaultMaxFileSizeMiB,
+		RolloverInterval: defaultRolloverInterval,
+		Compression:      compressionSnappy,
 	}
 }
 
@@ -55,7 +57,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg component.Config,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), tracesPrefix, new(spanRow), set.Logger)
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -71,7 +73,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg component.Config,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), metricsPrefix, new(metricRow), set.Logger)
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -87,7 +89,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg component.Config,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), logsPrefix, new(logRow), set.Logger)
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -0,0 +1,436 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The row types below define the columnar schema of each signal. Resource and
+// scope information is flattened into every row so that the files can be
+// queried without joins. Attribute values are stored as their string
+// representation, see pcommon.Value.AsString.
+
+type spanRow struct {
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeAttributes        map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID           string            `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState             string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name                   string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Kind                   string            `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64"`
+	EndTimeUnixNano        int64             `parquet:"name=end_time_unix_nano, type=INT64"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int32             `parquet:"name=dropped_attributes_count, type=INT32, convertedtype=UINT_32"`
+	Events                 []spanEventRow    `parquet:"name=events, type=LIST"`
+	DroppedEventsCount     int32             `parquet:"name=dropped_events_count, type=INT32, convertedtype=UINT_32"`
+	Links                  []spanLinkRow     `parquet:"name=links, type=LIST"`
+	DroppedLinksCount      int32             `parquet:"name=dropped_links_count, type=INT32, convertedtype=UINT_32"`
+	StatusCode             string            `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	StatusMessage          string            `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+type spanEventRow struct {
+	TimeUnixNano int64             `parquet:"name=time_unix_nano, type=INT64"`
+	Name         string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes   map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type spanLinkRow struct {
+	TraceID    string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID     string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type logRow struct {
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeAttributes        map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64"`
+	ObservedTimeUnixNano   int64             `parquet:"name=observed_time_unix_nano, type=INT64"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Flags                  int32             `parquet:"name=flags, type=INT32, convertedtype=UINT_32"`
+	SeverityNumber         int32             `parquet:"name=severity_number, type=INT32"`
+	SeverityText           string            `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Body                   string            `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int32             `parquet:"name=dropped_attributes_count, type=INT32, convertedtype=UINT_32"`
+}
+
+// metricRow holds a single data point. Columns that do not apply to the
+// metric type are left null.
+type metricRow struct {
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ScopeAttributes        map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	MetricType             string            `parquet:"name=metric_type, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	AggregationTemporality *string           `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	IsMonotonic            *bool             `parquet:"name=is_monotonic, type=BOOLEAN, repetitiontype=OPTIONAL"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Flags                  int32             `parquet:"name=flags, type=INT32, convertedtype=UINT_32"`
+
+	// Gauge and Sum.
+	DoubleValue *float64 `parquet:"name=double_value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	IntValue    *int64   `parquet:"name=int_value, type=INT64, repetitiontype=OPTIONAL"`
+
+	// Histogram, ExponentialHistogram and Summary.
+	Count *int64   `parquet:"name=count, type=INT64, convertedtype=UINT_64, repetitiontype=OPTIONAL"`
+	Sum   *float64 `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min   *float64 `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max   *float64 `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+
+	// Histogram.
+	BucketCounts   []int64   `parquet:"name=bucket_counts, type=LIST, valuetype=INT64, valueconvertedtype=UINT_64"`
+	ExplicitBounds []float64 `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+
+	// ExponentialHistogram.
+	Scale                *int32  `parquet:"name=scale, type=INT32, repetitiontype=OPTIONAL"`
+	ZeroCount            *int64  `parquet:"name=zero_count, type=INT64, convertedtype=UINT_64, repetitiontype=OPTIONAL"`
+	PositiveOffset       *int32  `parquet:"name=positive_offset, type=INT32, repetitiontype=OPTIONAL"`
+	PositiveBucketCounts []int64 `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64, valueconvertedtype=UINT_64"`
+	NegativeOffset       *int32  `parquet:"name=negative_offset, type=INT32, repetitiontype=OPTIONAL"`
+	NegativeBucketCounts []int64 `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64, valueconvertedtype=UINT_64"`
+
+	// Summary.
+	Quantiles      []float64 `parquet:"name=quantiles, type=LIST, valuetype=DOUBLE"`
+	QuantileValues []float64 `parquet:"name=quantile_values, type=LIST, valuetype=DOUBLE"`
+}
+
+// resourceColumns carries the resource and scope columns shared by all rows
+// produced from the same scope.
+type resourceColumns struct {
+	schemaURL          string
+	resourceAttributes map[string]string
+	scopeName          string
+	scopeVersion       string
+	scopeAttributes    map[string]string
+}
+
+func newResourceColumns(resourceSchemaURL string, res pcommon.Resource, scope pcommon.InstrumentationScope) resourceColumns {
+	return resourceColumns{
+		schemaURL:          resourceSchemaURL,
+		resourceAttributes: attributesToMap(res.Attributes()),
+		scopeName:          scope.Name(),
+		scopeVersion:       scope.Version(),
+		scopeAttributes:    attributesToMap(scope.Attributes()),
+	}
+}
+
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func uint64SliceToInt64(s pcommon.UInt64Slice) []int64 {
+	out := make([]int64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		out[i] = int64(s.At(i))
+	}
+	return out
+}
+
+func tracesToRows(td ptrace.Traces) []interface{} {
+	var rows []interface{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			rc := newResourceColumns(rs.SchemaUrl(), rs.Resource(), ss.Scope())
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				rows = append(rows, spanToRow(rc, spans.At(k)))
+			}
+		}
+	}
+	return rows
+}
+
+func spanToRow(rc resourceColumns, span ptrace.Span) *spanRow {
+	row := &spanRow{
+		ResourceSchemaURL:      rc.schemaURL,
+		ResourceAttributes:     rc.resourceAttributes,
+		ScopeName:              rc.scopeName,
+		ScopeVersion:           rc.scopeVersion,
+		ScopeAttributes:        rc.scopeAttributes,
+		TraceID:                span.TraceID().String(),
+		SpanID:                 span.SpanID().String(),
+		ParentSpanID:           span.ParentSpanID().String(),
+		TraceState:             span.TraceState().AsRaw(),
+		Name:                   span.Name(),
+		Kind:                   span.Kind().String(),
+		StartTimeUnixNano:      int64(span.StartTimestamp()),
+		EndTimeUnixNano:        int64(span.EndTimestamp()),
+		Attributes:             attributesToMap(span.Attributes()),
+		DroppedAttributesCount: int32(span.DroppedAttributesCount()),
+		DroppedEventsCount:     int32(span.DroppedEventsCount()),
+		DroppedLinksCount:      int32(span.DroppedLinksCount()),
+		StatusCode:             span.Status().Code().String(),
+		StatusMessage:          span.Status().Message(),
+	}
+	events := span.Events()
+	row.Events = make([]spanEventRow, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		row.Events[i] = spanEventRow{
+			TimeUnixNano: int64(event.Timestamp()),
+			Name:         event.Name(),
+			Attributes:   attributesToMap(event.Attributes()),
+		}
+	}
+	links := span.Links()
+	row.Links = make([]spanLinkRow, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		row.Links[i] = spanLinkRow{
+			TraceID:    link.TraceID().String(),
+			SpanID:     link.SpanID().String(),
+			TraceState: link.TraceState().AsRaw(),
+			Attributes: attributesToMap(link.Attributes()),
+		}
+	}
+	return row
+}
+
+func logsToRows(ld plog.Logs) []interface{} {
+	var rows []interface{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			rc := newResourceColumns(rl.SchemaUrl(), rl.Resource(), sl.Scope())
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				rows = append(rows, logRecordToRow(rc, lrs.At(k)))
+			}
+		}
+	}
+	return rows
+}
+
+func logRecordToRow(rc resourceColumns, lr plog.LogRecord) *logRow {
+	return &logRow{
+		ResourceSchemaURL:      rc.schemaURL,
+		ResourceAttributes:     rc.resourceAttributes,
+		ScopeName:              rc.scopeName,
+		ScopeVersion:           rc.scopeVersion,
+		ScopeAttributes:        rc.scopeAttributes,
+		TimeUnixNano:           int64(lr.Timestamp()),
+		ObservedTimeUnixNano:   int64(lr.ObservedTimestamp()),
+		TraceID:                lr.TraceID().String(),
+		SpanID:                 lr.SpanID().String(),
+		Flags:                  int32(lr.Flags()),
+		SeverityNumber:         int32(lr.SeverityNumber()),
+		SeverityText:           lr.SeverityText(),
+		Body:                   lr.Body().AsString(),
+		Attributes:             attributesToMap(lr.Attributes()),
+		DroppedAttributesCount: int32(lr.DroppedAttributesCount()),
+	}
+}
+
+func metricsToRows(md pmetric.Metrics) []interface{} {
+	var rows []interface{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			rc := newResourceColumns(rm.SchemaUrl(), rm.Resource(), sm.Scope())
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				rows = appendMetricRows(rows, rc, metrics.At(k))
+			}
+		}
+	}
+	return rows
+}
+
+func newMetricRow(rc resourceColumns, metric pmetric.Metric) *metricRow {
+	return &metricRow{
+		ResourceSchemaURL:  rc.schemaURL,
+		ResourceAttributes: rc.resourceAttributes,
+		ScopeName:          rc.scopeName,
+		ScopeVersion:       rc.scopeVersion,
+		ScopeAttributes:    rc.scopeAttributes,
+		MetricName:         metric.Name(),
+		MetricDescription:  metric.Description(),
+		MetricUnit:         metric.Unit(),
+		MetricType:         metric.Type().String(),
+	}
+}
+
+func appendMetricRows(rows []interface{}, rc resourceColumns, metric pmetric.Metric) []interface{} {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			row := newMetricRow(rc, metric)
+			setNumberDataPoint(row, dps.At(i))
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeSum:
+		sum := metric.Sum()
+		temporality := sum.AggregationTemporality().String()
+		monotonic := sum.IsMonotonic()
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			row := newMetricRow(rc, metric)
+			row.AggregationTemporality = &temporality
+			row.IsMonotonic = &monotonic
+			setNumberDataPoint(row, dps.At(i))
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeHistogram:
+		temporality := metric.Histogram().AggregationTemporality().String()
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			row := newMetricRow(rc, metric)
+			row.AggregationTemporality = &temporality
+			setHistogramDataPoint(row, dps.At(i))
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		temporality := metric.ExponentialHistogram().AggregationTemporality().String()
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			row := newMetricRow(rc, metric)
+			row.AggregationTemporality = &temporality
+			setExponentialHistogramDataPoint(row, dps.At(i))
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			row := newMetricRow(rc, metric)
+			setSummaryDataPoint(row, dps.At(i))
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func setNumberDataPoint(row *metricRow, dp pmetric.NumberDataPoint) {
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.Flags = int32(dp.Flags())
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		v := dp.DoubleValue()
+		row.DoubleValue = &v
+	case pmetric.NumberDataPointValueTypeInt:
+		v := dp.IntValue()
+		row.IntValue = &v
+	}
+}
+
+func setHistogramDataPoint(row *metricRow, dp pmetric.HistogramDataPoint) {
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.Flags = int32(dp.Flags())
+	count := int64(dp.Count())
+	row.Count = &count
+	if dp.HasSum() {
+		sum := dp.Sum()
+		row.Sum = &sum
+	}
+	if dp.HasMin() {
+		min := dp.Min()
+		row.Min = &min
+	}
+	if dp.HasMax() {
+		max := dp.Max()
+		row.Max = &max
+	}
+	row.BucketCounts = uint64SliceToInt64(dp.BucketCounts())
+	row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+}
+
+func setExponentialHistogramDataPoint(row *metricRow, dp pmetric.ExponentialHistogramDataPoint) {
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.Flags = int32(dp.Flags())
+	count := int64(dp.Count())
+	row.Count = &count
+	if dp.HasSum() {
+		sum := dp.Sum()
+		row.Sum = &sum
+	}
+	if dp.HasMin() {
+		min := dp.Min()
+		row.Min = &min
+	}
+	if dp.HasMax() {
+		max := dp.Max()
+		row.Max = &max
+	}
+	scale := dp.Scale()
+	row.Scale = &scale
+	zeroCount := int64(dp.ZeroCount())
+	row.ZeroCount = &zeroCount
+	positiveOffset := dp.Positive().Offset()
+	row.PositiveOffset = &positiveOffset
+	row.PositiveBucketCounts = uint64SliceToInt64(dp.Positive().BucketCounts())
+	negativeOffset := dp.Negative().Offset()
+	row.NegativeOffset = &negativeOffset
+	row.NegativeBucketCounts = uint64SliceToInt64(dp.Negative().BucketCounts())
+}
+
+func setSummaryDataPoint(row *metricRow, dp pmetric.SummaryDataPoint) {
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.Flags = int32(dp.Flags())
+	count := int64(dp.Count())
+	row.Count = &count
+	sum := dp.Sum()
+	row.Sum = &sum
+	qvs := dp.QuantileValues()
+	row.Quantiles = make([]float64, qvs.Len())
+	row.QuantileValues = make([]float64, qvs.Len())
+	for i := 0; i < qvs.Len(); i++ {
+		row.Quantiles[i] = qvs.At(i).Quantile()
+		row.QuantileValues[i] = qvs.At(i).Value()
+	}
+}
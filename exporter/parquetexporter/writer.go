@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	// inProgressSuffix is appended to the name of files that are still being
+	// written, so that readers of the directory only pick up complete files.
+	inProgressSuffix = ".inprogress"
+	fileSuffix       = ".parquet"
+	// parallelism is the number of goroutines used to marshal a row group.
+	parallelism = 1
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+}
+
+// rollingWriter writes rows of a single schema to a sequence of Parquet
+// files, starting a new file once the current one exceeds the maximum size
+// or has been open longer than the rollover interval.
+type rollingWriter struct {
+	dir              string
+	prefix           string
+	schema           interface{}
+	maxFileSize      int64
+	rolloverInterval time.Duration
+	compression      parquet.CompressionCodec
+	logger           *zap.Logger
+
+	mu      sync.Mutex
+	file    *os.File
+	pw      *writer.ParquetWriter
+	timer   *time.Timer
+	current string
+	// generation identifies the currently open file so that a rollover
+	// timer firing late does not close a newer file.
+	generation uint64
+	seq        uint64
+	now        func() time.Time
+}
+
+func newRollingWriter(cfg *Config, prefix string, schema interface{}, logger *zap.Logger) *rollingWriter {
+	return &rollingWriter{
+		dir:              cfg.Path,
+		prefix:           prefix,
+		schema:           schema,
+		maxFileSize:      int64(cfg.MaxFileSizeMiB) * 1024 * 1024,
+		rolloverInterval: cfg.RolloverInterval,
+		compression:      compressionCodecs[cfg.Compression],
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+// write appends rows to the current file, opening a new one if needed.
+func (w *rollingWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, row := range rows {
+		if w.pw == nil {
+			if err := w.open(); err != nil {
+				return err
+			}
+		}
+		if err := w.pw.Write(row); err != nil {
+			return w.abortCurrent(fmt.Errorf("failed to write row to %s: %w", w.current, err))
+		}
+		if w.size() >= w.maxFileSize {
+			if err := w.closeCurrent(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// size estimates the size of the current file, including the rows that are
+// still buffered in memory.
+func (w *rollingWriter) size() int64 {
+	return w.pw.Offset + w.pw.Size + w.pw.ObjsSize
+}
+
+func (w *rollingWriter) open() error {
+	w.seq++
+	name := fmt.Sprintf("%s-%s-%d%s", w.prefix, w.now().UTC().Format("20060102T150405Z"), w.seq, fileSuffix)
+	current := filepath.Join(w.dir, name)
+	file, err := os.OpenFile(current+inProgressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create parquet file: %w", err)
+	}
+	pw, err := writer.NewParquetWriterFromWriter(file, w.schema, parallelism)
+	if err != nil {
+		return multierr.Append(fmt.Errorf("failed to create parquet writer: %w", err), file.Close())
+	}
+	pw.CompressionType = w.compression
+	if pw.RowGroupSize > w.maxFileSize {
+		pw.RowGroupSize = w.maxFileSize
+	}
+
+	w.file = file
+	w.pw = pw
+	w.current = current
+	w.generation++
+	if w.rolloverInterval > 0 {
+		generation := w.generation
+		w.timer = time.AfterFunc(w.rolloverInterval, func() { w.rollover(generation) })
+	}
+	return nil
+}
+
+// rollover closes the file identified by generation if it is still open.
+func (w *rollingWriter) rollover(generation uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pw == nil || w.generation != generation {
+		return
+	}
+	if err := w.closeCurrent(); err != nil {
+		w.logger.Error("Failed to roll over parquet file", zap.Error(err))
+	}
+}
+
+// closeCurrent writes the footer of the current file and moves it to its
+// final name. It must be called with the lock held.
+func (w *rollingWriter) closeCurrent() error {
+	if w.pw == nil {
+		return nil
+	}
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	pw, file, current := w.pw, w.file, w.current
+	w.pw, w.file, w.current = nil, nil, ""
+
+	if err := pw.WriteStop(); err != nil {
+		return multierr.Append(fmt.Errorf("failed to flush parquet file %s: %w", current, err), file.Close())
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close parquet file %s: %w", current, err)
+	}
+	if err := os.Rename(current+inProgressSuffix, current); err != nil {
+		return fmt.Errorf("failed to finalize parquet file %s: %w", current, err)
+	}
+	return nil
+}
+
+// abortCurrent closes the current file after a failed write, leaving it
+// under its in-progress name since it has no footer. It returns err combined
+// with any error from closing the file. It must be called with the lock held.
+func (w *rollingWriter) abortCurrent(err error) error {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	file := w.file
+	w.pw, w.file, w.current = nil, nil, ""
+	return multierr.Append(err, file.Close())
+}
+
+// close flushes and closes the current file, if any.
+func (w *rollingWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeCurrent()
+}
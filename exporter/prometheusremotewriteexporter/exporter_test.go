@@ -393,6 +393,8 @@ func Test_PushMetrics(t *testing.T) {
 
 	summaryBatch := getMetricsFromMetricList(validMetrics1[validSummary], validMetrics2[validSummary])
 
+	exponentialHistogramBatch := getMetricsFromMetricList(validMetrics1[validExponentialHistogram], validMetrics2[validExponentialHistogram])
+
 	// len(BucketCount) > len(ExplicitBounds)
 	unmatchedBoundBucketHistBatch := getMetricsFromMetricList(validMetrics2[unmatchedBoundBucketHist])
 
@@ -441,6 +443,23 @@ func Test_PushMetrics(t *testing.T) {
 		}
 	}
 
+	checkHistogramFunc := func(t *testing.T, r *http.Request, expected int, isStaleMarker bool) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		dest, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		wr := &prompb.WriteRequest{}
+		require.NoError(t, proto.Unmarshal(dest, wr))
+		require.EqualValues(t, expected, len(wr.Timeseries))
+		for _, ts := range wr.Timeseries {
+			assert.Empty(t, ts.Samples)
+			require.Len(t, ts.Histograms, 1)
+			assert.EqualValues(t, 2, ts.Histograms[0].Schema)
+			assert.Equal(t, &prompb.Histogram_CountInt{CountInt: 6}, ts.Histograms[0].Count)
+		}
+	}
+
 	tests := []struct {
 		name               string
 		metrics            pmetric.Metrics
@@ -513,6 +532,13 @@ func Test_PushMetrics(t *testing.T) {
 			expectedTimeSeries: 10,
 			httpResponseCode:   http.StatusAccepted,
 		},
+		{
+			name:               "exponentialHistogram_case",
+			metrics:            exponentialHistogramBatch,
+			reqTestFunc:        checkHistogramFunc,
+			expectedTimeSeries: 2,
+			httpResponseCode:   http.StatusAccepted,
+		},
 		{
 			name:               "unmatchedBoundBucketHist_case",
 			metrics:            unmatchedBoundBucketHistBatch,
@@ -67,6 +67,8 @@ var (
 	validSummary        = "valid_Summary"
 	suffixedCounter     = "valid_IntSum_total"
 
+	validExponentialHistogram = "valid_ExponentialHistogram"
+
 	validIntGaugeDirty = "*valid_IntGauge$"
 
 	unmatchedBoundBucketHist = "unmatchedBoundBucketHist"
@@ -82,6 +84,8 @@ var (
 		validHistogramNoSum: getHistogramMetric(validHistogramNoSum, lbs1, time1, nil, uint64(intVal1), bounds, buckets),
 		validEmptyHistogram: getHistogramMetricEmptyDataPoint(validEmptyHistogram, lbs1, time1),
 		validSummary:        getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
+
+		validExponentialHistogram: getExponentialHistogramMetric(validExponentialHistogram, lbs1, time1, floatVal1, buckets),
 	}
 	validMetrics2 = map[string]pmetric.Metric{
 		validIntGauge:       getIntGaugeMetric(validIntGauge, lbs2, intVal2, time2),
@@ -92,7 +96,9 @@ var (
 		validHistogramNoSum: getHistogramMetric(validHistogramNoSum, lbs2, time2, nil, uint64(intVal2), bounds, buckets),
 		validEmptyHistogram: getHistogramMetricEmptyDataPoint(validEmptyHistogram, lbs2, time2),
 		validSummary:        getSummaryMetric(validSummary, lbs2, time2, floatVal2, uint64(intVal2), quantiles),
-		validIntGaugeDirty:  getIntGaugeMetric(validIntGaugeDirty, lbs1, intVal1, time1),
+
+		validExponentialHistogram: getExponentialHistogramMetric(validExponentialHistogram, lbs2, time2, floatVal2, buckets),
+		validIntGaugeDirty:        getIntGaugeMetric(validIntGaugeDirty, lbs1, intVal1, time1),
 		unmatchedBoundBucketHist: getHistogramMetric(unmatchedBoundBucketHist, pcommon.NewMap(), 0, &floatValZero, 0,
 			[]float64{0.1, 0.2, 0.3}, []uint64{1, 2}),
 	}
@@ -324,6 +330,25 @@ func getHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum *flo
 	return metric
 }
 
+func getExponentialHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum float64, buckets []uint64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	var count uint64
+	for _, bucket := range buckets {
+		count += bucket
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetScale(2)
+	dp.Positive().BucketCounts().FromRaw(buckets)
+	attributes.CopyTo(dp.Attributes())
+
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	return metric
+}
+
 func getEmptySummaryMetric(name string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(name)
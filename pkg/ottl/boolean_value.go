@@ -107,6 +107,24 @@ func (p *Parser[K]) newComparisonEvaluator(comparison *comparison) (BoolExpr[K],
 
 }
 
+func (p *Parser[K]) newConverterEvaluator(conv converter) (BoolExpr[K], error) {
+	getter, err := p.newConverterGetter(conv)
+	if err != nil {
+		return BoolExpr[K]{}, err
+	}
+	return BoolExpr[K]{func(ctx context.Context, tCtx K) (bool, error) {
+		result, err := getter.Get(ctx, tCtx)
+		if err != nil {
+			return false, err
+		}
+		boolResult, ok := result.(bool)
+		if !ok {
+			return false, fmt.Errorf("value returned from %v is not a bool: %v", conv.Function, result)
+		}
+		return boolResult, nil
+	}}, nil
+}
+
 func (p *Parser[K]) newBoolExpr(expr *booleanExpression) (BoolExpr[K], error) {
 	if expr == nil {
 		return BoolExpr[K]{alwaysTrue[K]}, nil
@@ -160,6 +178,11 @@ func (p *Parser[K]) newBooleanValueEvaluator(value *booleanValue) (BoolExpr[K],
 		if err != nil {
 			return BoolExpr[K]{}, err
 		}
+	case value.Converter != nil:
+		boolExpr, err = p.newConverterEvaluator(*value.Converter)
+		if err != nil {
+			return BoolExpr[K]{}, err
+		}
 	case value.ConstExpr != nil:
 		if *value.ConstExpr {
 			boolExpr = BoolExpr[K]{alwaysTrue[K]}
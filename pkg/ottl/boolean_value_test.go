@@ -464,3 +464,52 @@ func Test_newBooleanExpressionEvaluator(t *testing.T) {
 		})
 	}
 }
+
+func Test_newBooleanExpressionEvaluator_converter(t *testing.T) {
+	functions := map[string]interface{}{
+		"True":  func() (ExprFunc[interface{}], error) { return constant(true), nil },
+		"False": func() (ExprFunc[interface{}], error) { return constant(false), nil },
+		"Hello": hello[interface{}],
+	}
+	p := NewParser(
+		functions,
+		testParsePath,
+		testParseEnum,
+		componenttest.NewNopTelemetrySettings(),
+	)
+
+	tests := []struct {
+		condition string
+		want      bool
+		wantErr   bool
+	}{
+		{condition: `True()`, want: true},
+		{condition: `False()`, want: false},
+		{condition: `not False()`, want: true},
+		{condition: `True() and False()`, want: false},
+		{condition: `False() or True()`, want: true},
+		{condition: `True() == false`, want: false},
+		{condition: `Hello()`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			parsed, err := parseStatement(`set(name, "test") where ` + tt.condition)
+			assert.NoError(t, err)
+			evaluator, err := p.newBoolExpr(parsed.WhereClause)
+			assert.NoError(t, err)
+			result, err := evaluator.Eval(context.Background(), nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func constant(val interface{}) ExprFunc[interface{}] {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return val, nil
+	}
+}
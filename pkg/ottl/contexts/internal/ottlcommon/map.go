@@ -15,26 +15,119 @@
 package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ottlcommon"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
-func GetMapValue(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
+// GetMapValue returns the value found by following keys into m, starting with a string key.
+// Nil is returned if one of the map keys does not exist or one of the indexes is out of bounds.
+func GetMapValue(m pcommon.Map, keys []ottl.Key) (interface{}, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("cannot get map value without key")
+	}
+	if keys[0].String == nil {
+		return nil, fmt.Errorf("non-string indexing is not supported")
+	}
+	val, ok := m.Get(*keys[0].String)
+	if !ok {
+		return nil, nil
+	}
+	return GetIndexableValue(val, keys[1:])
+}
+
+// GetIndexableValue returns the value found by following keys into the maps and slices of val.
+// Nil is returned if one of the map keys does not exist or one of the indexes is out of bounds.
+func GetIndexableValue(val pcommon.Value, keys []ottl.Key) (interface{}, error) {
+	for _, k := range keys {
+		switch val.Type() {
+		case pcommon.ValueTypeMap:
+			if k.String == nil {
+				return nil, fmt.Errorf("map must be indexed by a string")
+			}
+			var ok bool
+			val, ok = val.Map().Get(*k.String)
+			if !ok {
+				return nil, nil
+			}
+		case pcommon.ValueTypeSlice:
+			if k.Int == nil {
+				return nil, fmt.Errorf("slice must be indexed by an int")
+			}
+			i := int(*k.Int)
+			if i < 0 || i >= val.Slice().Len() {
+				return nil, nil
+			}
+			val = val.Slice().At(i)
+		default:
+			return nil, fmt.Errorf("type %v does not support indexing", val.Type())
+		}
+	}
+	return GetValue(val), nil
+}
+
+// SetMapValue sets val at the location found by following keys into m, starting with a string key.
+// Missing map keys are created along the way.
+func SetMapValue(m pcommon.Map, keys []ottl.Key, val interface{}) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("cannot set map value without key")
+	}
+	if keys[0].String == nil {
+		return fmt.Errorf("non-string indexing is not supported")
+	}
+	current, ok := m.Get(*keys[0].String)
 	if !ok {
-		return nil
+		current = m.PutEmpty(*keys[0].String)
 	}
-	return GetValue(val)
+	return SetIndexableValue(current, keys[1:], val)
 }
 
-func SetMapValue(attrs pcommon.Map, mapKey string, val interface{}) {
-	var value pcommon.Value
-	switch val.(type) {
-	case []string, []bool, []int64, []float64, [][]byte, []any:
-		value = pcommon.NewValueSlice()
-	default:
-		value = pcommon.NewValueEmpty()
+// SetIndexableValue sets val at the location found by following keys into the maps and slices of current.
+// Missing map keys are created along the way, and empty values are turned into a map or a slice
+// depending on the type of the key used to index them.
+func SetIndexableValue(current pcommon.Value, keys []ottl.Key, val interface{}) error {
+	for _, k := range keys {
+		switch current.Type() {
+		case pcommon.ValueTypeMap:
+			if k.String == nil {
+				return fmt.Errorf("map must be indexed by a string")
+			}
+			next, ok := current.Map().Get(*k.String)
+			if !ok {
+				next = current.Map().PutEmpty(*k.String)
+			}
+			current = next
+		case pcommon.ValueTypeSlice:
+			if k.Int == nil {
+				return fmt.Errorf("slice must be indexed by an int")
+			}
+			i := int(*k.Int)
+			if i < 0 || i >= current.Slice().Len() {
+				return fmt.Errorf("index %d out of bounds", i)
+			}
+			current = current.Slice().At(i)
+		case pcommon.ValueTypeEmpty:
+			switch {
+			case k.String != nil:
+				current = current.SetEmptyMap().PutEmpty(*k.String)
+			case *k.Int < 0:
+				return fmt.Errorf("index %d out of bounds", *k.Int)
+			default:
+				slice := current.SetEmptySlice()
+				for slice.Len() <= int(*k.Int) {
+					slice.AppendEmpty()
+				}
+				current = slice.At(int(*k.Int))
+			}
+		default:
+			return fmt.Errorf("type %v does not support indexing", current.Type())
+		}
 	}
 
+	value := pcommon.NewValueEmpty()
 	SetValue(value, val)
-	value.CopyTo(attrs.PutEmpty(mapKey))
+	value.CopyTo(current)
+	return nil
 }
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func createNestedMap() pcommon.Map {
+	m := pcommon.NewMap()
+	m.PutStr("str", "val")
+	nested := m.PutEmptyMap("map")
+	nested.PutStr("key", "nested")
+	slice := nested.PutEmptySlice("slice")
+	slice.AppendEmpty().SetStr("first")
+	slice.AppendEmpty().SetEmptyMap().PutInt("int", 1)
+	return m
+}
+
+func stringKey(s string) ottl.Key {
+	return ottl.Key{String: ottltest.Strp(s)}
+}
+
+func intKey(i int64) ottl.Key {
+	return ottl.Key{Int: ottltest.Intp(i)}
+}
+
+func TestGetMapValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []ottl.Key
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "single key",
+			keys: []ottl.Key{stringKey("str")},
+			want: "val",
+		},
+		{
+			name: "nested map",
+			keys: []ottl.Key{stringKey("map"), stringKey("key")},
+			want: "nested",
+		},
+		{
+			name: "slice index",
+			keys: []ottl.Key{stringKey("map"), stringKey("slice"), intKey(0)},
+			want: "first",
+		},
+		{
+			name: "map in slice",
+			keys: []ottl.Key{stringKey("map"), stringKey("slice"), intKey(1), stringKey("int")},
+			want: int64(1),
+		},
+		{
+			name: "missing key",
+			keys: []ottl.Key{stringKey("map"), stringKey("unknown")},
+			want: nil,
+		},
+		{
+			name:    "int key on map",
+			keys:    []ottl.Key{intKey(0)},
+			wantErr: true,
+		},
+		{
+			name:    "string key on slice",
+			keys:    []ottl.Key{stringKey("map"), stringKey("slice"), stringKey("first")},
+			wantErr: true,
+		},
+		{
+			name: "index out of bounds",
+			keys: []ottl.Key{stringKey("map"), stringKey("slice"), intKey(2)},
+			want: nil,
+		},
+		{
+			name:    "index into string",
+			keys:    []ottl.Key{stringKey("str"), stringKey("key")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetMapValue(createNestedMap(), tt.keys)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetMapValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []ottl.Key
+		val      interface{}
+		modified func(m pcommon.Map)
+		wantErr  bool
+	}{
+		{
+			name: "single key",
+			keys: []ottl.Key{stringKey("str")},
+			val:  "new",
+			modified: func(m pcommon.Map) {
+				m.PutStr("str", "new")
+			},
+		},
+		{
+			name: "slice index",
+			keys: []ottl.Key{stringKey("map"), stringKey("slice"), intKey(0)},
+			val:  int64(2),
+			modified: func(m pcommon.Map) {
+				slice, _ := m.Get("map")
+				val, _ := slice.Map().Get("slice")
+				val.Slice().At(0).SetInt(2)
+			},
+		},
+		{
+			name: "missing keys are created",
+			keys: []ottl.Key{stringKey("new"), stringKey("key"), intKey(1)},
+			val:  true,
+			modified: func(m pcommon.Map) {
+				slice := m.PutEmptyMap("new").PutEmptySlice("key")
+				slice.AppendEmpty()
+				slice.AppendEmpty().SetBool(true)
+			},
+		},
+		{
+			name: "map value",
+			keys: []ottl.Key{stringKey("map"), stringKey("key")},
+			val:  map[string]interface{}{"a": "b"},
+			modified: func(m pcommon.Map) {
+				val, _ := m.Get("map")
+				val.Map().PutEmptyMap("key").PutStr("a", "b")
+			},
+		},
+		{
+			name: "slice value",
+			keys: []ottl.Key{stringKey("str")},
+			val: func() pcommon.Slice {
+				slice := pcommon.NewSlice()
+				slice.AppendEmpty().SetStr("a")
+				slice.AppendEmpty().SetInt(1)
+				return slice
+			}(),
+			modified: func(m pcommon.Map) {
+				slice := m.PutEmptySlice("str")
+				slice.AppendEmpty().SetStr("a")
+				slice.AppendEmpty().SetInt(1)
+			},
+		},
+		{
+			name:    "non-string first key",
+			keys:    []ottl.Key{intKey(0)},
+			val:     "new",
+			wantErr: true,
+		},
+		{
+			name:    "index out of bounds",
+			keys:    []ottl.Key{stringKey("map"), stringKey("slice"), intKey(5)},
+			val:     "new",
+			wantErr: true,
+		},
+		{
+			name:    "index into string",
+			keys:    []ottl.Key{stringKey("str"), stringKey("key")},
+			val:     "new",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createNestedMap()
+			err := SetMapValue(m, tt.keys, tt.val)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createNestedMap()
+			tt.modified(expected)
+			assert.Equal(t, expected.AsRaw(), m.AsRaw())
+		})
+	}
+}
@@ -64,8 +64,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("str")}},
 				},
 			},
 			orig:   "val",
@@ -78,8 +78,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bool")}},
 				},
 			},
 			orig:   true,
@@ -92,8 +92,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("int")}},
 				},
 			},
 			orig:   int64(10),
@@ -106,8 +106,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("double"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("double")}},
 				},
 			},
 			orig:   1.2,
@@ -120,8 +120,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bytes")}},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -134,8 +134,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes array empty",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_empty"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_empty")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -151,8 +151,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -168,8 +168,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -185,8 +185,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -202,8 +202,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -219,8 +219,8 @@ func TestResourcePathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
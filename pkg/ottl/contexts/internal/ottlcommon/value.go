@@ -81,12 +81,14 @@ func SetValue(value pcommon.Value, val interface{}) {
 			pval := value.Slice().AppendEmpty()
 			SetValue(pval, a)
 		}
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
 	case pcommon.Map:
 		v.CopyTo(value.SetEmptyMap())
 	case map[string]interface{}:
 		value.SetEmptyMap()
 		for mk, mv := range v {
-			SetMapValue(value.Map(), mk, mv)
+			SetValue(value.Map().PutEmpty(mk), mv)
 		}
 	}
 }
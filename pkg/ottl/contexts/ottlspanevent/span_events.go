@@ -97,11 +97,11 @@ func newPathGetSetter(path []ottl.Field) (ottl.GetSetter[TransformContext], erro
 	case "name":
 		return accessSpanEventName(), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if keys == nil {
 			return accessSpanEventAttributes(), nil
 		}
-		return accessSpanEventAttributesKey(mapKey), nil
+		return accessSpanEventAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessSpanEventDroppedAttributeCount(), nil
 	}
@@ -151,14 +151,13 @@ func accessSpanEventAttributes() ottl.StandardGetSetter[TransformContext] {
 	}
 }
 
-func accessSpanEventAttributesKey(mapKey *string) ottl.StandardGetSetter[TransformContext] {
+func accessSpanEventAttributesKey(keys []ottl.Key) ottl.StandardGetSetter[TransformContext] {
 	return ottl.StandardGetSetter[TransformContext]{
 		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
-			return ottlcommon.GetMapValue(tCtx.GetSpanEvent().Attributes(), *mapKey), nil
+			return ottlcommon.GetMapValue(tCtx.GetSpanEvent().Attributes(), keys)
 		},
 		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
-			ottlcommon.SetMapValue(tCtx.GetSpanEvent().Attributes(), *mapKey, val)
-			return nil
+			return ottlcommon.SetMapValue(tCtx.GetSpanEvent().Attributes(), keys, val)
 		},
 	}
 }
@@ -17,6 +17,8 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"context"
 	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 type ExprFunc[K any] func(ctx context.Context, tCtx K) (interface{}, error)
@@ -65,10 +67,65 @@ func (l literal[K]) Get(context.Context, K) (interface{}, error) {
 
 type exprGetter[K any] struct {
 	expr Expr[K]
+	keys []Key
 }
 
 func (g exprGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
-	return g.expr.Eval(ctx, tCtx)
+	result, err := g.expr.Eval(ctx, tCtx)
+	if err != nil {
+		return nil, err
+	}
+	// like for Paths, nil is returned for missing map keys and out of bounds indexes
+	for _, k := range g.keys {
+		switch {
+		case k.String != nil:
+			switch r := result.(type) {
+			case pcommon.Map:
+				val, ok := r.Get(*k.String)
+				if !ok {
+					return nil, nil
+				}
+				result = rawValue(val)
+			case map[string]interface{}:
+				val, ok := r[*k.String]
+				if !ok {
+					return nil, nil
+				}
+				result = val
+			default:
+				return nil, fmt.Errorf("type %T does not support string indexing", result)
+			}
+		case k.Int != nil:
+			i := int(*k.Int)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				if i < 0 || i >= r.Len() {
+					return nil, nil
+				}
+				result = rawValue(r.At(i))
+			case []interface{}:
+				if i < 0 || i >= len(r) {
+					return nil, nil
+				}
+				result = r[i]
+			default:
+				return nil, fmt.Errorf("type %T does not support int indexing", result)
+			}
+		}
+	}
+	return result, nil
+}
+
+// rawValue returns the Go representation of val used by the OTTL,
+// keeping maps and slices as their pdata types so they can be indexed further.
+func rawValue(val pcommon.Value) interface{} {
+	switch val.Type() {
+	case pcommon.ValueTypeMap:
+		return val.Map()
+	case pcommon.ValueTypeSlice:
+		return val.Slice()
+	}
+	return val.AsRaw()
 }
 
 type listGetter[K any] struct {
@@ -89,6 +146,22 @@ func (l *listGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
 	return evaluated, nil
 }
 
+type mapGetter[K any] struct {
+	mapValues map[string]Getter[K]
+}
+
+func (m *mapGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
+	evaluated := make(map[string]interface{}, len(m.mapValues))
+	for k, v := range m.mapValues {
+		val, err := v.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		evaluated[k] = val
+	}
+	return evaluated, nil
+}
+
 func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 	if val.IsNil != nil && *val.IsNil {
 		return &literal[K]{value: nil}, nil
@@ -120,16 +193,10 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
-			return p.pathParser(eL.Path)
+			return p.parsePath(eL.Path)
 		}
-		if eL.Invocation != nil {
-			call, err := p.newFunctionCall(*eL.Invocation)
-			if err != nil {
-				return nil, err
-			}
-			return &exprGetter[K]{
-				expr: call,
-			}, nil
+		if eL.Converter != nil {
+			return p.newConverterGetter(*eL.Converter)
 		}
 	}
 
@@ -145,9 +212,35 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		return &lg, nil
 	}
 
+	if val.Map != nil {
+		mg := mapGetter[K]{mapValues: make(map[string]Getter[K], len(val.Map.Values))}
+		for _, kvp := range val.Map.Values {
+			getter, err := p.newGetter(*kvp.Value)
+			if err != nil {
+				return nil, err
+			}
+			mg.mapValues[*kvp.Key] = getter
+		}
+		return &mg, nil
+	}
+
 	if val.MathExpression == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
 	}
 	return p.evaluateMathExpression(val.MathExpression)
 }
+
+func (p *Parser[K]) newConverterGetter(conv converter) (Getter[K], error) {
+	call, err := p.newFunctionCall(invocation{
+		Function:  conv.Function,
+		Arguments: conv.Arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &exprGetter[K]{
+		expr: call,
+		keys: conv.Keys,
+	}, nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
 )
@@ -30,6 +31,14 @@ func hello[K any]() (ExprFunc[K], error) {
 	}, nil
 }
 
+func pmap[K any]() (ExprFunc[K], error) {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		m := pcommon.NewMap()
+		m.PutEmptySlice("list").AppendEmpty().SetStr("first")
+		return m, nil
+	}, nil
+}
+
 func Test_newGetter(t *testing.T) {
 	tests := []struct {
 		name string
@@ -102,8 +111,8 @@ func Test_newGetter(t *testing.T) {
 			name: "function call",
 			val: value{
 				Literal: &mathExprLiteral{
-					Invocation: &invocation{
-						Function: "hello",
+					Converter: &converter{
+						Function: "Hello",
 					},
 				},
 			},
@@ -242,8 +251,8 @@ func Test_newGetter(t *testing.T) {
 					Values: []value{
 						{
 							Literal: &mathExprLiteral{
-								Invocation: &invocation{
-									Function: "hello",
+								Converter: &converter{
+									Function: "Hello",
 								},
 							},
 						},
@@ -286,9 +295,45 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: []any{"test0", int64(1)},
 		},
+		{
+			name: "map",
+			val: value{
+				Map: &mapValue{
+					Values: []mapItem{
+						{
+							Key:   ottltest.Strp("greeting"),
+							Value: &value{Literal: &mathExprLiteral{Converter: &converter{Function: "Hello"}}},
+						},
+						{
+							Key:   ottltest.Strp("nested"),
+							Value: &value{Map: &mapValue{}},
+						},
+					},
+				},
+			},
+			want: map[string]interface{}{"greeting": "world", "nested": map[string]interface{}{}},
+		},
+		{
+			name: "indexed converter",
+			val: value{
+				Literal: &mathExprLiteral{
+					Converter: &converter{
+						Function: "PMap",
+						Keys: []Key{
+							{String: ottltest.Strp("list")},
+							{Int: ottltest.Intp(0)},
+						},
+					},
+				},
+			},
+			want: "first",
+		},
 	}
 
-	functions := map[string]interface{}{"hello": hello[interface{}]}
+	functions := map[string]interface{}{
+		"Hello": hello[interface{}],
+		"PMap":  pmap[interface{}],
+	}
 
 	p := NewParser(
 		functions,
@@ -318,3 +363,70 @@ func Test_newGetter(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_exprGetter_invalidKeys(t *testing.T) {
+	p := NewParser(
+		map[string]interface{}{"PMap": pmap[interface{}]},
+		testParsePath,
+		testParseEnum,
+		component.TelemetrySettings{},
+	)
+
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{
+			name: "int index on map",
+			keys: []Key{{Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "string index on slice",
+			keys: []Key{{String: ottltest.Strp("list")}, {String: ottltest.Strp("first")}},
+		},
+		{
+			name: "index into string",
+			keys: []Key{{String: ottltest.Strp("list")}, {Int: ottltest.Intp(0)}, {Int: ottltest.Intp(0)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter, err := p.newConverterGetter(converter{Function: "PMap", Keys: tt.keys})
+			assert.NoError(t, err)
+			_, err = getter.Get(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_exprGetter_missingKeys(t *testing.T) {
+	p := NewParser(
+		map[string]interface{}{"PMap": pmap[interface{}]},
+		testParsePath,
+		testParseEnum,
+		component.TelemetrySettings{},
+	)
+
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{
+			name: "missing map key",
+			keys: []Key{{String: ottltest.Strp("unknown")}},
+		},
+		{
+			name: "index out of bounds",
+			keys: []Key{{String: ottltest.Strp("list")}, {Int: ottltest.Intp(1)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter, err := p.newConverterGetter(converter{Function: "PMap", Keys: tt.keys})
+			assert.NoError(t, err)
+			val, err := getter.Get(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Nil(t, val)
+		})
+	}
+}
@@ -30,6 +30,7 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 	functions["testing_error"] = functionThatHasAnError
 	functions["testing_getsetter"] = functionWithGetSetter
 	functions["testing_getter"] = functionWithGetter
+	functions["TestingGetter"] = functionWithGetter
 	functions["testing_multiple_args"] = functionWithMultipleArgs
 	functions["testing_string"] = functionWithString
 	functions["testing_string_slice"] = functionWithStringSlice
@@ -73,8 +74,8 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				Arguments: []value{
 					{
 						Literal: &mathExprLiteral{
-							Invocation: &invocation{
-								Function: "unknownfunc",
+							Converter: &converter{
+								Function: "UnknownFunc",
 							},
 						},
 					},
@@ -461,8 +462,8 @@ func Test_NewFunctionCall(t *testing.T) {
 								},
 								{
 									Literal: &mathExprLiteral{
-										Invocation: &invocation{
-											Function: "testing_getter",
+										Converter: &converter{
+											Function: "TestingGetter",
 											Arguments: []value{
 												{
 													Literal: &mathExprLiteral{
@@ -598,8 +599,8 @@ func Test_NewFunctionCall(t *testing.T) {
 								},
 								{
 									Literal: &mathExprLiteral{
-										Invocation: &invocation{
-											Function: "testing_getter",
+										Converter: &converter{
+											Function: "TestingGetter",
 											Arguments: []value{
 												{
 													Literal: &mathExprLiteral{
@@ -943,6 +944,7 @@ func defaultFunctionsForTests() map[string]interface{} {
 	functions["testing_setter"] = functionWithSetter
 	functions["testing_getsetter"] = functionWithGetSetter
 	functions["testing_getter"] = functionWithGetter
+	functions["TestingGetter"] = functionWithGetter
 	functions["testing_string"] = functionWithString
 	functions["testing_float"] = functionWithFloat
 	functions["testing_int"] = functionWithInt
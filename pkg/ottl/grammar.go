@@ -27,11 +27,12 @@ type parsedStatement struct {
 }
 
 // booleanValue represents something that evaluates to a boolean --
-// either an equality or inequality, explicit true or false, or
-// a parenthesized subexpression.
+// either an equality or inequality, a converter returning a boolean,
+// explicit true or false, or a parenthesized subexpression.
 type booleanValue struct {
 	Negation   *string            `parser:"@OpNot?"`
 	Comparison *comparison        `parser:"( @@"`
+	Converter  *converter         `parser:"| @@"`
 	ConstExpr  *boolean           `parser:"| @Boolean"`
 	SubExpr    *booleanExpression `parser:"| '(' @@ ')' )"`
 }
@@ -127,8 +128,16 @@ type invocation struct {
 	Arguments []value `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
 }
 
+// converter represents a function call that returns a value. Converter names start with an uppercase letter,
+// which allows them to be used as a boolean within a condition. The result can be indexed like a Path.
+type converter struct {
+	Function  string  `parser:"@(Uppercase (Uppercase | Lowercase)*)"`
+	Arguments []value `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
+	Keys      []Key   `parser:"( '[' @@ ']' )*"`
+}
+
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
-// mathExpression, function call, or literal.
+// mathExpression, converter call, map, list or literal.
 type value struct {
 	IsNil          *isNil           `parser:"( @'nil'"`
 	Literal        *mathExprLiteral `parser:"| @@ (?! OpAddSub | OpMultDiv)"`
@@ -137,6 +146,7 @@ type value struct {
 	String         *string          `parser:"| @String"`
 	Bool           *boolean         `parser:"| @Boolean"`
 	Enum           *EnumSymbol      `parser:"| @Uppercase"`
+	Map            *mapValue        `parser:"| @@"`
 	List           *list            `parser:"| @@)"`
 }
 
@@ -147,14 +157,32 @@ type Path struct {
 
 // Field is an item within a Path.
 type Field struct {
-	Name   string  `parser:"@Lowercase"`
-	MapKey *string `parser:"( '[' @String ']' )?"`
+	Name string `parser:"@Lowercase"`
+	// Deprecated: use Keys instead. MapKey is set to the first key when it is a string,
+	// so that PathExpressionParsers that only support a single map key keep working.
+	MapKey *string
+	Keys   []Key `parser:"( '[' @@ ']' )*"`
+}
+
+// Key is a string map key or an int slice index used to index into a Field or the result of a converter.
+type Key struct {
+	String *string `parser:"( @String"`
+	Int    *int64  `parser:"| @Int )"`
 }
 
 type list struct {
 	Values []value `parser:"'[' (@@)* (',' @@)* ']'"`
 }
 
+type mapValue struct {
+	Values []mapItem `parser:"'{' ( @@ ( ',' @@ )* )? '}'"`
+}
+
+type mapItem struct {
+	Key   *string `parser:"@String ':'"`
+	Value *value  `parser:"@@"`
+}
+
 // byteSlice type for capturing byte slices
 type byteSlice []byte
 
@@ -185,10 +213,10 @@ func (n *isNil) Capture(_ []string) error {
 }
 
 type mathExprLiteral struct {
-	Invocation *invocation `parser:"( @@"`
-	Float      *float64    `parser:"| @Float"`
-	Int        *int64      `parser:"| @Int"`
-	Path       *Path       `parser:"| @@ )"`
+	Converter *converter `parser:"( @@"`
+	Float     *float64   `parser:"| @Float"`
+	Int       *int64     `parser:"| @Int"`
+	Path      *Path      `parser:"| @@ )"`
 }
 
 type mathValue struct {
@@ -276,7 +304,9 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.:\[\]]`},
+		{Name: `LBrace`, Pattern: `\{`},
+		{Name: `RBrace`, Pattern: `\}`},
 		{Name: `Uppercase`, Pattern: `[A-Z_][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z_][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
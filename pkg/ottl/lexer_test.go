@@ -89,7 +89,7 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"nothing_recognizable", "#", true, []result{
 			{"", ""},
 		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
@@ -103,6 +103,19 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"map_literal", `{"key": attributes["list"][0]}`, false, []result{
+			{"LBrace", "{"},
+			{"String", `"key"`},
+			{"Punct", ":"},
+			{"Lowercase", "attributes"},
+			{"Punct", "["},
+			{"String", `"list"`},
+			{"Punct", "]"},
+			{"Punct", "["},
+			{"Int", "0"},
+			{"Punct", "]"},
+			{"RBrace", "}"},
+		}},
 		{"Mixing case", `aBCd`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
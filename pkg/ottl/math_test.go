@@ -135,17 +135,17 @@ func Test_evaluateMathExpression(t *testing.T) {
 		},
 		{
 			name:     "int functions",
-			input:    "one() + two()",
+			input:    "One() + Two()",
 			expected: 3,
 		},
 		{
 			name:     "functions",
-			input:    "threePointOne() + threePointOne()",
+			input:    "ThreePointOne() + ThreePointOne()",
 			expected: 6.2,
 		},
 		{
 			name:     "functions",
-			input:    "sum([1, 2, 3, 4]) / (1 * 10)",
+			input:    "Sum([1, 2, 3, 4]) / (1 * 10)",
 			expected: 1,
 		},
 		{
@@ -206,10 +206,10 @@ func Test_evaluateMathExpression(t *testing.T) {
 	}
 
 	functions := map[string]interface{}{
-		"one":           one[any],
-		"two":           two[any],
-		"threePointOne": threePointOne[any],
-		"sum":           sum[any],
+		"One":           one[any],
+		"Two":           two[any],
+		"ThreePointOne": threePointOne[any],
+		"Sum":           sum[any],
 	}
 
 	p := NewParser[any](
@@ -249,10 +249,10 @@ func Test_evaluateMathExpression_error(t *testing.T) {
 	}
 
 	functions := map[string]interface{}{
-		"one":           one[any],
-		"two":           two[any],
-		"threePointOne": threePointOne[any],
-		"sum":           sum[any],
+		"One":           one[any],
+		"Two":           two[any],
+		"ThreePointOne": threePointOne[any],
+		"Sum":           sum[any],
 	}
 
 	p := NewParser[any](
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// ExtractPatterns returns a `pcommon.Map` holding the named capture groups of pattern matched against the target string.
+func ExtractPatterns[K any](target ottl.Getter[K], pattern string) (ottl.ExprFunc[K], error) {
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern supplied to ExtractPatterns is not a valid regexp pattern: %w", err)
+	}
+	namedCaptureGroups := 0
+	for _, name := range compiledPattern.SubexpNames() {
+		if name != "" {
+			namedCaptureGroups++
+		}
+	}
+	if namedCaptureGroups == 0 {
+		return nil, errors.New("the pattern supplied to ExtractPatterns must contain at least one named capture group")
+	}
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("target must be a string but got %T", val)
+		}
+
+		result := pcommon.NewMap()
+		matches := compiledPattern.FindStringSubmatch(str)
+		if matches == nil {
+			return result, nil
+		}
+		result.EnsureCapacity(namedCaptureGroups)
+		for i, name := range compiledPattern.SubexpNames() {
+			if name != "" {
+				result.PutStr(name, matches[i])
+			}
+		}
+		return result, nil
+	}, nil
+}
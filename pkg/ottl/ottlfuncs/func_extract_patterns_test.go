@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ExtractPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		pattern  string
+		expected map[string]interface{}
+	}{
+		{
+			name:    "named captures",
+			value:   "GET /api/users 200",
+			pattern: `^(?P<method>\w+) (?P<path>\S+) (\d+)$`,
+			expected: map[string]interface{}{
+				"method": "GET",
+				"path":   "/api/users",
+			},
+		},
+		{
+			name:     "no match",
+			value:    "nothing to see",
+			pattern:  `^(?P<method>GET|POST) `,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:    "empty capture",
+			value:   "user= id=1",
+			pattern: `user=(?P<user>\S*) id=(?P<id>\d+)`,
+			expected: map[string]interface{}{
+				"user": "",
+				"id":   "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ExtractPatterns[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.pattern)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_ExtractPatterns_invalid(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(context.Context, interface{}) (interface{}, error) {
+			return int64(1), nil
+		},
+	}
+
+	_, err := ExtractPatterns[interface{}](target, `(`)
+	assert.Error(t, err)
+
+	_, err = ExtractPatterns[interface{}](target, `(\w+)`)
+	assert.Error(t, err)
+
+	exprFunc, err := ExtractPatterns[interface{}](target, `(?P<word>\w+)`)
+	assert.NoError(t, err)
+	_, err = exprFunc(nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// ParseKeyValue returns a `pcommon.Map` built from the key value pairs of the target string.
+// Pairs are separated by pairDelimiter, and keys are separated from their value by delimiter.
+func ParseKeyValue[K any](target ottl.Getter[K], delimiter string, pairDelimiter string) (ottl.ExprFunc[K], error) {
+	if delimiter == "" || pairDelimiter == "" {
+		return nil, errors.New("delimiter and pair delimiter cannot be empty")
+	}
+	if delimiter == pairDelimiter {
+		return nil, errors.New("delimiter and pair delimiter cannot be the same")
+	}
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("target must be a string but got %T", val)
+		}
+
+		result := pcommon.NewMap()
+		for _, pair := range splitPairs(str, pairDelimiter) {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			key, value, found := strings.Cut(pair, delimiter)
+			if !found {
+				return nil, fmt.Errorf("cannot split %q into a key and a value", pair)
+			}
+			result.PutStr(strings.TrimSpace(key), unquote(strings.TrimSpace(value)))
+		}
+		return result, nil
+	}, nil
+}
+
+// splitPairs splits str around each instance of pairDelimiter that is not within double quotes.
+func splitPairs(str string, pairDelimiter string) []string {
+	var pairs []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(str); i++ {
+		switch {
+		case str[i] == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && strings.HasPrefix(str[i:], pairDelimiter):
+			pairs = append(pairs, str[start:i])
+			start = i + len(pairDelimiter)
+			i += len(pairDelimiter) - 1
+		}
+	}
+	return append(pairs, str[start:])
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
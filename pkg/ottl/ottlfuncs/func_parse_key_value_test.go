@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         interface{}
+		delimiter     string
+		pairDelimiter string
+		expected      map[string]interface{}
+	}{
+		{
+			name:          "simple",
+			value:         "name=otel level=info",
+			delimiter:     "=",
+			pairDelimiter: " ",
+			expected: map[string]interface{}{
+				"name":  "otel",
+				"level": "info",
+			},
+		},
+		{
+			name:          "quoted values and extra whitespace",
+			value:         `  user="John Doe"   msg="a=b c"  `,
+			delimiter:     "=",
+			pairDelimiter: " ",
+			expected: map[string]interface{}{
+				"user": "John Doe",
+				"msg":  "a=b c",
+			},
+		},
+		{
+			name:          "multi character delimiters",
+			value:         "a: 1 || b: 2",
+			delimiter:     ":",
+			pairDelimiter: "||",
+			expected: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+		},
+		{
+			name:          "empty value",
+			value:         "a=&b=2",
+			delimiter:     "=",
+			pairDelimiter: "&",
+			expected: map[string]interface{}{
+				"a": "",
+				"b": "2",
+			},
+		},
+		{
+			name:          "empty string",
+			value:         "",
+			delimiter:     "=",
+			pairDelimiter: " ",
+			expected:      map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseKeyValue[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.delimiter, tt.pairDelimiter)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_ParseKeyValue_error(t *testing.T) {
+	target := func(val interface{}) ottl.Getter[interface{}] {
+		return &ottl.StandardGetSetter[interface{}]{
+			Getter: func(context.Context, interface{}) (interface{}, error) {
+				return val, nil
+			},
+		}
+	}
+
+	_, err := ParseKeyValue[interface{}](target("a=b"), "", " ")
+	assert.Error(t, err)
+	_, err = ParseKeyValue[interface{}](target("a=b"), "=", "=")
+	assert.Error(t, err)
+
+	exprFunc, err := ParseKeyValue[interface{}](target("a=b c"), "=", " ")
+	assert.NoError(t, err)
+	_, err = exprFunc(nil, nil)
+	assert.Error(t, err)
+
+	exprFunc, err = ParseKeyValue[interface{}](target(int64(1)), "=", " ")
+	assert.NoError(t, err)
+	_, err = exprFunc(nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func String[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch value := value.(type) {
+		case string:
+			return value, nil
+		case int64:
+			return strconv.FormatInt(value, 10), nil
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(value), nil
+		case []byte:
+			return hex.EncodeToString(value), nil
+		// maps are marshaled with sorted keys, so that the result is deterministic
+		case pcommon.Map:
+			return jsoniter.ConfigCompatibleWithStandardLibrary.MarshalToString(value.AsRaw())
+		case pcommon.Slice:
+			return jsoniter.ConfigCompatibleWithStandardLibrary.MarshalToString(value.AsRaw())
+		case map[string]interface{}, []interface{}:
+			return jsoniter.ConfigCompatibleWithStandardLibrary.MarshalToString(value)
+		default:
+			return nil, nil
+		}
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_String(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("key", "value")
+	m.PutInt("int", 1)
+
+	s := pcommon.NewSlice()
+	s.AppendEmpty().SetStr("a")
+	s.AppendEmpty().SetBool(true)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			value:    "test",
+			expected: "test",
+		},
+		{
+			name:     "int64",
+			value:    int64(-12),
+			expected: "-12",
+		},
+		{
+			name:     "float64",
+			value:    2.5,
+			expected: "2.5",
+		},
+		{
+			name:     "bool",
+			value:    true,
+			expected: "true",
+		},
+		{
+			name:     "bytes",
+			value:    []byte{1, 2, 255},
+			expected: "0102ff",
+		},
+		{
+			name:     "map",
+			value:    m,
+			expected: `{"int":1,"key":"value"}`,
+		},
+		{
+			name:     "slice",
+			value:    s,
+			expected: `["a",true]`,
+		},
+		{
+			name:     "raw list",
+			value:    []interface{}{"a", int64(1)},
+			expected: `["a",1]`,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := String[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			assert.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
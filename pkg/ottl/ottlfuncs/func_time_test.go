@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		layout   string
+		expected time.Time
+	}{
+		{
+			name:     "RFC3339",
+			value:    "2022-12-08T10:11:12Z",
+			layout:   time.RFC3339,
+			expected: time.Date(2022, 12, 8, 10, 11, 12, 0, time.UTC),
+		},
+		{
+			name:     "custom layout with offset",
+			value:    "08/12/2022 10:11:12.500 +0100",
+			layout:   "02/01/2006 15:04:05.000 -0700",
+			expected: time.Date(2022, 12, 8, 9, 11, 12, 500000000, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Time[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.layout)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result.(time.Time)))
+		})
+	}
+}
+
+func Test_Time_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "not a string",
+			value: int64(1),
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+		{
+			name:  "not matching the layout",
+			value: "yesterday",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Time[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, time.RFC3339)
+			assert.NoError(t, err)
+			_, err = exprFunc(nil, nil)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := Time[interface{}](&ottl.StandardGetSetter[interface{}]{}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_UnixNano(t *testing.T) {
+	exprFunc, err := UnixNano[interface{}](&ottl.StandardGetSetter[interface{}]{
+		Getter: func(context.Context, interface{}) (interface{}, error) {
+			return time.Date(2022, 12, 8, 10, 11, 12, 13, time.UTC), nil
+		},
+	})
+	assert.NoError(t, err)
+	result, err := exprFunc(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1670494272000000013), result)
+}
+
+func Test_UnixNano_error(t *testing.T) {
+	exprFunc, err := UnixNano[interface{}](&ottl.StandardGetSetter[interface{}]{
+		Getter: func(context.Context, interface{}) (interface{}, error) {
+			return "2022-12-08T10:11:12Z", nil
+		},
+	})
+	assert.NoError(t, err)
+	_, err = exprFunc(nil, nil)
+	assert.Error(t, err)
+}
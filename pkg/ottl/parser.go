@@ -64,6 +64,17 @@ func NewParser[K any](functions map[string]interface{}, pathParser PathExpressio
 	}
 }
 
+// parsePath sets the deprecated MapKey of the fields of path and interprets it with the PathExpressionParser.
+func (p *Parser[K]) parsePath(path *Path) (GetSetter[K], error) {
+	for i := range path.Fields {
+		field := &path.Fields[i]
+		if len(field.Keys) > 0 && field.Keys[0].String != nil {
+			field.MapKey = field.Keys[0].String
+		}
+	}
+	return p.pathParser(path)
+}
+
 func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error) {
 	var parsedStatements []*Statement[K]
 	var errors error
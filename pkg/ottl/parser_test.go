@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
 )
@@ -88,7 +90,7 @@ func Test_parse(t *testing.T) {
 		},
 		{
 			name:      "complex invocation",
-			statement: `set("foo", getSomething(bear.honey))`,
+			statement: `set("foo", GetSomething(bear.honey))`,
 			expected: &parsedStatement{
 				Invocation: invocation{
 					Function: "set",
@@ -98,8 +100,8 @@ func Test_parse(t *testing.T) {
 						},
 						{
 							Literal: &mathExprLiteral{
-								Invocation: &invocation{
-									Function: "getSomething",
+								Converter: &converter{
+									Function: "GetSomething",
 									Arguments: []value{
 										{
 											Literal: &mathExprLiteral{
@@ -139,8 +141,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -172,8 +174,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -227,8 +229,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -282,8 +284,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -385,8 +387,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bytes"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bytes")}},
 										},
 									},
 								},
@@ -412,8 +414,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -439,8 +441,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -466,8 +468,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -495,8 +497,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -528,8 +530,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -564,8 +566,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -576,7 +578,7 @@ func Test_parse(t *testing.T) {
 								Values: []value{
 									{
 										Literal: &mathExprLiteral{
-											Invocation: &invocation{
+											Converter: &converter{
 												Function: "Concat",
 												Arguments: []value{
 													{
@@ -625,8 +627,8 @@ func Test_parse(t *testing.T) {
 											Path: &Path{
 												Fields: []Field{
 													{
-														Name:   "attributes",
-														MapKey: ottltest.Strp("test"),
+														Name: "attributes",
+														Keys: []Key{{String: ottltest.Strp("test")}},
 													},
 												},
 											},
@@ -642,7 +644,7 @@ func Test_parse(t *testing.T) {
 		},
 		{
 			name:      "Invocation math mathExpression",
-			statement: `set(attributes["test"], 1000 - 600) where 1 + 1 * 2 == three / one()`,
+			statement: `set(attributes["test"], 1000 - 600) where 1 + 1 * 2 == three / One()`,
 			expected: &parsedStatement{
 				Invocation: invocation{
 					Function: "set",
@@ -652,8 +654,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -741,8 +743,8 @@ func Test_parse(t *testing.T) {
 													Operator: DIV,
 													Value: &mathValue{
 														Literal: &mathExprLiteral{
-															Invocation: &invocation{
-																Function: "one",
+															Converter: &converter{
+																Function: "One",
 															},
 														},
 													},
@@ -757,6 +759,129 @@ func Test_parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "nested indexing and map literal",
+			statement: `set(body["items"][0], {"key": "value", "list": [1, 2]})`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "body",
+											Keys: []Key{
+												{String: ottltest.Strp("items")},
+												{Int: ottltest.Intp(0)},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Map: &mapValue{
+								Values: []mapItem{
+									{
+										Key:   ottltest.Strp("key"),
+										Value: &value{String: ottltest.Strp("value")},
+									},
+									{
+										Key: ottltest.Strp("list"),
+										Value: &value{
+											List: &list{
+												Values: []value{
+													{Literal: &mathExprLiteral{Int: ottltest.Intp(1)}},
+													{Literal: &mathExprLiteral{Int: ottltest.Intp(2)}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "empty map literal",
+			statement: `set(attributes["test"], {})`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
+										},
+									},
+								},
+							},
+						},
+						{
+							Map: &mapValue{},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "indexed converter",
+			statement: `set(name, Split(attributes["test"], ",")[1])`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
+									},
+								},
+							},
+						},
+						{
+							Literal: &mathExprLiteral{
+								Converter: &converter{
+									Function: "Split",
+									Arguments: []value{
+										{
+											Literal: &mathExprLiteral{
+												Path: &Path{
+													Fields: []Field{
+														{
+															Name: "attributes",
+															Keys: []Key{{String: ottltest.Strp("test")}},
+														},
+													},
+												},
+											},
+										},
+										{
+											String: ottltest.Strp(","),
+										},
+									},
+									Keys: []Key{{Int: ottltest.Intp(1)}},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1189,6 +1314,61 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			statement: `IsMatch(name, "foo") and not IsMatch(name, "bar")`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Converter: &converter{
+							Function: "IsMatch",
+							Arguments: []value{
+								{
+									Literal: &mathExprLiteral{
+										Path: &Path{
+											Fields: []Field{
+												{
+													Name: "name",
+												},
+											},
+										},
+									},
+								},
+								{
+									String: ottltest.Strp("foo"),
+								},
+							},
+						},
+					},
+					Right: []*opAndBooleanValue{
+						{
+							Operator: "and",
+							Value: &booleanValue{
+								Negation: ottltest.Strp("not"),
+								Converter: &converter{
+									Function: "IsMatch",
+									Arguments: []value{
+										{
+											Literal: &mathExprLiteral{
+												Path: &Path{
+													Fields: []Field{
+														{
+															Name: "name",
+														},
+													},
+												},
+											},
+										},
+										{
+											String: ottltest.Strp("bar"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	// create a test name that doesn't confuse vscode so we can rerun tests with one click
@@ -1244,6 +1424,15 @@ func Test_parseStatement(t *testing.T) {
 		{`drop() where ==`, true},
 		{`drop() where == animal`, true},
 		{`drop() where attributes["path"] == "/healthcheck"`, false},
+		{`drop() where attributes["http"]["path"] == "/healthcheck"`, false},
+		{`drop() where attributes["list"][0] == 1`, false},
+		{`drop() where attributes[1.5] == 1`, true},
+		{`drop() where IsMatch(name, "foo")`, false},
+		{`drop() where not IsMatch(name, "foo") or Int(attributes["count"]) > 1`, false},
+		{`drop() where isMatch(name, "foo")`, true},
+		{`set(attributes["map"], {"a": 1, "b": {"c": [true, nil]}})`, false},
+		{`set(attributes["map"], {"a"})`, true},
+		{`set(attributes["map"], {a: 1})`, true},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -1308,3 +1497,23 @@ func Test_Execute(t *testing.T) {
 		})
 	}
 }
+
+func Test_parsePath_deprecatedMapKey(t *testing.T) {
+	var parsed []Path
+	p := NewParser(
+		defaultFunctionsForTests(),
+		func(path *Path) (GetSetter[interface{}], error) {
+			parsed = append(parsed, *path)
+			return testParsePath(&Path{Fields: []Field{{Name: "name"}}})
+		},
+		testParseEnum,
+		component.TelemetrySettings{},
+	)
+
+	_, err := p.ParseStatements([]string{`testing_getsetter(attributes["foo"][0]) where resource.attributes[0] == nil`})
+	require.NoError(t, err)
+
+	require.Len(t, parsed, 2)
+	assert.Equal(t, ottltest.Strp("foo"), parsed[0].Fields[0].MapKey)
+	assert.Nil(t, parsed[1].Fields[1].MapKey)
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionAuto = "auto"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	// tarMagic is found at offset 257 of both POSIX and GNU tar archives
+	tarMagic       = []byte("ustar")
+	tarMagicOffset = 257
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionGzip, compressionZstd, compressionAuto:
+		return nil
+	default:
+		return fmt.Errorf("invalid `compression` '%s', must be one of '%s', '%s' or '%s'",
+			compression, compressionGzip, compressionZstd, compressionAuto)
+	}
+}
+
+// detectCompression returns the compression of a file, based on the
+// configured compression and the first bytes of the file.
+func detectCompression(compression string, fp *Fingerprint) string {
+	if compression != compressionAuto {
+		return compression
+	}
+	switch {
+	case bytes.HasPrefix(fp.FirstBytes, gzipMagic):
+		return compressionGzip
+	case bytes.HasPrefix(fp.FirstBytes, zstdMagic):
+		return compressionZstd
+	default:
+		return compressionNone
+	}
+}
+
+func newDecompressor(compression string, r io.Reader) (io.ReadCloser, error) {
+	switch compression {
+	case compressionGzip:
+		return gzip.NewReader(r)
+	case compressionZstd:
+		dec, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+// readCompressed reads a compressed file from the start. Compressed files are
+// treated as immutable, so a file is only read once: after it has been read
+// completely, the offset is moved to its end and the file is ignored until its
+// fingerprint changes. If the decompressed content is a tar archive, each
+// regular file in it is read with the name of the member as file name.
+func (r *Reader) readCompressed(ctx context.Context) {
+	if r.Offset > 0 {
+		return
+	}
+
+	// The file is decompressed once before emitting anything, so that a file
+	// which is still being written is not partially read.
+	if err := r.verifyCompressed(); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			r.Debugw("Compressed file is incomplete, it will be read once complete")
+			return
+		}
+		r.Errorw("Failed to decompress file", zap.Error(err))
+	} else if err := r.emitCompressed(ctx); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		r.Errorw("Failed to read compressed file", zap.Error(err))
+	}
+
+	if err := r.offsetToEnd(); err != nil {
+		r.Errorw("Failed to mark compressed file as read", zap.Error(err))
+	}
+}
+
+func (r *Reader) openDecompressor() (io.ReadCloser, error) {
+	if _, err := r.file.Seek(0, 0); err != nil {
+		return nil, fmt.Errorf("seek: %w", err)
+	}
+	return newDecompressor(r.fileCompression, r.file)
+}
+
+// verifyCompressed checks that the whole file can be decompressed.
+func (r *Reader) verifyCompressed() error {
+	dec, err := r.openDecompressor()
+	if err != nil {
+		return err
+	}
+	defer dec.Close()
+	_, err = io.Copy(io.Discard, dec)
+	return err
+}
+
+func (r *Reader) emitCompressed(ctx context.Context) error {
+	dec, err := r.openDecompressor()
+	if err != nil {
+		return err
+	}
+	defer dec.Close()
+
+	br := bufio.NewReader(dec)
+	if isTar(br) {
+		return r.readTar(ctx, tar.NewReader(br))
+	}
+	return r.readStream(ctx, br, r.fileAttributes)
+}
+
+func isTar(br *bufio.Reader) bool {
+	header, err := br.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(header[tarMagicOffset:], tarMagic)
+}
+
+func (r *Reader) readTar(ctx context.Context, tr *tar.Reader) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar header: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		attrs := *r.fileAttributes
+		attrs.Name = path.Base(hdr.Name)
+		attrs.NameResolved = attrs.Name
+		if err := r.readStream(ctx, tr, &attrs); err != nil {
+			return fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+	}
+}
+
+// readStream emits all tokens of a decompressed stream. Since the stream is
+// complete, a trailing token without a terminator is emitted as well.
+func (r *Reader) readStream(ctx context.Context, src io.Reader, attrs *FileAttributes) error {
+	scanner := NewPositionalScanner(src, r.maxLogSize, 0, flushAtEOF(r.splitFunc))
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if ok := scanner.Scan(); !ok {
+			return scanner.getError()
+		}
+
+		token, err := r.encoding.Decode(scanner.Bytes())
+		if err != nil {
+			r.Errorw("decode: %w", zap.Error(err))
+			continue
+		}
+		r.emit(ctx, attrs, token)
+	}
+}
+
+// flushAtEOF wraps a split func so that any remaining data is returned as a
+// final token once the end of the input has been reached.
+func flushAtEOF(splitFunc bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := splitFunc(data, atEOF)
+		if err != nil || advance > 0 || token != nil || !atEOF || len(data) == 0 {
+			return advance, token, err
+		}
+		return len(data), bytes.TrimSpace(data), nil
+	}
+}
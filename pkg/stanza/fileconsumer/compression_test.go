@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func writeGzip(t *testing.T, path string, content []byte) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
+
+func writeZstd(t *testing.T, path string, content []byte) {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
+
+func tarArchive(t *testing.T, members map[string]string) []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0700}))
+	for name, content := range members {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0600, Size: int64(len(content))}))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestReadCompressed(t *testing.T) {
+	testCases := []struct {
+		name        string
+		compression string
+		write       func(*testing.T, string, []byte)
+	}{
+		{"gzip", compressionGzip, writeGzip},
+		{"zstd", compressionZstd, writeZstd},
+		{"auto_gzip", compressionAuto, writeGzip},
+		{"auto_zstd", compressionAuto, writeZstd},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			// The last line is emitted even without a trailing newline
+			tc.write(t, filepath.Join(tempDir, "app.log.1"), []byte("testlog1\ntestlog2\ntestlog3"))
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForToken(t, emitCalls, []byte("testlog1"))
+			waitForToken(t, emitCalls, []byte("testlog2"))
+			waitForToken(t, emitCalls, []byte("testlog3"))
+
+			// A compressed file is read only once
+			expectNoTokensUntil(t, emitCalls, 5*cfg.PollInterval)
+		})
+	}
+}
+
+func TestReadCompressedAutoPlainFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	writeGzip(t, filepath.Join(tempDir, "app.log.1.gz"), []byte("old\n"))
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "new1\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("old"), []byte("new1")})
+	writeString(t, temp, "new2\n")
+	waitForToken(t, emitCalls, []byte("new2"))
+}
+
+func TestReadCompressedTar(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	archive := filepath.Join(tempDir, "archive.tar.gz")
+	writeGzip(t, archive, tarArchive(t, map[string]string{
+		"logs/a.log": "a1\na2\n",
+		"logs/b.log": "b1\n",
+	}))
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	received := make(map[string][]string)
+	for i := 0; i < 3; i++ {
+		call := waitForEmit(t, emitCalls)
+		require.Equal(t, archive, call.attrs.Path)
+		received[call.attrs.Name] = append(received[call.attrs.Name], string(call.token))
+	}
+	require.Equal(t, map[string][]string{"a.log": {"a1", "a2"}, "b.log": {"b1"}}, received)
+	expectNoTokens(t, emitCalls)
+}
+
+func TestReadCompressedStartAtEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	writeGzip(t, filepath.Join(tempDir, "old.log.gz"), []byte("old\n"))
+
+	// Files found on the first poll are skipped, files found later are read
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	writeGzip(t, filepath.Join(tempDir, "new.log.gz"), []byte("new\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("new"))
+}
+
+func TestReadCompressedIncomplete(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte("testlog1\ntestlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	content := buf.Bytes()
+
+	// A file which is still being written is not read
+	temp := openTemp(t, tempDir)
+	_, err = temp.Write(content[:len(content)-4])
+	require.NoError(t, err)
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	// Once complete, it is read exactly once
+	_, err = temp.Write(content[len(content)-4:])
+	require.NoError(t, err)
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestDetectCompression(t *testing.T) {
+	gzipped := &Fingerprint{FirstBytes: []byte{0x1f, 0x8b, 0x08}}
+	zstded := &Fingerprint{FirstBytes: []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}}
+	plain := &Fingerprint{FirstBytes: []byte("plain text")}
+
+	require.Equal(t, compressionGzip, detectCompression(compressionAuto, gzipped))
+	require.Equal(t, compressionZstd, detectCompression(compressionAuto, zstded))
+	require.Equal(t, compressionNone, detectCompression(compressionAuto, plain))
+	require.Equal(t, compressionGzip, detectCompression(compressionGzip, plain))
+	require.Equal(t, compressionNone, detectCompression(compressionNone, gzipped))
+}
@@ -59,6 +59,8 @@ type Config struct {
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
+	Header                  *HeaderConfig         `mapstructure:"header,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
@@ -109,6 +111,15 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 	default:
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
+
+	var hs *headerSettings
+	if c.Header != nil {
+		var err error
+		if hs, err = c.Header.buildHeaderSettings(c.Splitter.EncodingConfig); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -118,6 +129,8 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
 				emit:            emit,
+				header:          hs,
+				compression:     c.Compression,
 			},
 			fromBeginning:   startAtBeginning,
 			splitterFactory: factory,
@@ -169,5 +182,18 @@ func (c Config) validate() error {
 	if err != nil {
 		return err
 	}
+
+	if err := validateCompression(c.Compression); err != nil {
+		return err
+	}
+
+	if c.Header != nil {
+		if c.StartAt == "end" {
+			return fmt.Errorf("`header` cannot be used with `start_at: end`")
+		}
+		if err := c.Header.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -22,8 +22,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -32,6 +34,20 @@ func TestUnmarshal(t *testing.T) {
 		DefaultConfig: newMockOperatorConfig(NewConfig()),
 		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
 		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name: "header",
+				Expect: func() *mockOperatorConfig {
+					regexCfg := regex.NewConfig()
+					regexCfg.Regex = "^#Fields: (?P<header_fields>.*)$"
+					cfg := NewConfig()
+					cfg.StartAt = "beginning"
+					cfg.Header = &HeaderConfig{
+						Pattern:           "^#",
+						MetadataOperators: []operator.Config{operator.NewConfig(regexCfg)},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "include_one",
 				Expect: func() *mockOperatorConfig {
@@ -336,6 +352,14 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "gzip"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "encoding_lower",
 				Expect: func() *mockOperatorConfig {
@@ -483,6 +507,72 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"Compression",
+			func(f *Config) {
+				f.Compression = "auto"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "auto", f.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"Header",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.Header = w3cHeaderConfig()
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.NotNil(t, f.readerFactory.readerConfig.header)
+			},
+		},
+		{
+			"HeaderWithStartAtEnd",
+			func(f *Config) {
+				f.Header = w3cHeaderConfig()
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"HeaderMissingPattern",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.Header = w3cHeaderConfig()
+				f.Header.Pattern = ""
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"HeaderInvalidPattern",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.Header = w3cHeaderConfig()
+				f.Header.Pattern = "("
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"HeaderMissingMetadataOperators",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.Header = &HeaderConfig{Pattern: "^#"}
+			},
+			require.Error,
+			nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+)
+
+const headerPipelineOutputType = "header_pipeline_output"
+
+// HeaderConfig is the configuration of the header of a file. The header
+// consists of the consecutive lines at the start of a file that match the
+// pattern. Each header line is sent through the metadata operators, and the
+// attributes they set are added to every entry read from the file.
+type HeaderConfig struct {
+	Pattern           string            `mapstructure:"pattern"`
+	MetadataOperators []operator.Config `mapstructure:"metadata_operators"`
+}
+
+func (c *HeaderConfig) validate() error {
+	if c.Pattern == "" {
+		return errors.New("`header.pattern` is required")
+	}
+	if _, err := regexp.Compile(c.Pattern); err != nil {
+		return fmt.Errorf("invalid `header.pattern`: %w", err)
+	}
+	if len(c.MetadataOperators) == 0 {
+		return errors.New("`header.metadata_operators` must not be empty")
+	}
+	return nil
+}
+
+// headerSettings are the settings shared by the readers of all files
+type headerSettings struct {
+	regex     *regexp.Regexp
+	splitFunc bufio.SplitFunc
+	config    *HeaderConfig
+}
+
+func (c *HeaderConfig) buildHeaderSettings(encodingConfig helper.EncodingConfig) (*headerSettings, error) {
+	regex, err := regexp.Compile(c.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `header.pattern`: %w", err)
+	}
+	enc, err := encodingConfig.Build()
+	if err != nil {
+		return nil, err
+	}
+	splitFunc, err := helper.NewNewlineSplitFunc(enc.Encoding, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create header split func: %w", err)
+	}
+	return &headerSettings{
+		regex:     regex,
+		splitFunc: splitFunc,
+		config:    c,
+	}, nil
+}
+
+// buildPipeline builds and starts the metadata operators of the header into a
+// pipeline whose output sets the resulting attributes on the given map. Each
+// reader uses its own pipeline, since files are read concurrently, and stops
+// it once the header is read or the reader is closed. The state of the
+// operators is not persisted, as the pipeline only lives as long as the reader.
+func (s *headerSettings) buildPipeline(logger *zap.SugaredLogger, attributes map[string]interface{}) (pipeline.Pipeline, error) {
+	outputOperator, err := helper.NewOutputConfig(headerPipelineOutputType, headerPipelineOutputType).Build(logger)
+	if err != nil {
+		return nil, err
+	}
+	output := &headerPipelineOutput{
+		OutputOperator: outputOperator,
+		attributes:     attributes,
+	}
+
+	p, err := pipeline.Config{
+		Operators:     s.config.MetadataOperators,
+		DefaultOutput: output,
+	}.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build header pipeline: %w", err)
+	}
+	if err = p.Start(storage.NewNopClient()); err != nil {
+		return nil, multierr.Append(fmt.Errorf("failed to start header pipeline: %w", err), p.Stop())
+	}
+	return p, nil
+}
+
+// headerPipelineOutput is the last operator of a header pipeline. It collects
+// the attributes of the header entries.
+type headerPipelineOutput struct {
+	helper.OutputOperator
+	attributes map[string]interface{}
+}
+
+// Process copies the attributes of the entry to the header attributes.
+func (o *headerPipelineOutput) Process(_ context.Context, ent *entry.Entry) error {
+	for k, v := range ent.Attributes {
+		o.attributes[k] = v
+	}
+	return nil
+}
+
+// readHeader reads the header lines of the file, starting at the current
+// offset, and sends them through the header pipeline. It returns true once the
+// first line after the header has been found, and false if the end of the
+// file was reached before that.
+func (r *Reader) readHeader(ctx context.Context) bool {
+	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+		r.Errorw("Failed to seek", zap.Error(err))
+		return false
+	}
+
+	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.header.splitFunc)
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+		}
+
+		if ok := scanner.Scan(); !ok {
+			if err := scanner.getError(); err != nil {
+				r.Errorw("Failed during header scan", zap.Error(err))
+			}
+			return false
+		}
+
+		token, err := r.encoding.Decode(scanner.Bytes())
+		if err != nil {
+			r.Errorw("decode: %w", zap.Error(err))
+		} else if !r.header.regex.Match(token) {
+			// The header ends with the first line that does not match. The
+			// line itself is read again as a regular entry.
+			r.HeaderFinalized = true
+			r.stopHeaderPipeline()
+			return true
+		} else {
+			ent := entry.New()
+			ent.Body = string(token)
+			if err := r.headerPipeline.Operators()[0].Process(ctx, ent); err != nil {
+				r.Errorw("Failed to process header line", zap.Error(err))
+			}
+		}
+
+		r.Offset = scanner.Pos()
+	}
+}
+
+// stopHeaderPipeline stops the header pipeline of the reader, if any.
+func (r *Reader) stopHeaderPipeline() {
+	if r.headerPipeline == nil {
+		return
+	}
+	if err := r.headerPipeline.Stop(); err != nil {
+		r.Errorw("Failed to stop header pipeline", zap.Error(err))
+	}
+	r.headerPipeline = nil
+}
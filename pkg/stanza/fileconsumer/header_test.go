@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func w3cHeaderConfig() *HeaderConfig {
+	regexCfg := regex.NewConfig()
+	regexCfg.Regex = "^#Fields: (?P<header_fields>.*)$"
+	return &HeaderConfig{
+		Pattern:           "^#",
+		MetadataOperators: []operator.Config{operator.NewConfig(regexCfg)},
+	}
+}
+
+func waitForHeaderToken(t *testing.T, c chan *emitParams, expected string, headerFields string) {
+	call := waitForEmit(t, c)
+	require.Equal(t, []byte(expected), call.token)
+	require.Equal(t, map[string]interface{}{"header_fields": headerFields}, call.attrs.HeaderAttributes)
+}
+
+func TestHeader(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Version: 1.0\n#Fields: date time c-ip\n2022-01-01 00:00:00 10.0.0.1\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForHeaderToken(t, emitCalls, "2022-01-01 00:00:00 10.0.0.1", "date time c-ip")
+	writeString(t, temp, "2022-01-01 00:00:01 10.0.0.2\n")
+	waitForHeaderToken(t, emitCalls, "2022-01-01 00:00:01 10.0.0.2", "date time c-ip")
+}
+
+func TestHeaderIncomplete(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	// Nothing is emitted until the end of the header is known
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Version: 1.0\n#Fields: date time")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	expectNoTokens(t, emitCalls)
+	writeString(t, temp, " c-ip\n2022-01-01 00:00:00 10.0.0.1\n")
+	waitForHeaderToken(t, emitCalls, "2022-01-01 00:00:00 10.0.0.1", "date time c-ip")
+}
+
+func TestHeaderFilesAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp1 := openTemp(t, tempDir)
+	writeString(t, temp1, "#Fields: a b\n1 2\n")
+	temp2 := openTemp(t, tempDir)
+	writeString(t, temp2, "#Fields: x y z\n7 8 9\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	received := make(map[string]string)
+	for i := 0; i < 2; i++ {
+		call := waitForEmit(t, emitCalls)
+		received[string(call.token)] = call.attrs.HeaderAttributes["header_fields"].(string)
+	}
+	require.Equal(t, map[string]string{"1 2": "a b", "7 8 9": "x y z"}, received)
+}
+
+func TestHeaderPersistedAcrossRestart(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	persister := testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: date time c-ip\n2022-01-01 00:00:00 10.0.0.1\n")
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	waitForHeaderToken(t, emitCallsOne, "2022-01-01 00:00:00 10.0.0.1", "date time c-ip")
+	require.NoError(t, operatorOne.Stop())
+
+	writeString(t, temp, "2022-01-01 00:00:01 10.0.0.2\n")
+
+	// The header is not read again, but its attributes are restored
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	defer func() {
+		require.NoError(t, operatorTwo.Stop())
+	}()
+	waitForHeaderToken(t, emitCallsTwo, "2022-01-01 00:00:01 10.0.0.2", "date time c-ip")
+	expectNoTokens(t, emitCallsTwo)
+}
+
+func TestHeaderPipelineLifecycle(t *testing.T) {
+	t.Parallel()
+
+	lifecycle := &lifecycleConfig{TransformerConfig: helper.NewTransformerConfig("lifecycle", "lifecycle")}
+	cfg := w3cHeaderConfig()
+	cfg.MetadataOperators = append([]operator.Config{operator.NewConfig(lifecycle)}, cfg.MetadataOperators...)
+	settings, err := cfg.buildHeaderSettings(helper.NewEncodingConfig())
+	require.NoError(t, err)
+
+	// the pipeline is started once built
+	attributes := make(map[string]interface{})
+	p, err := settings.buildPipeline(testutil.Logger(t), attributes)
+	require.NoError(t, err)
+	require.True(t, lifecycle.started)
+	require.False(t, lifecycle.stopped)
+
+	require.NoError(t, p.Operators()[0].Process(context.Background(), &entry.Entry{Body: "#Fields: a b"}))
+	require.Equal(t, map[string]interface{}{"header_fields": "a b"}, attributes)
+
+	// and stopped when the reader is closed
+	r := &Reader{SugaredLogger: testutil.Logger(t), headerPipeline: p}
+	r.Close()
+	require.True(t, lifecycle.stopped)
+	require.Nil(t, r.headerPipeline)
+}
+
+// lifecycleConfig builds a transformer recording whether it was started and stopped.
+type lifecycleConfig struct {
+	helper.TransformerConfig
+	started bool
+	stopped bool
+}
+
+func (c *lifecycleConfig) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformer, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &lifecycleOperator{TransformerOperator: transformer, config: c}, nil
+}
+
+type lifecycleOperator struct {
+	helper.TransformerOperator
+	config *lifecycleConfig
+}
+
+func (o *lifecycleOperator) Start(operator.Persister) error {
+	o.config.started = true
+	return nil
+}
+
+func (o *lifecycleOperator) Stop() error {
+	o.config.stopped = true
+	return nil
+}
+
+func (o *lifecycleOperator) Process(ctx context.Context, ent *entry.Entry) error {
+	o.Write(ctx, ent)
+	return nil
+}
@@ -23,12 +23,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
 	emit            EmitFunc
+	header          *headerSettings
+	compression     string
 }
 
 // Reader manages a single file
@@ -38,11 +41,19 @@ type Reader struct {
 	splitFunc bufio.SplitFunc
 	encoding  helper.Encoding
 
-	Fingerprint    *Fingerprint
-	Offset         int64
+	Fingerprint *Fingerprint
+	Offset      int64
+	// HeaderFinalized is set once all header lines of the file have been read
+	HeaderFinalized bool
+	// HeaderAttributes holds the attributes parsed from the header lines
+	HeaderAttributes map[string]interface{}
+
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+	headerPipeline pipeline.Pipeline
+	// fileCompression is the compression of the file, if any
+	fileCompression string
 }
 
 // offsetToEnd sets the starting offset
@@ -57,6 +68,17 @@ func (r *Reader) offsetToEnd() error {
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	if r.fileCompression != compressionNone {
+		r.readCompressed(ctx)
+		return
+	}
+
+	if r.headerPipeline != nil && !r.HeaderFinalized {
+		if !r.readHeader(ctx) {
+			return
+		}
+	}
+
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
@@ -91,8 +113,9 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 }
 
-// Close will close the file
+// Close will close the file and stop the header pipeline
 func (r *Reader) Close() {
+	r.stopHeaderPipeline()
 	if r.file != nil {
 		if err := r.file.Close(); err != nil {
 			r.Debugw("Problem closing reader", zap.Error(err))
@@ -45,6 +45,7 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
 		withSplitterFunc(old.splitFunc).
+		withHeader(old.HeaderFinalized, old.HeaderAttributes).
 		build()
 }
 
@@ -58,10 +59,12 @@ func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
 
 type readerBuilder struct {
 	*readerFactory
-	file      *os.File
-	fp        *Fingerprint
-	offset    int64
-	splitFunc bufio.SplitFunc
+	file             *os.File
+	fp               *Fingerprint
+	offset           int64
+	splitFunc        bufio.SplitFunc
+	headerFinalized  bool
+	headerAttributes map[string]interface{}
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -88,10 +91,24 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withHeader(finalized bool, attributes map[string]interface{}) *readerBuilder {
+	b.headerFinalized = finalized
+	b.headerAttributes = attributes
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:    b.readerConfig,
+		Offset:          b.offset,
+		HeaderFinalized: b.headerFinalized,
+	}
+
+	if b.readerConfig.header != nil {
+		r.HeaderAttributes = make(map[string]interface{}, len(b.headerAttributes))
+		for k, v := range b.headerAttributes {
+			r.HeaderAttributes[k] = v
+		}
 	}
 
 	if b.splitFunc != nil {
@@ -116,10 +133,19 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
+		r.fileAttributes.HeaderAttributes = r.HeaderAttributes
+
+		if b.readerConfig.header != nil && !r.HeaderFinalized {
+			r.headerPipeline, err = b.readerConfig.header.buildPipeline(r.SugaredLogger, r.HeaderAttributes)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
+				r.stopHeaderPipeline()
 				return nil, err
 			}
 		}
@@ -132,10 +158,15 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 	} else if b.file != nil {
 		fp, err := b.readerFactory.newFingerprint(r.file)
 		if err != nil {
+			r.stopHeaderPipeline()
 			return nil, err
 		}
 		r.Fingerprint = fp
 	}
 
+	if b.file != nil && r.Fingerprint != nil {
+		r.fileCompression = detectCompression(b.readerConfig.compression, r.Fingerprint)
+	}
+
 	return r, nil
 }
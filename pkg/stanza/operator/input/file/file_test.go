@@ -26,7 +26,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -68,6 +71,32 @@ func TestAddFileFields(t *testing.T) {
 	require.Equal(t, temp.Name(), e.Attributes["log.file.path"])
 }
 
+// TestAddHeaderAttributes tests that the attributes parsed from the header of
+// a file are added to every entry read from it
+func TestAddHeaderAttributes(t *testing.T) {
+	t.Parallel()
+	regexCfg := regex.NewConfig()
+	regexCfg.Regex = "^#Fields: (?P<header_fields>.*)$"
+	operator, logReceived, tempDir := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Header = &fileconsumer.HeaderConfig{
+			Pattern:           "^#",
+			MetadataOperators: []operator.Config{operator.NewConfig(regexCfg)},
+		}
+	})
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: id name\n1 stanza\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, "1 stanza", e.Body)
+	require.Equal(t, "id name", e.Attributes["header_fields"])
+}
+
 // AddFileResolvedFields tests that the `log.file.name_resolved` and `log.file.path_resolved` fields are included
 // when IncludeFileNameResolved and IncludeFilePathResolved are set to true
 func TestAddFileResolvedFields(t *testing.T) {
@@ -76,6 +76,17 @@ func TestConfig(t *testing.T) {
 					return p
 				}(),
 			},
+			{
+				Name: "header_delimiter",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.HeaderAttribute = "header_field"
+					p.HeaderDelimiter = " "
+					p.ParseFrom = entry.NewBodyField("message")
+					p.FieldDelimiter = ","
+					return p
+				}(),
+			},
 			{
 				Name: "timestamp",
 				Expect: func() *Config {
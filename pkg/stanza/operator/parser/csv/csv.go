@@ -53,6 +53,7 @@ type Config struct {
 	Header          string `mapstructure:"header"`
 	HeaderAttribute string `mapstructure:"header_attribute"`
 	FieldDelimiter  string `mapstructure:"delimiter"`
+	HeaderDelimiter string `mapstructure:"header_delimiter"`
 	LazyQuotes      bool   `mapstructure:"lazy_quotes"`
 	IgnoreQuotes    bool   `mapstructure:"ignore_quotes"`
 }
@@ -78,22 +79,27 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("invalid 'delimiter': '%s'", c.FieldDelimiter)
 	}
 
+	if c.HeaderDelimiter == "" {
+		c.HeaderDelimiter = c.FieldDelimiter
+	}
+
 	var headers []string
 	switch {
 	case c.Header == "" && c.HeaderAttribute == "":
 		return nil, errors.New("missing required field 'header' or 'header_attribute'")
 	case c.Header != "" && c.HeaderAttribute != "":
 		return nil, errors.New("only one header parameter can be set: 'header' or 'header_attribute'")
-	case c.Header != "" && !strings.Contains(c.Header, c.FieldDelimiter):
+	case c.Header != "" && !strings.Contains(c.Header, c.HeaderDelimiter):
 		return nil, errors.New("missing field delimiter in header")
 	case c.Header != "":
-		headers = strings.Split(c.Header, c.FieldDelimiter)
+		headers = strings.Split(c.Header, c.HeaderDelimiter)
 	}
 
 	return &Parser{
 		ParserOperator:  parserOperator,
 		header:          headers,
 		headerAttribute: c.HeaderAttribute,
+		headerDelimiter: c.HeaderDelimiter,
 		fieldDelimiter:  fieldDelimiter,
 		lazyQuotes:      c.LazyQuotes,
 		ignoreQuotes:    c.IgnoreQuotes,
@@ -105,6 +111,7 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 type Parser struct {
 	helper.ParserOperator
 	fieldDelimiter  rune
+	headerDelimiter string
 	header          []string
 	headerAttribute string
 	lazyQuotes      bool
@@ -132,7 +139,7 @@ func (r *Parser) Process(ctx context.Context, e *entry.Entry) error {
 			r.Error(err)
 			return err
 		}
-		headers := strings.Split(headerString, string([]rune{r.fieldDelimiter}))
+		headers := strings.Split(headerString, r.headerDelimiter)
 		parse = generateParseFunc(headers, r.fieldDelimiter, r.lazyQuotes, r.ignoreQuotes)
 	}
 
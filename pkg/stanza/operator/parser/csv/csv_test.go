@@ -240,6 +240,34 @@ func TestParserCSV(t *testing.T) {
 			false,
 			false,
 		},
+		{
+			"dynamic-fields-header-delimiter",
+			func(p *Config) {
+				p.HeaderAttribute = "Fields"
+				p.HeaderDelimiter = " "
+				p.FieldDelimiter = ","
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"Fields": "name age",
+					},
+					Body: "stanza dev,1",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"Fields": "name age",
+						"name":   "stanza dev",
+						"age":    "1",
+					},
+					Body: "stanza dev,1",
+				},
+			},
+			false,
+			false,
+		},
 		{
 			"dynamic-fields-multiple-entries",
 			func(p *Config) {
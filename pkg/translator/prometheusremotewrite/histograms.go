@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	// minSchema and maxSchema are the bounds of the schema of Prometheus native
+	// histograms, which is the equivalent of the scale of exponential histograms.
+	minSchema = -4
+	maxSchema = 8
+	// defaultZeroThreshold is the width of the zero bucket, as exponential
+	// histograms only count values that are exactly zero in it.
+	defaultZeroThreshold = 1e-128
+	// maxEmptyBucketsInSpan is the largest number of consecutive empty buckets
+	// encoded within a span, rather than by starting a new span.
+	maxEmptyBucketsInSpan = 2
+)
+
+// addSingleExponentialHistogramDataPoint converts pt to a Prometheus native histogram, and adds it to its
+// corresponding time series in tsMap
+func addSingleExponentialHistogramDataPoint(pt pmetric.ExponentialHistogramDataPoint, resource pcommon.Resource, metric pmetric.Metric,
+	settings Settings, tsMap map[string]*prompb.TimeSeries) error {
+	histogram, err := exponentialToNativeHistogram(pt)
+	if err != nil {
+		return fmt.Errorf("%w. %s is dropped", err, metric.Name())
+	}
+
+	name := prometheustranslator.BuildPromCompliantName(metric, settings.Namespace)
+	labels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nameStr, name)
+
+	sig := timeSeriesSignature(metric.Type().String(), &labels)
+	ts, ok := tsMap[sig]
+	if ok {
+		ts.Histograms = append(ts.Histograms, histogram)
+	} else {
+		tsMap[sig] = &prompb.TimeSeries{
+			Labels:     labels,
+			Histograms: []prompb.Histogram{histogram},
+		}
+	}
+	return nil
+}
+
+// exponentialToNativeHistogram translates an OTel exponential histogram data point to a Prometheus native histogram.
+// The scale of the data point becomes the schema of the native histogram. Data points with a scale above the
+// largest schema are downscaled by merging buckets, while data points with a scale below the smallest schema
+// cannot be converted.
+func exponentialToNativeHistogram(pt pmetric.ExponentialHistogramDataPoint) (prompb.Histogram, error) {
+	scale := pt.Scale()
+	if scale < minSchema {
+		return prompb.Histogram{}, fmt.Errorf("cannot convert exponential histogram with scale %d, the minimum is %d", scale, minSchema)
+	}
+
+	var scaleDown int32
+	if scale > maxSchema {
+		scaleDown = scale - maxSchema
+		scale = maxSchema
+	}
+
+	positiveSpans, positiveDeltas := convertBucketsLayout(pt.Positive(), scaleDown)
+	negativeSpans, negativeDeltas := convertBucketsLayout(pt.Negative(), scaleDown)
+
+	histogram := prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: pt.Count()},
+		Sum:            pt.Sum(),
+		Schema:         scale,
+		ZeroThreshold:  defaultZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: pt.ZeroCount()},
+		PositiveSpans:  positiveSpans,
+		PositiveDeltas: positiveDeltas,
+		NegativeSpans:  negativeSpans,
+		NegativeDeltas: negativeDeltas,
+		Timestamp:      convertTimeStamp(pt.Timestamp()),
+	}
+	if pt.Flags().NoRecordedValue() {
+		histogram.Sum = math.Float64frombits(value.StaleNaN)
+	}
+	return histogram, nil
+}
+
+// convertBucketsLayout translates the buckets of an exponential histogram to the spans and deltas of a native
+// histogram, merging 2^scaleDown adjacent buckets into one.
+//
+// The bucket with index i of an exponential histogram covers (base^i, base^(i+1)], while the bucket with index i
+// of a native histogram covers (base^(i-1), base^i], so the indices are shifted by one. Empty buckets are left
+// out, except for short runs of them between non-empty buckets, which are cheaper to encode than a new span.
+func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]*prompb.BucketSpan, []int64) {
+	bucketCounts := buckets.BucketCounts()
+	offset := buckets.Offset()
+
+	var (
+		spans     []*prompb.BucketSpan
+		deltas    []int64
+		prevCount int64
+		// nextIndex is the index following the last bucket added to a span.
+		nextIndex int32
+	)
+	appendBucket := func(count int64) {
+		spans[len(spans)-1].Length++
+		deltas = append(deltas, count-prevCount)
+		prevCount = count
+	}
+
+	for i := 0; i < bucketCounts.Len(); {
+		index := (offset + int32(i)) >> scaleDown
+		var count uint64
+		for ; i < bucketCounts.Len() && (offset+int32(i))>>scaleDown == index; i++ {
+			count += bucketCounts.At(i)
+		}
+		if count == 0 {
+			continue
+		}
+
+		index++
+		gap := index - nextIndex
+		switch {
+		case len(spans) == 0:
+			spans = append(spans, &prompb.BucketSpan{Offset: index})
+		case gap > maxEmptyBucketsInSpan:
+			spans = append(spans, &prompb.BucketSpan{Offset: gap})
+		default:
+			for ; gap > 0; gap-- {
+				appendBucket(0)
+			}
+		}
+		appendBucket(int64(count))
+		nextIndex = index + 1
+	}
+
+	return spans, deltas
+}
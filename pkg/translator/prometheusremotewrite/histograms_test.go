@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_convertBucketsLayout(t *testing.T) {
+	tests := []struct {
+		name           string
+		offset         int32
+		bucketCounts   []uint64
+		scaleDown      int32
+		expectedSpans  []*prompb.BucketSpan
+		expectedDeltas []int64
+	}{
+		{
+			name: "no buckets",
+		},
+		{
+			name:           "indices are shifted by one",
+			bucketCounts:   []uint64{1, 2, 3},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 3}},
+			expectedDeltas: []int64{1, 1, 1},
+		},
+		{
+			name:           "leading empty buckets are skipped",
+			bucketCounts:   []uint64{0, 0, 5},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 3, Length: 1}},
+			expectedDeltas: []int64{5},
+		},
+		{
+			name:         "small gaps are filled and large gaps start a new span",
+			offset:       -2,
+			bucketCounts: []uint64{4, 0, 0, 2, 0, 0, 0, 1, 0},
+			expectedSpans: []*prompb.BucketSpan{
+				{Offset: -1, Length: 4},
+				{Offset: 3, Length: 1},
+			},
+			expectedDeltas: []int64{4, -4, 0, 2, -1},
+		},
+		{
+			name:           "scale down by one",
+			offset:         -1,
+			bucketCounts:   []uint64{1, 2, 3, 4, 5},
+			scaleDown:      1,
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 3}},
+			expectedDeltas: []int64{1, 4, 4},
+		},
+		{
+			name:           "scale down by two",
+			offset:         3,
+			bucketCounts:   []uint64{1, 1, 1, 1, 1},
+			scaleDown:      2,
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+			expectedDeltas: []int64{1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			buckets.SetOffset(tt.offset)
+			buckets.BucketCounts().FromRaw(tt.bucketCounts)
+			spans, deltas := convertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.expectedSpans, spans)
+			assert.Equal(t, tt.expectedDeltas, deltas)
+		})
+	}
+}
+
+func Test_exponentialToNativeHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		dp       func() pmetric.ExponentialHistogramDataPoint
+		expected prompb.Histogram
+		wantErr  string
+	}{
+		{
+			name: "positive, negative and zero buckets",
+			dp: func() pmetric.ExponentialHistogramDataPoint {
+				dp := pmetric.NewExponentialHistogramDataPoint()
+				dp.SetTimestamp(pcommon.Timestamp(time1))
+				dp.SetScale(2)
+				dp.SetCount(10)
+				dp.SetSum(42.5)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{2, 3})
+				dp.Negative().SetOffset(-1)
+				dp.Negative().BucketCounts().FromRaw([]uint64{4})
+				return dp
+			},
+			expected: prompb.Histogram{
+				Count:          &prompb.Histogram_CountInt{CountInt: 10},
+				Sum:            42.5,
+				Schema:         2,
+				ZeroThreshold:  defaultZeroThreshold,
+				ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 2, Length: 2}},
+				PositiveDeltas: []int64{2, 1},
+				NegativeSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}},
+				NegativeDeltas: []int64{4},
+				Timestamp:      msTime1,
+			},
+		},
+		{
+			name: "scale above the maximum schema is downscaled",
+			dp: func() pmetric.ExponentialHistogramDataPoint {
+				dp := pmetric.NewExponentialHistogramDataPoint()
+				dp.SetTimestamp(pcommon.Timestamp(time1))
+				dp.SetScale(10)
+				dp.SetCount(4)
+				dp.SetSum(4)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1, 1})
+				return dp
+			},
+			expected: prompb.Histogram{
+				Count:          &prompb.Histogram_CountInt{CountInt: 4},
+				Sum:            4,
+				Schema:         8,
+				ZeroThreshold:  defaultZeroThreshold,
+				ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 1}},
+				PositiveDeltas: []int64{4},
+				Timestamp:      msTime1,
+			},
+		},
+		{
+			name: "scale below the minimum schema",
+			dp: func() pmetric.ExponentialHistogramDataPoint {
+				dp := pmetric.NewExponentialHistogramDataPoint()
+				dp.SetScale(-5)
+				return dp
+			},
+			wantErr: "cannot convert exponential histogram with scale -5, the minimum is -4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exponentialToNativeHistogram(tt.dp())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_exponentialToNativeHistogramStaleNaN(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetSum(1)
+	dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	got, err := exponentialToNativeHistogram(dp)
+	require.NoError(t, err)
+	assert.True(t, value.IsStaleNaN(got.Sum))
+}
+
+func Test_addSingleExponentialHistogramDataPoint(t *testing.T) {
+	metric := getExponentialHistogramMetric(validExponentialHistogram, lbs1, time1, floatVal1, 0, 0, []uint64{1, 2})
+	later := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	metric.ExponentialHistogram().DataPoints().At(0).CopyTo(later)
+	later.SetTimestamp(pcommon.Timestamp(time2))
+
+	tsMap := map[string]*prompb.TimeSeries{}
+	for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+		err := addSingleExponentialHistogramDataPoint(metric.ExponentialHistogram().DataPoints().At(i), pcommon.NewResource(), metric, Settings{}, tsMap)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, tsMap, 1)
+	for _, ts := range tsMap {
+		assert.Equal(t, getPromLabels(nameStr, validExponentialHistogram, label11, value11, label12, value12), ts.Labels)
+		assert.Empty(t, ts.Samples)
+		require.Len(t, ts.Histograms, 2)
+		assert.Equal(t, msTime1, ts.Histograms[0].Timestamp)
+		assert.Equal(t, msTime2, ts.Histograms[1].Timestamp)
+		assert.Equal(t, &prompb.Histogram_CountInt{CountInt: 3}, ts.Histograms[0].Count)
+	}
+}
+
+func TestFromMetricsExponentialHistogram(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	getExponentialHistogramMetric(validExponentialHistogram, lbs1, time1, floatVal1, 0, 0, []uint64{1, 2}).CopyTo(metrics.AppendEmpty())
+	getExponentialHistogramMetric("unsupported_scale", lbs1, time1, floatVal1, -10, 0, []uint64{1, 2}).CopyTo(metrics.AppendEmpty())
+
+	tsMap, err := FromMetrics(md, Settings{})
+	assert.EqualError(t, err, "cannot convert exponential histogram with scale -10, the minimum is -4. unsupported_scale is dropped")
+	require.Len(t, tsMap, 1)
+	for _, ts := range tsMap {
+		require.Len(t, ts.Histograms, 1)
+		assert.False(t, math.IsNaN(ts.Histograms[0].Sum))
+	}
+}
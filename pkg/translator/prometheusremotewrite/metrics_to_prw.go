@@ -88,6 +88,16 @@ func FromMetrics(md pmetric.Metrics, settings Settings) (tsMap map[string]*promp
 					for x := 0; x < dataPoints.Len(); x++ {
 						addSingleHistogramDataPoint(dataPoints.At(x), resource, metric, settings, tsMap)
 					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						if err := addSingleExponentialHistogramDataPoint(dataPoints.At(x), resource, metric, settings, tsMap); err != nil {
+							errs = multierr.Append(errs, err)
+						}
+					}
 				case pmetric.MetricTypeSummary:
 					dataPoints := metric.Summary().DataPoints()
 					if dataPoints.Len() == 0 {
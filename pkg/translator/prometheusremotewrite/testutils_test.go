@@ -121,6 +121,8 @@ var (
 	validSummary     = "valid_Summary"
 	suffixedCounter  = "valid_IntSum_total"
 
+	validExponentialHistogram = "valid_ExponentialHistogram"
+
 	// valid metrics as input should not return error
 	validMetrics1 = map[string]pmetric.Metric{
 		validIntGauge:    getIntGaugeMetric(validIntGauge, lbs1, intVal1, time1),
@@ -130,6 +132,8 @@ var (
 		validSum:         getSumMetric(validSum, lbs1, floatVal1, time1),
 		validHistogram:   getHistogramMetric(validHistogram, lbs1, time1, floatVal1, uint64(intVal1), bounds, buckets),
 		validSummary:     getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
+
+		validExponentialHistogram: getExponentialHistogramMetric(validExponentialHistogram, lbs1, time1, floatVal1, 0, 0, buckets),
 	}
 
 	empty = "empty"
@@ -144,6 +148,9 @@ var (
 	emptyCumulativeSum       = "emptyCumulativeSum"
 	emptyCumulativeHistogram = "emptyCumulativeHistogram"
 
+	emptyExponentialHistogram           = "emptyExponentialHistogram"
+	emptyCumulativeExponentialHistogram = "emptyCumulativeExponentialHistogram"
+
 	// different metrics that will not pass validate metrics and will cause the exporter to return an error
 	invalidMetrics = map[string]pmetric.Metric{
 		empty:                    pmetric.NewMetric(),
@@ -153,6 +160,9 @@ var (
 		emptySummary:             getEmptySummaryMetric(emptySummary),
 		emptyCumulativeSum:       getEmptyCumulativeSumMetric(emptyCumulativeSum),
 		emptyCumulativeHistogram: getEmptyCumulativeHistogramMetric(emptyCumulativeHistogram),
+
+		emptyExponentialHistogram:           getEmptyExponentialHistogramMetric(emptyExponentialHistogram),
+		emptyCumulativeExponentialHistogram: getEmptyCumulativeExponentialHistogramMetric(emptyCumulativeExponentialHistogram),
 	}
 )
 
@@ -369,6 +379,44 @@ func getHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum floa
 	return metric
 }
 
+func getEmptyExponentialHistogramMetric(name string) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram()
+	return metric
+}
+
+func getEmptyCumulativeExponentialHistogramMetric(name string) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	return metric
+}
+
+func getExponentialHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum float64, scale int32, offset int32,
+	buckets []uint64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	if strings.HasPrefix(name, "staleNaN") {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	}
+	var count uint64
+	for _, bucket := range buckets {
+		count += bucket
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetScale(scale)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().BucketCounts().FromRaw(buckets)
+	attributes.CopyTo(dp.Attributes())
+
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	return metric
+}
+
 func getEmptySummaryMetric(name string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(name)
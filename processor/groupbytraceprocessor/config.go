@@ -17,6 +17,7 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
 
@@ -42,6 +43,12 @@ type Config struct {
 	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
+	// StorageID is the ID of the storage extension used to checkpoint the in-flight traces.
+	// When set, the traces that weren't released before the collector stopped are reloaded
+	// on startup, and released once the wait duration expires.
+	// Default: nil, traces are only kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
+
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
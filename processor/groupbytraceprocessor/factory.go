@@ -85,8 +85,11 @@ func createTracesProcessor(
 		return nil, errDiscardOrphansNotSupported
 	}
 
-	// the only supported storage for now
-	st = newMemoryStorage()
+	if oCfg.StorageID != nil {
+		st = newPersistentStorage(params.Logger, *oCfg.StorageID, params.ID)
+	} else {
+		st = newMemoryStorage()
+	}
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
@@ -93,14 +93,40 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+	return sp.restoreTraces(ctx)
+}
+
+// restoreTraces feeds the traces left in-flight by a previous run back to the event machine,
+// so that they are released once the wait duration expires.
+func (sp *groupByTraceProcessor) restoreTraces(ctx context.Context) error {
+	rst, ok := sp.st.(restorableStorage)
+	if !ok {
+		return nil
+	}
+
+	traces, err := rst.restore(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't restore the traces from the storage: %w", err)
+	}
+
+	var errs error
+	for _, trace := range traces {
+		errs = multierr.Append(errs, sp.eventMachine.consume(trace))
+	}
+	if len(traces) > 0 {
+		sp.logger.Info("restored traces from the storage", zap.Int("traces", len(traces)))
+	}
+	return errs
 }
 
 // Shutdown is invoked during service shutdown.
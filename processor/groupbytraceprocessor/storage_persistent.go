@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	// slotCountKey is the key of the number of slots allocated so far
+	slotCountKey = "slot_count"
+	// slotKeyPrefix is the prefix of the keys holding the trace ID stored in each slot
+	slotKeyPrefix = "slot_"
+	// traceKeyPrefix is the prefix of the keys holding the batches of spans of each trace
+	traceKeyPrefix = "trace_"
+)
+
+var (
+	tracesMarshaler   = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler = &ptrace.ProtoUnmarshaler{}
+)
+
+// restorableStorage is implemented by storages able to return the traces left
+// in-flight by a previous run of the processor.
+type restorableStorage interface {
+	restore(context.Context) ([]ptrace.Traces, error)
+}
+
+// persistedTrace is the state of a trace in the storage extension.
+type persistedTrace struct {
+	// slot is the index of the key listing the trace ID in the storage extension
+	slot int
+	// batches is the number of batches of spans written for the trace
+	batches int
+	// persisted is set once the slot listing the trace ID has been written
+	persisted bool
+	// restored is set for the traces read by restore, until they are received again
+	restored bool
+}
+
+// persistentStorage keeps the traces in memory, and checkpoints them to a storage
+// extension, so that in-flight traces survive a restart of the collector.
+//
+// Each batch of spans is written under its own key, and each trace ID is listed
+// under a slot key, reused once the trace is deleted, so that the cost of a write
+// doesn't depend on the number of traces in-flight. The operations on a given
+// trace are serialized by the event machine worker owning it, which is why the
+// storage extension is accessed outside of the lock.
+type persistentStorage struct {
+	*memoryStorage
+
+	storageID   component.ID
+	componentID component.ID
+	logger      *zap.Logger
+
+	// lock protects the client and the state of the persisted traces and slots
+	lock      sync.Mutex
+	client    storageext.Client
+	traces    map[pcommon.TraceID]*persistedTrace
+	freeSlots []int
+	slotCount int
+
+	// slotCountLock serializes the writes of the slot count, so that it never decreases
+	slotCountLock      sync.Mutex
+	persistedSlotCount int
+}
+
+var _ storage = (*persistentStorage)(nil)
+var _ restorableStorage = (*persistentStorage)(nil)
+
+func newPersistentStorage(logger *zap.Logger, storageID component.ID, componentID component.ID) *persistentStorage {
+	return &persistentStorage{
+		memoryStorage: newMemoryStorage(),
+		storageID:     storageID,
+		componentID:   componentID,
+		logger:        logger,
+		client:        storageext.NewNopClient(),
+		traces:        make(map[pcommon.TraceID]*persistedTrace),
+	}
+}
+
+func (st *persistentStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	if err := st.memoryStorage.createOrAppend(traceID, td); err != nil {
+		return err
+	}
+
+	st.lock.Lock()
+	pt, ok := st.traces[traceID]
+	if ok && pt.restored {
+		// the spans have been read from the storage extension, they are already persisted
+		pt.restored = false
+		st.lock.Unlock()
+		return nil
+	}
+	if !ok {
+		pt = &persistedTrace{slot: st.allocateSlot()}
+		st.traces[traceID] = pt
+	}
+	var ops []storageext.Operation
+	slotCount := 0
+	if !pt.persisted {
+		// the slot is written again if a previous write failed
+		slotCount = pt.slot + 1
+		ops = append(ops, storageext.SetOperation(slotKey(pt.slot), traceID[:]))
+	}
+	// the batch count is only advanced once the batch is written, as restore stops
+	// reading the batches of a trace at the first missing one
+	batch := pt.batches
+	client := st.client
+	st.lock.Unlock()
+
+	bts, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal trace %q: %w", traceID, err)
+	}
+	ops = append(ops, storageext.SetOperation(batchKey(traceID, batch), bts))
+
+	// the slot count is written before the slot, so that the slot is always read by restore
+	if err := st.persistSlotCount(client, slotCount); err != nil {
+		return err
+	}
+	if err := client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+
+	st.lock.Lock()
+	pt.persisted = true
+	pt.batches = batch + 1
+	st.lock.Unlock()
+	return nil
+}
+
+func (st *persistentStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	rss, err := st.memoryStorage.delete(traceID)
+	if err != nil {
+		return nil, err
+	}
+
+	st.lock.Lock()
+	pt, ok := st.traces[traceID]
+	delete(st.traces, traceID)
+	client := st.client
+	st.lock.Unlock()
+	if !ok {
+		return rss, nil
+	}
+
+	err = deleteTrace(context.Background(), client, traceID, pt.slot, pt.batches)
+
+	// the slot is only reused once its key has been deleted, so that the deletion
+	// doesn't overwrite the trace ID of another trace
+	st.lock.Lock()
+	st.freeSlots = append(st.freeSlots, pt.slot)
+	st.lock.Unlock()
+	return rss, err
+}
+
+func (st *persistentStorage) start(ctx context.Context, host component.Host) error {
+	extension, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExtension, ok := extension.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+
+	st.lock.Lock()
+	st.client = client
+	st.lock.Unlock()
+
+	return st.memoryStorage.start(ctx, host)
+}
+
+// restore reads the traces persisted by a previous run of the processor. The traces are kept
+// in the storage extension until they are deleted, so that they survive another restart
+// happening before they are released.
+func (st *persistentStorage) restore(ctx context.Context) ([]ptrace.Traces, error) {
+	st.lock.Lock()
+	client := st.client
+	st.lock.Unlock()
+
+	bts, err := client.Get(ctx, slotCountKey)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the slot count from the storage: %w", err)
+	}
+	slotCount := 0
+	if bts != nil {
+		if len(bts) != 8 {
+			return nil, fmt.Errorf("the slot count in the storage is corrupted: unexpected length %d", len(bts))
+		}
+		slotCount = int(binary.BigEndian.Uint64(bts))
+	}
+
+	var traces []ptrace.Traces
+	restored := make(map[pcommon.TraceID]*persistedTrace)
+	var freeSlots []int
+	for slot := 0; slot < slotCount; slot++ {
+		traceID, err := st.readSlot(ctx, client, slot)
+		if err != nil {
+			return nil, err
+		}
+		if traceID.IsEmpty() {
+			freeSlots = append(freeSlots, slot)
+			continue
+		}
+
+		trace, batches, err := st.readTrace(ctx, client, traceID)
+		if err != nil {
+			return nil, err
+		}
+		if trace.ResourceSpans().Len() == 0 {
+			// the trace would never be released, so it's deleted right away
+			st.logger.Debug("trace without spans in the storage, deleting", zap.Stringer("traceID", traceID))
+			if err := deleteTrace(ctx, client, traceID, slot, batches); err != nil {
+				// the slot is kept in use, the deletion is retried on the next restart
+				st.logger.Warn("couldn't delete trace without spans from the storage", zap.Stringer("traceID", traceID), zap.Error(err))
+				continue
+			}
+			freeSlots = append(freeSlots, slot)
+			continue
+		}
+		traces = append(traces, trace)
+		// the trace is still in the storage extension, it's deleted along with the
+		// slot once it's released
+		restored[traceID] = &persistedTrace{slot: slot, batches: batches, persisted: true, restored: true}
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	for traceID, pt := range restored {
+		st.traces[traceID] = pt
+	}
+	st.freeSlots = append(st.freeSlots, freeSlots...)
+	if slotCount > st.slotCount {
+		st.slotCount = slotCount
+	}
+	st.slotCountLock.Lock()
+	if slotCount > st.persistedSlotCount {
+		st.persistedSlotCount = slotCount
+	}
+	st.slotCountLock.Unlock()
+	return traces, nil
+}
+
+// deleteTrace deletes the slot listing the trace ID and the batches of spans of the trace.
+func deleteTrace(ctx context.Context, client storageext.Client, traceID pcommon.TraceID, slot int, batches int) error {
+	ops := make([]storageext.Operation, 0, batches+1)
+	ops = append(ops, storageext.DeleteOperation(slotKey(slot)))
+	for batch := 0; batch < batches; batch++ {
+		ops = append(ops, storageext.DeleteOperation(batchKey(traceID, batch)))
+	}
+	return client.Batch(ctx, ops...)
+}
+
+// readSlot returns the trace ID listed in the slot, or an empty trace ID if the slot is free.
+func (st *persistentStorage) readSlot(ctx context.Context, client storageext.Client, slot int) (pcommon.TraceID, error) {
+	var traceID pcommon.TraceID
+	bts, err := client.Get(ctx, slotKey(slot))
+	if err != nil {
+		return traceID, fmt.Errorf("couldn't read slot %d from the storage: %w", slot, err)
+	}
+	if bts == nil {
+		return traceID, nil
+	}
+	if len(bts) != len(traceID) {
+		st.logger.Warn("corrupted trace ID in the storage, skipping", zap.Int("slot", slot), zap.Int("length", len(bts)))
+		return traceID, nil
+	}
+	copy(traceID[:], bts)
+	return traceID, nil
+}
+
+// readTrace returns the spans of all the batches of the trace, and the number of batches.
+func (st *persistentStorage) readTrace(ctx context.Context, client storageext.Client, traceID pcommon.TraceID) (ptrace.Traces, int, error) {
+	trace := ptrace.NewTraces()
+	batches := 0
+	for ; ; batches++ {
+		content, err := client.Get(ctx, batchKey(traceID, batches))
+		if err != nil {
+			return trace, batches, fmt.Errorf("couldn't read trace %q from the storage: %w", traceID, err)
+		}
+		if content == nil {
+			return trace, batches, nil
+		}
+		batch, err := tracesUnmarshaler.UnmarshalTraces(content)
+		if err != nil {
+			st.logger.Warn("couldn't unmarshal spans from the storage, skipping", zap.Stringer("traceID", traceID), zap.Error(err))
+			continue
+		}
+		batch.ResourceSpans().MoveAndAppendTo(trace.ResourceSpans())
+	}
+}
+
+func (st *persistentStorage) shutdown() error {
+	st.lock.Lock()
+	client := st.client
+	st.lock.Unlock()
+	return multierr.Append(st.memoryStorage.shutdown(), client.Close(context.Background()))
+}
+
+// allocateSlot returns a free slot, reusing the slots of deleted traces first.
+// It must be called with the lock held.
+func (st *persistentStorage) allocateSlot() int {
+	if n := len(st.freeSlots); n > 0 {
+		slot := st.freeSlots[n-1]
+		st.freeSlots = st.freeSlots[:n-1]
+		return slot
+	}
+	st.slotCount++
+	return st.slotCount - 1
+}
+
+// persistSlotCount writes the slot count if it's greater than the one already written.
+// Slots are only allocated when all the existing ones are in use, so this rarely writes.
+func (st *persistentStorage) persistSlotCount(client storageext.Client, slotCount int) error {
+	st.slotCountLock.Lock()
+	defer st.slotCountLock.Unlock()
+	if slotCount <= st.persistedSlotCount {
+		return nil
+	}
+	bts := make([]byte, 8)
+	binary.BigEndian.PutUint64(bts, uint64(slotCount))
+	if err := client.Set(context.Background(), slotCountKey, bts); err != nil {
+		return fmt.Errorf("couldn't write the slot count to the storage: %w", err)
+	}
+	st.persistedSlotCount = slotCount
+	return nil
+}
+
+func slotKey(slot int) string {
+	return slotKeyPrefix + strconv.Itoa(slot)
+}
+
+func batchKey(traceID pcommon.TraceID, batch int) string {
+	return traceKeyPrefix + traceID.String() + "_" + strconv.Itoa(batch)
+}
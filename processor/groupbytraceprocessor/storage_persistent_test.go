@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestPersistentCreateAndRestoreTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	ctx := context.Background()
+
+	st := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, st.start(ctx, host))
+
+	firstTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	secondTraceID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	thirdTraceID := pcommon.TraceID([16]byte{3, 4, 5, 6})
+
+	// test
+	require.NoError(t, st.createOrAppend(firstTraceID, simpleTracesWithID(firstTraceID)))
+	require.NoError(t, st.createOrAppend(firstTraceID, simpleTracesWithID(firstTraceID)))
+	require.NoError(t, st.createOrAppend(secondTraceID, simpleTracesWithID(secondTraceID)))
+	require.NoError(t, st.createOrAppend(thirdTraceID, simpleTracesWithID(thirdTraceID)))
+	deleted, err := st.delete(secondTraceID)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	require.NoError(t, st.shutdown())
+
+	// verify
+	restarted := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, restarted.start(ctx, host))
+	defer func() {
+		assert.NoError(t, restarted.shutdown())
+	}()
+
+	traces, err := restarted.restore(ctx)
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+
+	spanCounts := map[pcommon.TraceID]int{}
+	for _, trace := range traces {
+		traceID, err := getTraceID(trace)
+		require.NoError(t, err)
+		spanCounts[traceID] = trace.SpanCount()
+	}
+	assert.Equal(t, map[pcommon.TraceID]int{firstTraceID: 2, thirdTraceID: 1}, spanCounts)
+
+	// the restored traces aren't in memory until they are received again
+	retrieved, err := restarted.get(firstTraceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestPersistentReusesSlots(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	ctx := context.Background()
+
+	st := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, st.start(ctx, host))
+
+	firstTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	secondTraceID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+
+	// test
+	require.NoError(t, st.createOrAppend(firstTraceID, simpleTracesWithID(firstTraceID)))
+	_, err := st.delete(firstTraceID)
+	require.NoError(t, err)
+	require.NoError(t, st.createOrAppend(secondTraceID, simpleTracesWithID(secondTraceID)))
+	require.NoError(t, st.shutdown())
+
+	// verify
+	assert.Equal(t, 1, st.slotCount)
+	assert.Equal(t, 0, st.traces[secondTraceID].slot)
+
+	restarted := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, restarted.start(ctx, host))
+	defer func() {
+		assert.NoError(t, restarted.shutdown())
+	}()
+
+	traces, err := restarted.restore(ctx)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	traceID, err := getTraceID(traces[0])
+	require.NoError(t, err)
+	assert.Equal(t, secondTraceID, traceID)
+
+	// receiving the restored trace again doesn't persist its spans twice
+	require.NoError(t, restarted.createOrAppend(secondTraceID, traces[0]))
+	assert.Equal(t, 1, restarted.traces[secondTraceID].batches)
+}
+
+func TestPersistentFailedWritesAreRetried(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	ctx := context.Background()
+
+	st := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, st.start(ctx, host))
+	client := &failingClient{Client: st.client}
+	st.client = client
+
+	firstTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	secondTraceID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+
+	// test
+	client.fail = true
+	assert.Error(t, st.createOrAppend(firstTraceID, simpleTracesWithID(firstTraceID)))
+	client.fail = false
+	require.NoError(t, st.createOrAppend(firstTraceID, simpleTracesWithID(firstTraceID)))
+	require.NoError(t, st.createOrAppend(secondTraceID, simpleTracesWithID(secondTraceID)))
+	client.fail = true
+	assert.Error(t, st.createOrAppend(secondTraceID, simpleTracesWithID(secondTraceID)))
+	client.fail = false
+	require.NoError(t, st.createOrAppend(secondTraceID, simpleTracesWithID(secondTraceID)))
+	require.NoError(t, st.shutdown())
+
+	// verify
+	assert.Equal(t, 1, st.traces[firstTraceID].batches)
+	assert.Equal(t, 2, st.traces[secondTraceID].batches)
+
+	restarted := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, restarted.start(ctx, host))
+	defer func() {
+		assert.NoError(t, restarted.shutdown())
+	}()
+
+	traces, err := restarted.restore(ctx)
+	require.NoError(t, err)
+	spanCounts := map[pcommon.TraceID]int{}
+	for _, trace := range traces {
+		traceID, err := getTraceID(trace)
+		require.NoError(t, err)
+		spanCounts[traceID] = trace.SpanCount()
+	}
+	assert.Equal(t, map[pcommon.TraceID]int{firstTraceID: 1, secondTraceID: 2}, spanCounts)
+}
+
+func TestPersistentRestoreDeletesTracesWithoutSpans(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	ctx := context.Background()
+
+	st := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, st.start(ctx, host))
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, ptrace.NewTraces()))
+	require.NoError(t, st.shutdown())
+
+	// test
+	restarted := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, restarted.start(ctx, host))
+	defer func() {
+		assert.NoError(t, restarted.shutdown())
+	}()
+	traces, err := restarted.restore(ctx)
+	require.NoError(t, err)
+
+	// verify
+	assert.Empty(t, traces)
+	assert.Empty(t, restarted.traces)
+	assert.Equal(t, []int{0}, restarted.freeSlots)
+	slot, err := restarted.client.Get(ctx, slotKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, slot)
+	batch, err := restarted.client.Get(ctx, batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, batch)
+}
+
+func TestPersistentStorageExtensionErrors(t *testing.T) {
+	host := storagetest.NewStorageHost().
+		WithNonStorageExtension("non-storage")
+
+	st := newPersistentStorage(zap.NewNop(), storagetest.NewStorageID("missing"), component.NewID(typeStr))
+	assert.EqualError(t, st.start(context.Background(), host), "storage extension 'test_storage/missing' not found")
+
+	st = newPersistentStorage(zap.NewNop(), storagetest.NewNonStorageID("non-storage"), component.NewID(typeStr))
+	assert.EqualError(t, st.start(context.Background(), host), "non-storage extension 'non_storage/non-storage' found")
+}
+
+func TestProcessorReleasesRestoredTraces(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	ctx := context.Background()
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// the first processor waits for longer than the test, so the trace is still in-flight when it shuts down
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StorageID:    &storageID,
+	}
+	first := newGroupByTraceProcessor(zap.NewNop(), newPersistentStorage(zap.NewNop(), storageID, component.NewID(typeStr)), &mockProcessor{}, config)
+	require.NoError(t, first.Start(ctx, host))
+	require.NoError(t, first.ConsumeTraces(ctx, simpleTracesWithID(traceID)))
+	require.NoError(t, first.Shutdown(ctx))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	var received ptrace.Traces
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			received = td
+			wg.Done()
+			return nil
+		},
+	}
+
+	// test
+	config.WaitDuration = time.Millisecond
+	second := newGroupByTraceProcessor(zap.NewNop(), newPersistentStorage(zap.NewNop(), storageID, component.NewID(typeStr)), next, config)
+	require.NoError(t, second.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, second.Shutdown(ctx))
+	}()
+
+	// verify
+	wg.Wait()
+	receivedTraceID, err := getTraceID(received)
+	require.NoError(t, err)
+	assert.Equal(t, traceID, receivedTraceID)
+}
+
+// failingClient fails all the writes while fail is set.
+type failingClient struct {
+	storageext.Client
+	fail bool
+}
+
+func (c *failingClient) Set(ctx context.Context, key string, value []byte) error {
+	if c.fail {
+		return errors.New("failed to write")
+	}
+	return c.Client.Set(ctx, key, value)
+}
+
+func (c *failingClient) Batch(ctx context.Context, ops ...storageext.Operation) error {
+	if c.fail {
+		return errors.New("failed to write")
+	}
+	return c.Client.Batch(ctx, ops...)
+}
@@ -35,6 +35,20 @@ var validAttributeSource = map[AttributeSource]bool{
 	recordAttributeSource:  true,
 }
 
+type SamplerMode string
+
+const (
+	hashSeedSamplerMode   = SamplerMode("hash_seed")
+	consistentSamplerMode = SamplerMode("consistent")
+
+	defaultSamplerMode = hashSeedSamplerMode
+)
+
+var validSamplerMode = map[SamplerMode]bool{
+	hashSeedSamplerMode:   true,
+	consistentSamplerMode: true,
+}
+
 // Config has the configuration guiding the sampler processor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -48,6 +62,11 @@ type Config struct {
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
 
+	// SamplerMode (traces only) defines how traces are sampled. The allowed values are `hash_seed`, sampling traces
+	// by the hash of their trace ID, or `consistent`, implementing the OpenTelemetry consistent probability sampling
+	// with the p-value and r-value of the `ot` tracestate entry. Default is `hash_seed`.
+	SamplerMode SamplerMode `mapstructure:"mode"`
+
 	// AttributeSource (logs only) defines where to look for the attribute in from_attribute. The allowed values are
 	// `traceID` or `record`. Default is `traceID`.
 	AttributeSource `mapstructure:"attribute_source"`
@@ -71,5 +90,8 @@ func (cfg *Config) Validate() error {
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
+	if cfg.SamplerMode != "" && !validSamplerMode[cfg.SamplerMode] {
+		return fmt.Errorf("invalid sampler mode: %v. Expected: %v or %v", cfg.SamplerMode, hashSeedSamplerMode, consistentSamplerMode)
+	}
 	return nil
 }
@@ -40,6 +40,16 @@ func TestLoadConfig(t *testing.T) {
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
 				AttributeSource:    "traceID",
+				SamplerMode:        "hash_seed",
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "consistent"),
+			expected: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(component.NewID(typeStr)),
+				SamplingPercentage: 10,
+				AttributeSource:    "traceID",
+				SamplerMode:        "consistent",
 			},
 		},
 		{
@@ -51,6 +61,7 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:    "record",
 				FromAttribute:      "foo",
 				SamplingPriority:   "bar",
+				SamplerMode:        "hash_seed",
 			},
 		},
 	}
@@ -85,3 +96,9 @@ func TestLoadInvalidConfig(t *testing.T) {
 	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "invalid.yaml"), factories)
 	require.ErrorContains(t, err, "negative sampling rate: -15.30")
 }
+
+func TestValidateSamplerMode(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplerMode = "proportional"
+	assert.EqualError(t, cfg.Validate(), "invalid sampler mode: proportional. Expected: hash_seed or consistent")
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"math"
+	"math/bits"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// adjustedCountAttribute is the span attribute holding the number of spans in the population
+// represented by the sampled span, i.e. the inverse of its sampling probability.
+const adjustedCountAttribute = "sampling.adjusted_count"
+
+// consistentSampler implements the OpenTelemetry consistent probability sampling: a span is
+// sampled if its r-value, shared by all the spans of the trace, is greater or equal than its
+// p-value, the negative base-2 logarithm of the sampling probability. See
+// https://opentelemetry.io/docs/reference/specification/trace/tracestate-probability-sampling/
+type consistentSampler struct {
+	// pFloor is the p-value of the lowest power-of-two probability greater than the configured
+	// one, chosen for the traces whose hash is lower or equal than floorThreshold. The other
+	// traces get the p-value pFloor+1, so that the configured probability is reached on average.
+	pFloor         uint8
+	floorThreshold uint32
+	hashSeed       uint32
+	logger         *zap.Logger
+}
+
+func newConsistentSampler(samplingPercentage float32, hashSeed uint32, logger *zap.Logger) *consistentSampler {
+	cs := &consistentSampler{hashSeed: hashSeed, logger: logger}
+	probability := float64(samplingPercentage) / 100
+	switch {
+	case probability >= 1:
+		cs.pFloor = 0
+		cs.floorThreshold = math.MaxUint32
+	case probability <= 0 || -math.Log2(probability) >= maxPValue:
+		cs.pFloor = maxPValue
+		cs.floorThreshold = math.MaxUint32
+	default:
+		_, exp := math.Frexp(probability)
+		// probability is in [2^(exp-1), 2^exp).
+		pFloor := -exp
+		// The probability of choosing pFloor over pFloor+1.
+		floorProbability := math.Ldexp(probability, pFloor+1) - 1
+		cs.pFloor = uint8(pFloor)
+		cs.floorThreshold = uint32(floorProbability * math.MaxUint32)
+	}
+	return cs
+}
+
+// sample returns whether the span is sampled and, if it is, updates its tracestate with its
+// p-value and r-value and sets its adjusted count.
+func (cs *consistentSampler) sample(s ptrace.Span) bool {
+	otValue, others := splitTraceState(s.TraceState().AsRaw())
+	ot, err := parseOTTraceState(otValue)
+	if err != nil {
+		cs.logger.Debug("Ignoring invalid sampling values of the tracestate", zap.Error(err))
+	}
+	if ot.hasP && (!ot.hasR || ot.p > ot.r) {
+		// The upstream adjusted count can't be trusted without a consistent r-value.
+		ot.hasP = false
+	}
+	tid := s.TraceID()
+	if !ot.hasR {
+		ot.r, ot.hasR = rValue(tid[:], cs.hashSeed), true
+	}
+
+	p := cs.pFloor
+	if p < maxPValue && hash(tid[:], ^cs.hashSeed) > cs.floorThreshold {
+		p++
+	}
+	// The probability can only decrease along the sampling stages.
+	if ot.hasP && ot.p > p {
+		p = ot.p
+	}
+	if ot.r < p {
+		return false
+	}
+
+	ot.p, ot.hasP = p, true
+	s.TraceState().FromRaw(joinTraceState(ot.serialize(), others))
+	s.Attributes().PutDouble(adjustedCountAttribute, math.Ldexp(1, int(p)))
+	return true
+}
+
+// rValue derives the r-value of the trace from the hash of its trace ID, for traces that did
+// not get one upstream.
+func rValue(tid []byte, hashSeed uint32) uint8 {
+	random := uint64(hash(tid, hashSeed))<<32 | uint64(hash(tid, hashSeed+1))
+	r := bits.LeadingZeros64(random)
+	if r > maxRValue {
+		r = maxRValue
+	}
+	return uint8(r)
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestNewConsistentSampler(t *testing.T) {
+	tests := []struct {
+		percentage       float32
+		pFloor           uint8
+		floorProbability float64
+	}{
+		{percentage: 100, pFloor: 0, floorProbability: 1},
+		{percentage: 150, pFloor: 0, floorProbability: 1},
+		{percentage: 0, pFloor: maxPValue, floorProbability: 1},
+		{percentage: 50, pFloor: 0, floorProbability: 0},
+		{percentage: 25, pFloor: 1, floorProbability: 0},
+		{percentage: 75, pFloor: 0, floorProbability: 0.5},
+		{percentage: 30, pFloor: 1, floorProbability: 0.2},
+	}
+	for _, tt := range tests {
+		cs := newConsistentSampler(tt.percentage, 0, zap.NewNop())
+		assert.Equal(t, tt.pFloor, cs.pFloor, "percentage %v", tt.percentage)
+		assert.InDelta(t, tt.floorProbability, float64(cs.floorThreshold)/math.MaxUint32, 1e-6, "percentage %v", tt.percentage)
+	}
+}
+
+func TestConsistentSamplerSample(t *testing.T) {
+	tests := []struct {
+		name               string
+		percentage         float32
+		traceState         string
+		sampled            bool
+		expectedTraceState string
+		adjustedCount      float64
+	}{
+		{
+			name:               "r-value over p-value",
+			percentage:         25,
+			traceState:         "ot=r:3,vendor=a",
+			sampled:            true,
+			expectedTraceState: "ot=p:2;r:3,vendor=a",
+			adjustedCount:      4,
+		},
+		{
+			name:               "r-value equal to p-value",
+			percentage:         25,
+			traceState:         "ot=r:2",
+			sampled:            true,
+			expectedTraceState: "ot=p:2;r:2",
+			adjustedCount:      4,
+		},
+		{
+			name:       "r-value under p-value",
+			percentage: 25,
+			traceState: "ot=r:1",
+			sampled:    false,
+		},
+		{
+			name:               "upstream p-value kept when lower probability",
+			percentage:         50,
+			traceState:         "ot=p:3;r:4;x:y",
+			sampled:            true,
+			expectedTraceState: "ot=p:3;r:4;x:y",
+			adjustedCount:      8,
+		},
+		{
+			name:               "upstream p-value replaced when higher probability",
+			percentage:         25,
+			traceState:         "ot=p:1;r:4",
+			sampled:            true,
+			expectedTraceState: "ot=p:2;r:4",
+			adjustedCount:      4,
+		},
+		{
+			name:               "inconsistent upstream p-value ignored",
+			percentage:         100,
+			traceState:         "ot=p:5;r:4",
+			sampled:            true,
+			expectedTraceState: "ot=p:0;r:4",
+			adjustedCount:      1,
+		},
+		{
+			name:       "zero probability",
+			percentage: 0,
+			traceState: "ot=r:62",
+			sampled:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := newConsistentSampler(tt.percentage, 0, zap.NewNop())
+			span := ptrace.NewSpan()
+			span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+			span.TraceState().FromRaw(tt.traceState)
+
+			require.Equal(t, tt.sampled, cs.sample(span))
+			if !tt.sampled {
+				return
+			}
+			assert.Equal(t, tt.expectedTraceState, span.TraceState().AsRaw())
+			adjustedCount, ok := span.Attributes().Get(adjustedCountAttribute)
+			require.True(t, ok)
+			assert.Equal(t, tt.adjustedCount, adjustedCount.Double())
+		})
+	}
+}
+
+func TestConsistentSamplerWithoutRValue(t *testing.T) {
+	cs := newConsistentSampler(100, 0, zap.NewNop())
+	span := ptrace.NewSpan()
+	tid := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	span.SetTraceID(tid)
+
+	require.True(t, cs.sample(span))
+	otValue, _ := splitTraceState(span.TraceState().AsRaw())
+	ot, err := parseOTTraceState(otValue)
+	require.NoError(t, err)
+	assert.True(t, ot.hasR)
+	assert.Equal(t, rValue(tid[:], 0), ot.r)
+}
+
+// TestConsistentSamplerRates checks that the ratio of sampled traces and the sum of their
+// adjusted counts match the configured probability, also across two sampling stages.
+func TestConsistentSamplerRates(t *testing.T) {
+	const numTraces = 100_000
+	first := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(component.NewID(typeStr)),
+		SamplingPercentage: 30,
+		SamplerMode:        consistentSamplerMode,
+	}
+	second := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(component.NewID(typeStr)),
+		SamplingPercentage: 10,
+		HashSeed:           1234,
+		SamplerMode:        consistentSamplerMode,
+	}
+	sink := new(consumertest.TracesSink)
+	secondStage, err := newTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), second, sink)
+	require.NoError(t, err)
+	firstStage, err := newTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), first, secondStage)
+	require.NoError(t, err)
+
+	for _, td := range genRandomTestData(numTraces, 1, "svc", 1) {
+		require.NoError(t, firstStage.ConsumeTraces(context.Background(), td))
+	}
+
+	var adjustedCounts float64
+	for _, td := range sink.AllTraces() {
+		span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		adjustedCount, ok := span.Attributes().Get(adjustedCountAttribute)
+		require.True(t, ok)
+		assert.GreaterOrEqual(t, adjustedCount.Double(), 8.0)
+		adjustedCounts += adjustedCount.Double()
+	}
+	assert.InDelta(t, 0.1, float64(sink.SpanCount())/numTraces, 0.01)
+	assert.InDelta(t, numTraces, adjustedCounts, 0.1*numTraces)
+}
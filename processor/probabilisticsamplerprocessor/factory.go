@@ -52,6 +52,7 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		ProcessorSettings: config.NewProcessorSettings(component.NewID(typeStr)),
 		AttributeSource:   defaultAttributeSource,
+		SamplerMode:       defaultSamplerMode,
 	}
 }
 
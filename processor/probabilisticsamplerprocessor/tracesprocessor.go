@@ -55,7 +55,9 @@ const (
 type traceSamplerProcessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
-	logger             *zap.Logger
+	// consistentSampler is only set in the consistent sampler mode.
+	consistentSampler *consistentSampler
+	logger            *zap.Logger
 }
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform head sampling according to the given
@@ -67,6 +69,9 @@ func newTracesProcessor(ctx context.Context, set component.ProcessorCreateSettin
 		hashSeed:           cfg.HashSeed,
 		logger:             set.Logger,
 	}
+	if cfg.SamplerMode == consistentSamplerMode {
+		tsp.consistentSampler = newConsistentSampler(cfg.SamplingPercentage, cfg.HashSeed, set.Logger)
+	}
 
 	return processorhelper.NewTracesProcessor(
 		ctx,
@@ -100,23 +105,30 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 					statCountTracesSampled.M(int64(1)),
 				)
 
-				// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
-				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
-				// Hashing here prevents bias due to such systems.
-				tidBytes := s.TraceID()
-				sampled := sp == mustSampleSpan ||
-					hash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+				policy := "trace_id_hash"
+				var sampled bool
+				if tsp.consistentSampler != nil {
+					policy = "consistent_probability"
+					sampled = sp == mustSampleSpan || tsp.consistentSampler.sample(s)
+				} else {
+					// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
+					// with various different criteria to generate trace id and perhaps were already sampled without hashing.
+					// Hashing here prevents bias due to such systems.
+					tidBytes := s.TraceID()
+					sampled = sp == mustSampleSpan ||
+						hash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+				}
 
 				if sampled {
 					_ = stats.RecordWithTags(
 						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, "true")},
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, policy), tag.Upsert(tagSampledKey, "true")},
 						statCountTracesSampled.M(int64(1)),
 					)
 				} else {
 					_ = stats.RecordWithTags(
 						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, "false")},
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, policy), tag.Upsert(tagSampledKey, "false")},
 						statCountTracesSampled.M(int64(1)),
 					)
 				}
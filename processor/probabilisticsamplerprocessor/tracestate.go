@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// otTraceStateKey is the key of the OpenTelemetry entry of the W3C tracestate, holding the
+	// p-value and r-value of the consistent probability sampling, e.g. "ot=p:2;r:5".
+	otTraceStateKey = "ot"
+	// maxPValue is the p-value of the zero sampling probability.
+	maxPValue = 63
+	// maxRValue is the highest r-value, the number of leading zeros of a 62 bits random value.
+	maxRValue = 62
+)
+
+// otTraceState is the parsed value of the "ot" tracestate entry.
+type otTraceState struct {
+	hasP bool
+	p    uint8
+	hasR bool
+	r    uint8
+	// fields are the other fields of the entry, kept as is.
+	fields []string
+}
+
+// splitTraceState returns the value of the "ot" entry of the W3C tracestate and its other
+// entries.
+func splitTraceState(traceState string) (otValue string, others []string) {
+	for _, member := range strings.Split(traceState, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		if strings.HasPrefix(member, otTraceStateKey+"=") {
+			otValue = member[len(otTraceStateKey)+1:]
+			continue
+		}
+		others = append(others, member)
+	}
+	return otValue, others
+}
+
+// joinTraceState builds the W3C tracestate, with the updated "ot" entry first.
+func joinTraceState(otValue string, others []string) string {
+	if otValue == "" {
+		return strings.Join(others, ",")
+	}
+	return strings.Join(append([]string{otTraceStateKey + "=" + otValue}, others...), ",")
+}
+
+// parseOTTraceState parses the value of the "ot" tracestate entry. Invalid p-values and
+// r-values are returned as errors and left unset.
+func parseOTTraceState(value string) (otTraceState, error) {
+	var ot otTraceState
+	if value == "" {
+		return ot, nil
+	}
+	var errs []string
+	for _, field := range strings.Split(value, ";") {
+		switch {
+		case strings.HasPrefix(field, "p:"):
+			p, err := strconv.ParseUint(field[2:], 10, 8)
+			if err != nil || p > maxPValue {
+				errs = append(errs, fmt.Sprintf("invalid p-value %q", field[2:]))
+				continue
+			}
+			ot.p, ot.hasP = uint8(p), true
+		case strings.HasPrefix(field, "r:"):
+			r, err := strconv.ParseUint(field[2:], 10, 8)
+			if err != nil || r > maxRValue {
+				errs = append(errs, fmt.Sprintf("invalid r-value %q", field[2:]))
+				continue
+			}
+			ot.r, ot.hasR = uint8(r), true
+		case field != "":
+			ot.fields = append(ot.fields, field)
+		}
+	}
+	if len(errs) > 0 {
+		return ot, fmt.Errorf("invalid ot tracestate %q: %s", value, strings.Join(errs, ", "))
+	}
+	return ot, nil
+}
+
+// serialize returns the value of the "ot" tracestate entry.
+func (ot otTraceState) serialize() string {
+	var fields []string
+	if ot.hasP {
+		fields = append(fields, "p:"+strconv.Itoa(int(ot.p)))
+	}
+	if ot.hasR {
+		fields = append(fields, "r:"+strconv.Itoa(int(ot.r)))
+	}
+	return strings.Join(append(fields, ot.fields...), ";")
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitJoinTraceState(t *testing.T) {
+	otValue, others := splitTraceState("vendor1=a, ot=p:2;r:5 ,vendor2=b")
+	assert.Equal(t, "p:2;r:5", otValue)
+	assert.Equal(t, []string{"vendor1=a", "vendor2=b"}, others)
+	assert.Equal(t, "ot=p:3;r:5,vendor1=a,vendor2=b", joinTraceState("p:3;r:5", others))
+
+	otValue, others = splitTraceState("")
+	assert.Empty(t, otValue)
+	assert.Empty(t, others)
+	assert.Equal(t, "vendor1=a", joinTraceState("", []string{"vendor1=a"}))
+}
+
+func TestParseOTTraceState(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    otTraceState
+		serialized  string
+		expectedErr string
+	}{
+		{
+			value:      "",
+			serialized: "",
+		},
+		{
+			value:      "p:2;r:5",
+			expected:   otTraceState{hasP: true, p: 2, hasR: true, r: 5},
+			serialized: "p:2;r:5",
+		},
+		{
+			value:      "r:62;x:foo;p:63",
+			expected:   otTraceState{hasP: true, p: 63, hasR: true, r: 62, fields: []string{"x:foo"}},
+			serialized: "p:63;r:62;x:foo",
+		},
+		{
+			value:       "p:64;r:5",
+			expected:    otTraceState{hasR: true, r: 5},
+			serialized:  "r:5",
+			expectedErr: `invalid ot tracestate "p:64;r:5": invalid p-value "64"`,
+		},
+		{
+			value:       "p:1;r:63",
+			expected:    otTraceState{hasP: true, p: 1},
+			serialized:  "p:1",
+			expectedErr: `invalid ot tracestate "p:1;r:63": invalid r-value "63"`,
+		},
+		{
+			value:       "p:x;r:-1",
+			serialized:  "",
+			expectedErr: `invalid ot tracestate "p:x;r:-1": invalid p-value "x", invalid r-value "-1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ot, err := parseOTTraceState(tt.value)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, ot)
+			assert.Equal(t, tt.serialized, ot.serialize())
+		})
+	}
+}
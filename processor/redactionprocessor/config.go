@@ -35,6 +35,12 @@ type Config struct {
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// HashKey is the secret key used to hash blocked values. If it is set,
+	// the parts of a value that match a blocked value are replaced with
+	// their hex encoded HMAC-SHA256 instead of a fixed mask. This keeps
+	// masked values joinable across signals without making them readable
+	HashKey string `mapstructure:"hash_key"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -39,6 +39,7 @@ func TestLoadConfig(t *testing.T) {
 				AllowAllKeys:      false,
 				AllowedKeys:       []string{"description", "group", "id", "name"},
 				BlockedValues:     []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
+				HashKey:           "a-secret-key",
 				Summary:           debug,
 			},
 		},
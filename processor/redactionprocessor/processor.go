@@ -16,6 +16,9 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"sort"
@@ -24,29 +27,30 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
-const attrValuesSeparator = ","
-
-var _ component.TracesProcessor = (*redaction)(nil)
+const (
+	attrValuesSeparator = ","
+	defaultMask         = "****"
+)
 
 type redaction struct {
-	// Attribute keys allowed in a span
+	// Attribute keys allowed in a span, log record or data point
 	allowList map[string]string
-	// Attribute values blocked in a span
+	// Attribute values blocked in a span, log record or data point
 	blockRegexList map[string]*regexp.Regexp
 	// Redaction processor configuration
 	config *Config
 	// Logger
 	logger *zap.Logger
-	// Next trace consumer in line
-	next consumer.Traces
 }
 
 // newRedaction creates a new instance of the redaction processor
-func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next consumer.Traces) (*redaction, error) {
+func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	blockRegexList, err := makeBlockRegexList(ctx, config)
 	if err != nil {
@@ -59,11 +63,10 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next
 		blockRegexList: blockRegexList,
 		config:         config,
 		logger:         logger,
-		next:           next,
 	}, nil
 }
 
-// processTraces implements ProcessMetricsFunc. It processes the incoming data
+// processTraces implements ProcessTracesFunc. It processes the incoming data
 // and returns the data to be sent to the next component
 func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptrace.Traces, error) {
 	for i := 0; i < batch.ResourceSpans().Len(); i++ {
@@ -93,6 +96,140 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processAttrs(ctx, rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				s.processAttrs(ctx, lr.Attributes())
+				s.processBody(lr)
+			}
+		}
+	}
+	return logs, nil
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.processAttrs(ctx, rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				s.processMetric(ctx, sm.Metrics().At(k))
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// processMetric redacts the attributes of all data points of a metric
+func (s *redaction) processMetric(ctx context.Context, metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.processAttrs(ctx, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.processAttrs(ctx, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.processAttrs(ctx, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.processAttrs(ctx, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.processAttrs(ctx, dps.At(i).Attributes())
+		}
+	}
+}
+
+// processBody masks blocked values in the body of a log record. String values
+// nested in map and slice bodies are masked as well. The allowed keys list
+// does not apply to the body, so no part of it is removed
+func (s *redaction) processBody(lr plog.LogRecord) {
+	maskedCount := s.maskValue(lr.Body())
+	if maskedCount == 0 {
+		return
+	}
+	if s.config.Summary == info || s.config.Summary == debug {
+		count := int64(maskedCount)
+		if existingVal, found := lr.Attributes().Get(bodyMaskedCount); found {
+			count += existingVal.Int()
+		}
+		lr.Attributes().PutInt(bodyMaskedCount, count)
+	}
+}
+
+// maskValue masks blocked values in a string value, or recursively in the
+// values of a map or slice, and returns the number of string values masked
+func (s *redaction) maskValue(value pcommon.Value) int {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		if masked, ok := s.maskString(value.Str()); ok {
+			value.SetStr(masked)
+			return 1
+		}
+	case pcommon.ValueTypeMap:
+		count := 0
+		value.Map().Range(func(_ string, v pcommon.Value) bool {
+			count += s.maskValue(v)
+			return true
+		})
+		return count
+	case pcommon.ValueTypeSlice:
+		count := 0
+		for i := 0; i < value.Slice().Len(); i++ {
+			count += s.maskValue(value.Slice().At(i))
+		}
+		return count
+	}
+	return 0
+}
+
+// maskString replaces all parts of a string matching a blocked value. It
+// reports whether any part of the string was masked
+func (s *redaction) maskString(strVal string) (string, bool) {
+	masked := false
+	for _, compiledRE := range s.blockRegexList {
+		if compiledRE.MatchString(strVal) {
+			masked = true
+			strVal = compiledRE.ReplaceAllStringFunc(strVal, s.mask)
+		}
+	}
+	return strVal, masked
+}
+
+// mask returns the replacement for a blocked value. Without a hash key the
+// value is replaced with a fixed length of asterisks. With a hash key it is
+// replaced with the hex encoded HMAC-SHA256 of the value, so that equal
+// values can still be correlated without being readable
+func (s *redaction) mask(blocked string) string {
+	if s.config.HashKey == "" {
+		return defaultMask
+	}
+	h := hmac.New(sha256.New, []byte(s.config.HashKey))
+	h.Write([]byte(blocked))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // processAttrs redacts the attributes of a resource span or a span
 func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// TODO: Use the context for recording metrics
@@ -118,15 +255,12 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				toBlock = append(toBlock, k)
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-			}
+		if value.Type() != pcommon.ValueTypeStr {
+			return true
+		}
+		if maskedValue, masked := s.maskString(value.Str()); masked {
+			toBlock = append(toBlock, k)
+			value.SetStr(maskedValue)
 		}
 		return true
 	})
@@ -140,17 +274,6 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
 }
 
-// ConsumeTraces implements the SpanProcessor interface
-func (s *redaction) ConsumeTraces(ctx context.Context, batch ptrace.Traces) error {
-	batch, err := s.processTraces(ctx, batch)
-	if err != nil {
-		return err
-	}
-
-	err = s.next.ConsumeTraces(ctx, batch)
-	return err
-}
-
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
 func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map, valuesAttr, countAttr string) {
 	redactedCount := int64(len(redactedAttrs))
@@ -181,6 +304,7 @@ const (
 	redactedKeyCount = "redaction.redacted.count"
 	maskedValues     = "redaction.masked.keys"
 	maskedValueCount = "redaction.masked.count"
+	bodyMaskedCount  = "redaction.body.masked.count"
 )
 
 // makeAllowList sets up a lookup table of allowed span attribute keys
@@ -195,7 +319,11 @@ func makeAllowList(c *Config) map[string]string {
 	// span attributes (e.g. `notes`, `description`), then it will those
 	// attribute keys in `redaction.masked.keys` and set the
 	// `redaction.masked.count` to 2
-	redactionKeys := []string{redactedKeys, redactedKeyCount, maskedValues, maskedValueCount}
+	//
+	// If the processor masks values in the body of a log record, then it sets
+	// the `redaction.body.masked.count` log attribute to the number of values
+	// masked
+	redactionKeys := []string{redactedKeys, redactedKeyCount, maskedValues, maskedValueCount, bodyMaskedCount}
 	// allowList consists of the keys explicitly allowed by the configuration
 	// as well as of the new span attributes that the processor creates to
 	// summarize its changes
@@ -25,14 +25,15 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
 
 func TestCapabilities(t *testing.T) {
 	config := &Config{}
-	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
 	assert.NoError(t, err)
 
 	cap := processor.Capabilities()
@@ -41,8 +42,7 @@ func TestCapabilities(t *testing.T) {
 
 func TestStartShutdown(t *testing.T) {
 	config := &Config{}
-	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
 	assert.NoError(t, err)
 
 	ctx := context.Background()
@@ -145,8 +145,6 @@ func TestAllowAllKeysMaskValues(t *testing.T) {
 	assert.Equal(t, "placeholder ****", value.Str())
 }
 
-// TODO: Test redaction with metric tags in a metrics PR
-
 // TestRedactSummaryDebug validates that the processor writes a verbose summary
 // of any attributes it deleted to the new redaction.redacted.keys and
 // redaction.redacted.count span attributes while set to full debug output
@@ -359,7 +357,7 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
 		Summary:       "debug",
 	}
-	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t), consumertest.NewNop())
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
 	require.NoError(t, err)
 
 	attrs := pcommon.NewMap()
@@ -390,6 +388,132 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 	assert.Equal(t, int64(2), val.Int())
 }
 
+// TestHashBlockedValues validates that the processor replaces blocked values
+// with their keyed hash when Config.HashKey is set
+func TestHashBlockedValues(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"name", "email"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		HashKey:       "secret",
+	}
+	masked := map[string]pcommon.Value{
+		"name":  pcommon.NewValueStr("placeholder 4111111111111111"),
+		"email": pcommon.NewValueStr("4111111111111111"),
+	}
+
+	_, _, next := runTest(t, nil, nil, masked, config)
+
+	attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	nameValue, _ := attr.Get("name")
+	emailValue, _ := attr.Get("email")
+	hash := "d6c005134ac50dec0e01cbc4aeaf3fbdb511c4ceeb55f909c29def3b0cffba36"
+	assert.Equal(t, "placeholder "+hash, nameValue.Str())
+	assert.Equal(t, hash, emailValue.Str())
+}
+
+// TestRedactLogs validates that the processor redacts log record and
+// resource attributes and masks blocked values in log bodies
+func TestRedactLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "name"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "debug",
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("name", "host 4111111111111111")
+	rl.Resource().Attributes().PutStr("credit_card", "4111111111111111")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	strBody := lrs.AppendEmpty()
+	strBody.Body().SetStr("paid with 4111111111111111")
+	strBody.Attributes().PutInt("id", 5)
+	strBody.Attributes().PutStr("credit_card", "4111111111111111")
+
+	mapBody := lrs.AppendEmpty()
+	assert.NoError(t, mapBody.Body().SetEmptyMap().FromRaw(map[string]interface{}{
+		"card":  "4111111111111111",
+		"cards": []interface{}{"4111111111111111", "none"},
+		"id":    5,
+	}))
+
+	cleanBody := lrs.AppendEmpty()
+	cleanBody.Body().SetStr("nothing to see")
+
+	out, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	resAttrs := out.ResourceLogs().At(0).Resource().Attributes()
+	_, ok := resAttrs.Get("credit_card")
+	assert.False(t, ok)
+	val, _ := resAttrs.Get("name")
+	assert.Equal(t, "host ****", val.Str())
+
+	outLogs := out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	lr := outLogs.At(0)
+	assert.Equal(t, "paid with ****", lr.Body().Str())
+	_, ok = lr.Attributes().Get("credit_card")
+	assert.False(t, ok)
+	val, _ = lr.Attributes().Get(redactedKeys)
+	assert.Equal(t, "credit_card", val.Str())
+	val, _ = lr.Attributes().Get(bodyMaskedCount)
+	assert.Equal(t, int64(1), val.Int())
+
+	lr = outLogs.At(1)
+	assert.Equal(t, map[string]interface{}{
+		"card":  "****",
+		"cards": []interface{}{"****", "none"},
+		"id":    int64(5),
+	}, lr.Body().Map().AsRaw())
+	val, _ = lr.Attributes().Get(bodyMaskedCount)
+	assert.Equal(t, int64(2), val.Int())
+
+	lr = outLogs.At(2)
+	assert.Equal(t, "nothing to see", lr.Body().Str())
+	_, ok = lr.Attributes().Get(bodyMaskedCount)
+	assert.False(t, ok)
+}
+
+// TestRedactMetrics validates that the processor redacts resource and data
+// point attributes of all metric types
+func TestRedactMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"name"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("credit_card", "4111111111111111")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	var attrs []pcommon.Map
+	attrs = append(attrs, ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes())
+	attrs = append(attrs, ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes())
+	attrs = append(attrs, ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	attrs = append(attrs, ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes())
+	attrs = append(attrs, ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes())
+	for _, attr := range attrs {
+		attr.PutStr("name", "placeholder 4111111111111111")
+		attr.PutStr("credit_card", "4111111111111111")
+	}
+
+	_, err = processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+
+	_, ok := rm.Resource().Attributes().Get("credit_card")
+	assert.False(t, ok)
+	for _, attr := range attrs {
+		assert.Equal(t, 1, attr.Len())
+		val, _ := attr.Get("name")
+		assert.Equal(t, "placeholder ****", val.Str())
+	}
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
@@ -426,9 +550,11 @@ func runTest(
 	// test
 	ctx := context.Background()
 	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t))
+	assert.NoError(t, err)
+	outBatch, err := processor.processTraces(ctx, inBatch)
 	assert.NoError(t, err)
-	err = processor.ConsumeTraces(ctx, inBatch)
+	err = next.ConsumeTraces(ctx, outBatch)
 
 	// verify
 	assert.NoError(t, err)
@@ -457,8 +583,7 @@ func BenchmarkRedactSummaryDebug(b *testing.B) {
 		"credit_card": pcommon.NewValueStr("would be nice"),
 	}
 	ctx := context.Background()
-	next := new(consumertest.TracesSink)
-	processor, _ := newRedaction(ctx, config, zaptest.NewLogger(b), next)
+	processor, _ := newRedaction(ctx, config, zaptest.NewLogger(b))
 
 	for i := 0; i < b.N; i++ {
 		runBenchmark(allowed, redacted, masked, processor)
@@ -484,8 +609,7 @@ func BenchmarkMaskSummaryDebug(b *testing.B) {
 		"url":  pcommon.NewValueStr("https://www.this_is_testing_url.com"),
 	}
 	ctx := context.Background()
-	next := new(consumertest.TracesSink)
-	processor, _ := newRedaction(ctx, config, zaptest.NewLogger(b), next)
+	processor, _ := newRedaction(ctx, config, zaptest.NewLogger(b))
 
 	for i := 0; i < b.N; i++ {
 		runBenchmark(allowed, nil, masked, processor)
@@ -519,5 +643,5 @@ func runBenchmark(
 		v.CopyTo(span.Attributes().PutEmpty(k))
 	}
 
-	_ = processor.ConsumeTraces(context.Background(), inBatch)
+	_, _ = processor.processTraces(context.Background(), inBatch)
 }
@@ -40,6 +40,12 @@ type Config struct {
 	// block processing of signals. (Optional field)
 	Prefetch []string `mapstructure:"prefetch"`
 
+	// CacheDirectory is an optional directory, given as a path or a file:// URL,
+	// holding local copies of the schema files laid out as <host>/<path>,
+	// e.g. opentelemetry.io/schemas/1.9.0. Schema files are looked up there first,
+	// and the downloaded ones are saved there. (Optional field)
+	CacheDirectory string `mapstructure:"cache_directory"`
+
 	// Targets define what schema families should be
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
@@ -47,6 +47,7 @@ func TestLoadConfig(t *testing.T) {
 		Prefetch: []string{
 			"https://opentelemetry.io/schemas/1.9.0",
 		},
+		CacheDirectory: "/var/lib/otelcol/schemas",
 		Targets: []string{
 			"https://opentelemetry.io/schemas/1.4.2",
 			"https://example.com/otel/schemas/1.2.0",
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Provider retrieves the content of the schema file published at a schema URL.
+type Provider interface {
+	Retrieve(ctx context.Context, schemaURL string) ([]byte, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider returns a provider downloading schema files from their schema URL.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (p *httpProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download schema file %q: status code %d", schemaURL, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+type cacheProvider struct {
+	dir  string
+	next Provider
+}
+
+var _ Provider = (*cacheProvider)(nil)
+
+// NewCacheProvider returns a provider looking for schema files in a local directory,
+// laid out as <dir>/<host>/<path>, e.g. <dir>/opentelemetry.io/schemas/1.9.0.
+// The directory may be given as a path or a file:// URL.
+// Schema files that are not found locally are retrieved from next, if not nil, and
+// saved in the directory for later use.
+func NewCacheProvider(dir string, next Provider) (Provider, error) {
+	if strings.HasPrefix(dir, "file:") {
+		u, err := url.Parse(dir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache directory %q: %w", dir, err)
+		}
+		dir = u.Path
+	}
+	if dir == "" {
+		return nil, errors.New("cache directory must not be empty")
+	}
+	return &cacheProvider{dir: filepath.Clean(dir), next: next}, nil
+}
+
+func (p *cacheProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(p.dir, u.Host, filepath.FromSlash(u.Path))
+	if !strings.HasPrefix(path, p.dir+string(filepath.Separator)) {
+		return nil, fmt.Errorf("schema url %q resolves outside of the cache directory", schemaURL)
+	}
+
+	content, err := os.ReadFile(path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) || p.next == nil {
+		return content, err
+	}
+
+	content, err = p.next.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	// caching is best effort, the schema file was retrieved anyway
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err == nil {
+		_ = os.WriteFile(path, content, 0600)
+	}
+	return content, nil
+}
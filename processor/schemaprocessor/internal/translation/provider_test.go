@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas/1.1.0" {
+			wr.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := wr.Write([]byte("file_format: 1.0.0\n"))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+
+	p := NewHTTPProvider(ts.Client())
+	content, err := p.Retrieve(context.Background(), ts.URL+"/schemas/1.1.0")
+	require.NoError(t, err, "Must not error when retrieving an existing schema file")
+	assert.Equal(t, "file_format: 1.0.0\n", string(content))
+
+	_, err = p.Retrieve(context.Background(), ts.URL+"/schemas/1.2.0")
+	assert.ErrorContains(t, err, "status code 404")
+}
+
+type countingProvider struct {
+	calls   int
+	content []byte
+}
+
+func (p *countingProvider) Retrieve(context.Context, string) ([]byte, error) {
+	p.calls++
+	return p.content, nil
+}
+
+func TestCacheProvider(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "opentelemetry.io", "schemas"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "opentelemetry.io", "schemas", "1.0.0"), []byte("local"), 0600))
+
+	next := &countingProvider{content: []byte("remote")}
+	p, err := NewCacheProvider("file://"+filepath.ToSlash(dir), next)
+	require.NoError(t, err)
+
+	content, err := p.Retrieve(context.Background(), "https://opentelemetry.io/schemas/1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "local", string(content), "Must use the local copy of the schema file")
+	assert.Equal(t, 0, next.calls)
+
+	for i := 0; i < 2; i++ {
+		content, err = p.Retrieve(context.Background(), "https://opentelemetry.io/schemas/1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, "remote", string(content))
+	}
+	assert.Equal(t, 1, next.calls, "Must save the retrieved schema file in the cache directory")
+
+	_, err = p.Retrieve(context.Background(), "https://opentelemetry.io/../../etc/passwd")
+	assert.Error(t, err, "Must not read files outside of the cache directory")
+}
+
+func TestCacheProviderWithoutFallback(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewCacheProvider(t.TempDir(), nil)
+	require.NoError(t, err)
+	_, err = p.Retrieve(context.Background(), "https://opentelemetry.io/schemas/1.0.0")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = NewCacheProvider("", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// supportedFileFormat is the major and minor version of the schema file format
+// that can be read, any patch version of it is supported.
+var supportedFileFormat = &Version{Major: 1, Minor: 1}
+
+var ErrUnsupportedFileFormat = errors.New("unsupported schema file format")
+
+// Schema is the content of a schema file, as defined by
+// https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.1.0/
+type Schema struct {
+	FileFormat string                       `yaml:"file_format"`
+	SchemaURL  string                       `yaml:"schema_url"`
+	Versions   map[string]VersionDefinition `yaml:"versions"`
+}
+
+// VersionDefinition holds the changes introduced by a version of the schema,
+// relative to the previous version.
+type VersionDefinition struct {
+	All        AttributesChanges `yaml:"all"`
+	Resources  AttributesChanges `yaml:"resources"`
+	Spans      SpansChanges      `yaml:"spans"`
+	SpanEvents SpanEventsChanges `yaml:"span_events"`
+	Metrics    MetricsChanges    `yaml:"metrics"`
+	Logs       LogsChanges       `yaml:"logs"`
+}
+
+type AttributesChanges struct {
+	Changes []AttributesChange `yaml:"changes"`
+}
+
+type AttributesChange struct {
+	RenameAttributes map[string]string `yaml:"rename_attributes"`
+}
+
+type SpansChanges struct {
+	Changes []SpansChange `yaml:"changes"`
+}
+
+type SpansChange struct {
+	RenameAttributes struct {
+		AttributeMap map[string]string `yaml:"attribute_map"`
+		ApplyToSpans []string          `yaml:"apply_to_spans"`
+	} `yaml:"rename_attributes"`
+}
+
+type SpanEventsChanges struct {
+	Changes []SpanEventsChange `yaml:"changes"`
+}
+
+type SpanEventsChange struct {
+	RenameEvents struct {
+		NameMap map[string]string `yaml:"name_map"`
+	} `yaml:"rename_events"`
+	RenameAttributes struct {
+		AttributeMap  map[string]string `yaml:"attribute_map"`
+		ApplyToSpans  []string          `yaml:"apply_to_spans"`
+		ApplyToEvents []string          `yaml:"apply_to_events"`
+	} `yaml:"rename_attributes"`
+}
+
+type MetricsChanges struct {
+	Changes []MetricsChange `yaml:"changes"`
+}
+
+type MetricsChange struct {
+	RenameMetrics    map[string]string `yaml:"rename_metrics"`
+	RenameAttributes struct {
+		AttributeMap   map[string]string `yaml:"attribute_map"`
+		ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+	} `yaml:"rename_attributes"`
+}
+
+type LogsChanges struct {
+	Changes []LogsChange `yaml:"changes"`
+}
+
+type LogsChange struct {
+	RenameAttributes struct {
+		AttributeMap map[string]string `yaml:"attribute_map"`
+	} `yaml:"rename_attributes"`
+}
+
+// ReadSchema parses a schema file, checking that its file format is supported.
+func ReadSchema(r io.Reader) (*Schema, error) {
+	var schema Schema
+	if err := yaml.NewDecoder(r).Decode(&schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema file: %w", err)
+	}
+
+	format, err := NewVersion(schema.FileFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file format %q: %w", schema.FileFormat, err)
+	}
+	if format.Major != supportedFileFormat.Major || format.Minor > supportedFileFormat.Minor {
+		return nil, fmt.Errorf("file format %s: %w", format, ErrUnsupportedFileFormat)
+	}
+	return &schema, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readTestSchema(t *testing.T) *Schema {
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must be able to open the test schema")
+	defer f.Close()
+
+	schema, err := ReadSchema(f)
+	require.NoError(t, err, "Must be able to read the test schema")
+	return schema
+}
+
+func TestReadSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := readTestSchema(t)
+	assert.Equal(t, "1.0.0", schema.FileFormat)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", schema.SchemaURL)
+	require.Contains(t, schema.Versions, "1.1.0")
+	require.Contains(t, schema.Versions, "1.0.0")
+
+	def := schema.Versions["1.1.0"]
+	require.Len(t, def.All.Changes, 1)
+	assert.Equal(t, "kubernetes.pod.name", def.All.Changes[0].RenameAttributes["k8s.pod.name"])
+	require.Len(t, def.Spans.Changes, 1)
+	assert.Equal(t, []string{"HTTP GET"}, def.Spans.Changes[0].RenameAttributes.ApplyToSpans)
+	require.Len(t, def.SpanEvents.Changes, 2)
+	assert.Equal(t, map[string]string{"stacktrace": "stack_trace"}, def.SpanEvents.Changes[0].RenameEvents.NameMap)
+	require.Len(t, def.Metrics.Changes, 2)
+	assert.Equal(t, "cpu.usage.total", def.Metrics.Changes[0].RenameMetrics["container.cpu.usage.total"])
+	require.Len(t, def.Logs.Changes, 1)
+}
+
+func TestReadSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+		content  string
+		err      error
+	}{
+		{
+			scenario: "unsupported file format",
+			content:  "file_format: 2.0.0\nschema_url: https://example.com/schemas/1.0.0\n",
+			err:      ErrUnsupportedFileFormat,
+		},
+		{
+			scenario: "invalid file format",
+			content:  "file_format: one\n",
+			err:      ErrInvalidVersion,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			_, err := ReadSchema(strings.NewReader(tc.content))
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	_, err := ReadSchema(strings.NewReader("versions: [\n"))
+	assert.Error(t, err, "Must error when the schema file is not valid yaml")
+}
@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var ErrUnknownVersion = errors.New("version not defined by the schema")
+
+// Translation holds the changes defined by each version of a schema family,
+// allowing to convert signals between any two of its versions.
+type Translation struct {
+	// versions is sorted in ascending order
+	versions []*Version
+	// upgrades holds the changes to apply when converting signals to each version
+	// from the previous one, and downgrades the changes to revert them.
+	upgrades   map[Version]*changeSet
+	downgrades map[Version]*changeSet
+}
+
+// NewTranslation compiles the changes defined by the schema.
+func NewTranslation(schema *Schema) (*Translation, error) {
+	t := &Translation{
+		upgrades:   make(map[Version]*changeSet, len(schema.Versions)),
+		downgrades: make(map[Version]*changeSet, len(schema.Versions)),
+	}
+	for id, def := range schema.Versions {
+		version, err := NewVersion(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", id, err)
+		}
+		cs := newChangeSet(def)
+		t.versions = append(t.versions, version)
+		t.upgrades[*version] = cs
+		t.downgrades[*version] = cs.reverted()
+	}
+	sort.Slice(t.versions, func(i, j int) bool {
+		return t.versions[i].LessThan(t.versions[j])
+	})
+	return t, nil
+}
+
+// SupportsVersion returns whether the version is defined by the schema.
+func (t *Translation) SupportsVersion(v *Version) bool {
+	_, ok := t.upgrades[*v]
+	return ok
+}
+
+// Migration returns the changes to apply to convert signals from a version to another.
+func (t *Translation) Migration(from, to *Version) (*Migration, error) {
+	for _, v := range []*Version{from, to} {
+		if !t.SupportsVersion(v) {
+			return nil, fmt.Errorf("%s: %w", v, ErrUnknownVersion)
+		}
+	}
+
+	m := &Migration{}
+	switch {
+	case from.LessThan(to):
+		for _, v := range t.versions {
+			if v.GreaterThan(from) && !v.GreaterThan(to) {
+				m.steps = append(m.steps, t.upgrades[*v])
+			}
+		}
+	case from.GreaterThan(to):
+		for i := len(t.versions) - 1; i >= 0; i-- {
+			if v := t.versions[i]; v.GreaterThan(to) && !v.GreaterThan(from) {
+				m.steps = append(m.steps, t.downgrades[*v])
+			}
+		}
+	}
+	return m, nil
+}
+
+// Migration applies the changes between two versions of a schema to signals.
+type Migration struct {
+	steps []*changeSet
+}
+
+func (m *Migration) ApplyResource(resource pcommon.Resource) {
+	for _, cs := range m.steps {
+		for _, op := range cs.resources {
+			op.apply(resource.Attributes())
+		}
+	}
+}
+
+func (m *Migration) ApplySpan(span ptrace.Span) {
+	for _, cs := range m.steps {
+		for _, op := range cs.spans {
+			if op.applyToSpans.contains(span.Name()) {
+				op.attributes.apply(span.Attributes())
+			}
+		}
+		for i := 0; i < span.Events().Len(); i++ {
+			event := span.Events().At(i)
+			for _, op := range cs.spanEvents {
+				if name, ok := op.events[event.Name()]; ok {
+					event.SetName(name)
+				}
+				if op.applyToSpans.contains(span.Name()) && op.applyToEvents.contains(event.Name()) {
+					op.attributes.apply(event.Attributes())
+				}
+			}
+		}
+	}
+}
+
+func (m *Migration) ApplyMetric(metric pmetric.Metric) {
+	for _, cs := range m.steps {
+		for _, op := range cs.metrics {
+			if name, ok := op.metrics[metric.Name()]; ok {
+				metric.SetName(name)
+			}
+			if len(op.attributes) > 0 && op.applyToMetrics.contains(metric.Name()) {
+				for _, attrs := range dataPointsAttributes(metric) {
+					op.attributes.apply(attrs)
+				}
+			}
+		}
+	}
+}
+
+func (m *Migration) ApplyLog(log plog.LogRecord) {
+	for _, cs := range m.steps {
+		for _, op := range cs.logs {
+			op.apply(log.Attributes())
+		}
+	}
+}
+
+// renames maps old names to new names.
+type renames map[string]string
+
+func (r renames) inverted() renames {
+	if r == nil {
+		return nil
+	}
+	inv := make(renames, len(r))
+	for from, to := range r {
+		inv[to] = from
+	}
+	return inv
+}
+
+// apply renames the attributes, unless an attribute with the new name already exists.
+func (r renames) apply(attrs pcommon.Map) {
+	for from, to := range r {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		if _, exists := attrs.Get(to); exists {
+			continue
+		}
+		// the value has to be copied out, as the map may be reallocated
+		value := pcommon.NewValueEmpty()
+		v.CopyTo(value)
+		attrs.Remove(from)
+		value.CopyTo(attrs.PutEmpty(to))
+	}
+}
+
+// names is a set of names that a change applies to, an empty set matching all names.
+type names map[string]struct{}
+
+func newNames(list []string) names {
+	set := make(names, len(list))
+	for _, name := range list {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+func (n names) contains(name string) bool {
+	if len(n) == 0 {
+		return true
+	}
+	_, ok := n[name]
+	return ok
+}
+
+type spanOp struct {
+	attributes   renames
+	applyToSpans names
+}
+
+type spanEventOp struct {
+	events        renames
+	attributes    renames
+	applyToSpans  names
+	applyToEvents names
+}
+
+type metricOp struct {
+	metrics        renames
+	attributes     renames
+	applyToMetrics names
+}
+
+// changeSet holds the changes of a version, grouped per signal, in the order
+// they must be applied. The changes defined in the "all" section come first.
+type changeSet struct {
+	resources  []renames
+	spans      []spanOp
+	spanEvents []spanEventOp
+	metrics    []metricOp
+	logs       []renames
+}
+
+func newChangeSet(def VersionDefinition) *changeSet {
+	cs := &changeSet{}
+	for _, change := range def.All.Changes {
+		attrs := renames(change.RenameAttributes)
+		cs.resources = append(cs.resources, attrs)
+		cs.spans = append(cs.spans, spanOp{attributes: attrs})
+		cs.spanEvents = append(cs.spanEvents, spanEventOp{attributes: attrs})
+		cs.metrics = append(cs.metrics, metricOp{attributes: attrs})
+		cs.logs = append(cs.logs, attrs)
+	}
+	for _, change := range def.Resources.Changes {
+		cs.resources = append(cs.resources, change.RenameAttributes)
+	}
+	for _, change := range def.Spans.Changes {
+		cs.spans = append(cs.spans, spanOp{
+			attributes:   change.RenameAttributes.AttributeMap,
+			applyToSpans: newNames(change.RenameAttributes.ApplyToSpans),
+		})
+	}
+	for _, change := range def.SpanEvents.Changes {
+		if len(change.RenameEvents.NameMap) > 0 {
+			cs.spanEvents = append(cs.spanEvents, spanEventOp{events: change.RenameEvents.NameMap})
+		}
+		if len(change.RenameAttributes.AttributeMap) > 0 {
+			cs.spanEvents = append(cs.spanEvents, spanEventOp{
+				attributes:    change.RenameAttributes.AttributeMap,
+				applyToSpans:  newNames(change.RenameAttributes.ApplyToSpans),
+				applyToEvents: newNames(change.RenameAttributes.ApplyToEvents),
+			})
+		}
+	}
+	for _, change := range def.Metrics.Changes {
+		if len(change.RenameMetrics) > 0 {
+			cs.metrics = append(cs.metrics, metricOp{metrics: change.RenameMetrics})
+		}
+		if len(change.RenameAttributes.AttributeMap) > 0 {
+			cs.metrics = append(cs.metrics, metricOp{
+				attributes:     change.RenameAttributes.AttributeMap,
+				applyToMetrics: newNames(change.RenameAttributes.ApplyToMetrics),
+			})
+		}
+	}
+	for _, change := range def.Logs.Changes {
+		cs.logs = append(cs.logs, change.RenameAttributes.AttributeMap)
+	}
+	return cs
+}
+
+// reverted returns the changes undoing this change set, which are the inverted
+// changes applied in reverse order.
+func (cs *changeSet) reverted() *changeSet {
+	rev := &changeSet{}
+	for i := len(cs.resources) - 1; i >= 0; i-- {
+		rev.resources = append(rev.resources, cs.resources[i].inverted())
+	}
+	for i := len(cs.spans) - 1; i >= 0; i-- {
+		op := cs.spans[i]
+		op.attributes = op.attributes.inverted()
+		rev.spans = append(rev.spans, op)
+	}
+	for i := len(cs.spanEvents) - 1; i >= 0; i-- {
+		op := cs.spanEvents[i]
+		op.events = op.events.inverted()
+		op.attributes = op.attributes.inverted()
+		rev.spanEvents = append(rev.spanEvents, op)
+	}
+	for i := len(cs.metrics) - 1; i >= 0; i-- {
+		op := cs.metrics[i]
+		op.metrics = op.metrics.inverted()
+		op.attributes = op.attributes.inverted()
+		rev.metrics = append(rev.metrics, op)
+	}
+	for i := len(cs.logs) - 1; i >= 0; i-- {
+		rev.logs = append(rev.logs, cs.logs[i].inverted())
+	}
+	return rev
+}
+
+// dataPointsAttributes returns the attributes of all the data points of the metric.
+func dataPointsAttributes(metric pmetric.Metric) []pcommon.Map {
+	var attrs []pcommon.Map
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			attrs = append(attrs, metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			attrs = append(attrs, metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			attrs = append(attrs, metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			attrs = append(attrs, metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			attrs = append(attrs, metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+	return attrs
+}
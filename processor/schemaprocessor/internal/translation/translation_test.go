@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTranslation(t *testing.T) *Translation {
+	tr, err := NewTranslation(readTestSchema(t))
+	require.NoError(t, err, "Must be able to compile the test schema")
+	return tr
+}
+
+func TestTranslationSupportsVersion(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	assert.True(t, tr.SupportsVersion(&Version{1, 0, 0}))
+	assert.True(t, tr.SupportsVersion(&Version{1, 1, 0}))
+	assert.False(t, tr.SupportsVersion(&Version{1, 2, 0}))
+
+	_, err := tr.Migration(&Version{1, 0, 0}, &Version{1, 2, 0})
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestMigrationResource(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	upgrade, err := tr.Migration(&Version{1, 0, 0}, &Version{1, 1, 0})
+	require.NoError(t, err)
+
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("k8s.pod.name", "pod")
+	res.Attributes().PutStr("telemetry.auto.version", "1.0")
+	res.Attributes().PutStr("service.name", "svc")
+	upgrade.ApplyResource(res)
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes.pod.name":          "pod",
+		"telemetry.auto_instr.version": "1.0",
+		"service.name":                 "svc",
+	}, res.Attributes().AsRaw())
+
+	downgrade, err := tr.Migration(&Version{1, 1, 0}, &Version{1, 0, 0})
+	require.NoError(t, err)
+	downgrade.ApplyResource(res)
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":           "pod",
+		"telemetry.auto.version": "1.0",
+		"service.name":           "svc",
+	}, res.Attributes().AsRaw())
+}
+
+func TestMigrationKeepsExistingAttributes(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	upgrade, err := tr.Migration(&Version{1, 0, 0}, &Version{1, 1, 0})
+	require.NoError(t, err)
+
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("k8s.pod.name", "old")
+	res.Attributes().PutStr("kubernetes.pod.name", "new")
+	upgrade.ApplyResource(res)
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":        "old",
+		"kubernetes.pod.name": "new",
+	}, res.Attributes().AsRaw())
+}
+
+func TestMigrationSpan(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	upgrade, err := tr.Migration(&Version{1, 0, 0}, &Version{1, 1, 0})
+	require.NoError(t, err)
+
+	get := ptrace.NewSpan()
+	get.SetName("HTTP GET")
+	get.Attributes().PutStr("peer.service", "backend")
+	get.Attributes().PutStr("k8s.node.name", "node")
+	event := get.Events().AppendEmpty()
+	event.SetName("stacktrace")
+	exception := get.Events().AppendEmpty()
+	exception.SetName("exception.stack_trace")
+	exception.Attributes().PutStr("peer.service", "backend")
+
+	post := ptrace.NewSpan()
+	post.SetName("HTTP POST")
+	post.Attributes().PutStr("peer.service", "backend")
+
+	upgrade.ApplySpan(get)
+	upgrade.ApplySpan(post)
+
+	assert.Equal(t, map[string]interface{}{
+		"peer.service.name":    "backend",
+		"kubernetes.node.name": "node",
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", get.Events().At(0).Name())
+	assert.Equal(t, map[string]interface{}{"peer.service.name": "backend"}, get.Events().At(1).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"peer.service": "backend"}, post.Attributes().AsRaw(), "Must only rename attributes of the listed spans")
+
+	downgrade, err := tr.Migration(&Version{1, 1, 0}, &Version{1, 0, 0})
+	require.NoError(t, err)
+	downgrade.ApplySpan(get)
+	assert.Equal(t, map[string]interface{}{
+		"peer.service":  "backend",
+		"k8s.node.name": "node",
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "stacktrace", get.Events().At(0).Name())
+}
+
+func TestMigrationMetric(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	upgrade, err := tr.Migration(&Version{1, 0, 0}, &Version{1, 1, 0})
+	require.NoError(t, err)
+
+	renamed := pmetric.NewMetric()
+	renamed.SetName("container.cpu.usage.total")
+	renamed.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("k8s.pod.name", "pod")
+
+	filtered := pmetric.NewMetric()
+	filtered.SetName("system.cpu.utilization")
+	filtered.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("status", "idle")
+
+	other := pmetric.NewMetric()
+	other.SetName("system.network.io")
+	other.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("status", "up")
+
+	upgrade.ApplyMetric(renamed)
+	upgrade.ApplyMetric(filtered)
+	upgrade.ApplyMetric(other)
+
+	assert.Equal(t, "cpu.usage.total", renamed.Name())
+	assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod"}, renamed.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"state": "idle"}, filtered.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"status": "up"}, other.Histogram().DataPoints().At(0).Attributes().AsRaw())
+
+	downgrade, err := tr.Migration(&Version{1, 1, 0}, &Version{1, 0, 0})
+	require.NoError(t, err)
+	downgrade.ApplyMetric(renamed)
+	assert.Equal(t, "container.cpu.usage.total", renamed.Name())
+}
+
+func TestMigrationLog(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	upgrade, err := tr.Migration(&Version{1, 0, 0}, &Version{1, 1, 0})
+	require.NoError(t, err)
+
+	log := plog.NewLogRecord()
+	log.Attributes().PutStr("process.executable_name", "otelcol")
+	upgrade.ApplyLog(log)
+	assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, log.Attributes().AsRaw())
+
+	same, err := tr.Migration(&Version{1, 1, 0}, &Version{1, 1, 0})
+	require.NoError(t, err)
+	same.ApplyLog(log)
+	assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, log.Attributes().AsRaw())
+}
@@ -15,19 +15,66 @@
 package schemaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor"
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
+// retryInterval is the minimum duration between two attempts to retrieve
+// a schema file that could not be retrieved.
+const retryInterval = time.Minute
+
+// target is the schema version that signals of a schema family are converted to.
+type target struct {
+	schemaURL string
+	version   *translation.Version
+}
+
+type cachedTranslation struct {
+	// done is closed once the schema file has been retrieved, and the fields
+	// below are set.
+	done        chan struct{}
+	translation *translation.Translation
+	err         error
+	retrievedAt time.Time
+}
+
+// wait returns the translation once it has been retrieved.
+func (c *cachedTranslation) wait(ctx context.Context) (*translation.Translation, error) {
+	select {
+	case <-c.done:
+		return c.translation, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets  map[string]target
+	prefetch []string
+	cacheDir string
+	client   confighttp.HTTPClientSettings
+	set      component.TelemetrySettings
+	log      *zap.Logger
+
+	provider translation.Provider
+	// translations holds the translations read from the schema files, by schema URL.
+	lock         sync.Mutex
+	translations map[string]*cachedTranslation
+	now          func() time.Time
 }
 
 func newTransformer(
@@ -39,29 +86,211 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+
+	targets := make(map[string]target, len(cfg.Targets))
+	for _, schemaURL := range cfg.Targets {
+		family, version, err := translation.GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return nil, err
+		}
+		targets[family] = target{schemaURL: schemaURL, version: version}
+	}
+
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		targets:      targets,
+		prefetch:     cfg.Prefetch,
+		cacheDir:     cfg.CacheDirectory,
+		client:       cfg.HTTPClientSettings,
+		set:          set.TelemetrySettings,
+		log:          set.Logger,
+		translations: make(map[string]*cachedTranslation),
+		now:          time.Now,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		schemaURL := t.migrateResource(ctx, rl)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			migration := t.migrateScope(ctx, schemaURL, sl.SchemaUrl(), sl.SetSchemaUrl)
+			if migration == nil {
+				continue
+			}
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				migration.ApplyLog(sl.LogRecords().At(k))
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		schemaURL := t.migrateResource(ctx, rm)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			migration := t.migrateScope(ctx, schemaURL, sm.SchemaUrl(), sm.SetSchemaUrl)
+			if migration == nil {
+				continue
+			}
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				migration.ApplyMetric(sm.Metrics().At(k))
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		schemaURL := t.migrateResource(ctx, rs)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			migration := t.migrateScope(ctx, schemaURL, ss.SchemaUrl(), ss.SetSchemaUrl)
+			if migration == nil {
+				continue
+			}
+			for k := 0; k < ss.Spans().Len(); k++ {
+				migration.ApplySpan(ss.Spans().At(k))
+			}
+		}
+	}
 	return td, nil
 }
 
-// start will load the remote file definition if it isn't already cached
-// and resolve the schema translation file
+// migrateResource converts the resource attributes to the target version of their
+// schema family, and returns the schema URL the resource was published with.
+func (t *transformer) migrateResource(ctx context.Context, res alias.Resource) string {
+	schemaURL := res.SchemaUrl()
+	if migration, targetURL := t.migration(ctx, schemaURL); migration != nil {
+		migration.ApplyResource(res.Resource())
+		res.SetSchemaUrl(targetURL)
+	}
+	return schemaURL
+}
+
+// migrateScope returns the changes to apply to the signals of a scope, which
+// use the schema URL of the scope if set, or the one of their resource otherwise.
+func (t *transformer) migrateScope(ctx context.Context, resourceURL, scopeURL string, setScopeURL func(string)) *translation.Migration {
+	if scopeURL == "" {
+		migration, _ := t.migration(ctx, resourceURL)
+		return migration
+	}
+	migration, targetURL := t.migration(ctx, scopeURL)
+	if migration != nil {
+		setScopeURL(targetURL)
+	}
+	return migration
+}
+
+// migration returns the changes to apply to signals published with the schema URL,
+// and the target schema URL, or nil if the signals are not to be converted.
+func (t *transformer) migration(ctx context.Context, schemaURL string) (*translation.Migration, string) {
+	if schemaURL == "" {
+		return nil, ""
+	}
+	family, version, err := translation.GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		t.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, ""
+	}
+	tgt, ok := t.targets[family]
+	if !ok || version.Equal(tgt.version) {
+		return nil, ""
+	}
+
+	// the schema file of the newest version defines the changes of all the versions
+	fileURL := tgt.schemaURL
+	if version.GreaterThan(tgt.version) {
+		fileURL = schemaURL
+	}
+	tr, err := t.translation(ctx, fileURL)
+	if err != nil {
+		return nil, ""
+	}
+	migration, err := tr.Migration(version, tgt.version)
+	if err != nil {
+		t.log.Debug("Unable to convert signals", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, ""
+	}
+	return migration, tgt.schemaURL
+}
+
+// translation returns the translation defined by the schema file at the schema URL,
+// retrieving it if it is not cached yet. The schema file is retrieved outside of the
+// lock, so that only the signals using it wait for it.
+func (t *transformer) translation(ctx context.Context, schemaURL string) (*translation.Translation, error) {
+	t.lock.Lock()
+	if cached, ok := t.translations[schemaURL]; ok {
+		select {
+		case <-cached.done:
+			if cached.err == nil || t.now().Sub(cached.retrievedAt) < retryInterval {
+				t.lock.Unlock()
+				return cached.translation, cached.err
+			}
+		default:
+			// the schema file is being retrieved for other signals
+			t.lock.Unlock()
+			return cached.wait(ctx)
+		}
+	}
+	cached := &cachedTranslation{done: make(chan struct{})}
+	t.translations[schemaURL] = cached
+	provider := t.provider
+	t.lock.Unlock()
+
+	tr, err := retrieve(ctx, provider, schemaURL)
+	if err != nil {
+		t.log.Warn("Failed to retrieve schema file, the signals using it are not converted",
+			zap.String("schema-url", schemaURL), zap.Error(err))
+	}
+	cached.translation, cached.err, cached.retrievedAt = tr, err, t.now()
+	close(cached.done)
+	return tr, err
+}
+
+func retrieve(ctx context.Context, provider translation.Provider, schemaURL string) (*translation.Translation, error) {
+	if provider == nil {
+		return nil, errors.New("processor is not started")
+	}
+	content, err := provider.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := translation.ReadSchema(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return translation.NewTranslation(schema)
+}
+
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.client.ToClient(host, t.set)
+	if err != nil {
+		return fmt.Errorf("failed to create http client: %w", err)
+	}
+	provider := translation.NewHTTPProvider(client)
+	if t.cacheDir != "" {
+		if provider, err = translation.NewCacheProvider(t.cacheDir, provider); err != nil {
+			return err
+		}
+	}
+	t.lock.Lock()
+	t.provider = provider
+	t.lock.Unlock()
+
+	for _, schemaURL := range t.prefetch {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		// failures are logged, and retried once signals using the schema url are received
+		_, _ = t.translation(ctx, schemaURL)
+	}
+	for _, tgt := range t.targets {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", tgt.schemaURL))
+		_, _ = t.translation(ctx, tgt.schemaURL)
 	}
 	return nil
 }
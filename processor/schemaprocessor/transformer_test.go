@@ -18,11 +18,13 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -108,3 +110,155 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func newTranslatingTransformer(t *testing.T, target string) *transformer {
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{target}
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+	return trans
+}
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		requests++
+		SchemaHandler(t)(wr, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	oldURL, newURL := ts.URL+"/schemas/1.0.0", ts.URL+"/schemas/1.1.0"
+
+	t.Run("upgrade traces", func(t *testing.T) {
+		trans := newTranslatingTransformer(t, newURL)
+
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(oldURL)
+		rs.Resource().Attributes().PutStr("k8s.pod.name", "pod")
+		s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		s.SetName("HTTP GET")
+		s.Attributes().PutStr("peer.service", "backend")
+		s.Events().AppendEmpty().SetName("stacktrace")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err)
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, newURL, rs.SchemaUrl(), "Must stamp the target schema url")
+		assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod"}, rs.Resource().Attributes().AsRaw())
+		s = rs.ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, map[string]interface{}{"peer.service.name": "backend"}, s.Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", s.Events().At(0).Name())
+	})
+
+	t.Run("downgrade metrics", func(t *testing.T) {
+		trans := newTranslatingTransformer(t, oldURL)
+
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl(newURL)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.SetSchemaUrl(newURL)
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("cpu.usage.total")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err)
+		rm = out.ResourceMetrics().At(0)
+		assert.Equal(t, oldURL, rm.SchemaUrl())
+		assert.Equal(t, oldURL, rm.ScopeMetrics().At(0).SchemaUrl())
+		assert.Equal(t, "container.cpu.usage.total", rm.ScopeMetrics().At(0).Metrics().At(0).Name())
+	})
+
+	t.Run("logs of other families are unchanged", func(t *testing.T) {
+		trans := newTranslatingTransformer(t, newURL)
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "otelcol")
+		expected := plog.NewLogs()
+		in.CopyTo(expected)
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err)
+		assert.Equal(t, expected, out)
+	})
+
+	t.Run("logs upgraded", func(t *testing.T) {
+		trans := newTranslatingTransformer(t, newURL)
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(oldURL)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err)
+		attrs := out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+		assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, attrs.AsRaw())
+	})
+}
+
+func TestTransformerUnavailableSchema(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(ts.Close)
+
+	trans := newTranslatingTransformer(t, ts.URL+"/schemas/1.1.0")
+
+	in := plog.NewLogs()
+	rl := in.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(ts.URL + "/schemas/1.0.0")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "otelcol")
+	expected := plog.NewLogs()
+	in.CopyTo(expected)
+
+	out, err := trans.processLogs(context.Background(), in)
+	require.NoError(t, err, "Must pass signals through when the schema file is unavailable")
+	assert.Equal(t, expected, out)
+}
+
+func TestTransformerSlowSchemaDoesNotBlockOthers(t *testing.T) {
+	t.Parallel()
+
+	slowRequests := 0
+	slowReceived, releaseSlow := make(chan struct{}), make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow/1.1.0" {
+			slowRequests++
+			close(slowReceived)
+			<-releaseSlow
+		}
+		SchemaHandler(t)(wr, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	trans := newTranslatingTransformer(t, ts.URL+"/schemas/1.1.0")
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := trans.translation(context.Background(), ts.URL+"/slow/1.1.0")
+			results <- err
+		}()
+	}
+	<-slowReceived
+
+	_, err := trans.translation(context.Background(), ts.URL+"/schemas/1.0.0")
+	assert.NoError(t, err, "Must retrieve a schema file while another one is being retrieved")
+
+	close(releaseSlow)
+	assert.NoError(t, <-results)
+	assert.NoError(t, <-results)
+	assert.Equal(t, 1, slowRequests, "Must retrieve the schema file once for concurrent signals")
+}
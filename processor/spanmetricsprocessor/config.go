@@ -42,6 +42,28 @@ type Dimension struct {
 	Default *string `mapstructure:"default"`
 }
 
+// ExponentialHistogramConfig defines the configuration of the exponential latency histogram.
+type ExponentialHistogramConfig struct {
+	// MaxSize is the maximum number of buckets of the histogram. The scale of the histogram
+	// is reduced as needed to fit the recorded latencies in that many buckets.
+	// Optional. See defaultExponentialHistogramMaxSize in processor.go for the default value.
+	MaxSize int32 `mapstructure:"max_size"`
+}
+
+// EventsConfig defines the configuration of the span events counter.
+type EventsConfig struct {
+	// Enabled turns on the events_total metric, counting the span events.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Names restricts the counted span events to the ones with the given names, e.g. "exception".
+	// All the span events are counted if empty.
+	Names []string `mapstructure:"names"`
+
+	// Dimensions defines the list of dimensions added to the ones of the span, fetched from
+	// the event's attributes. The name of the event is always added as the event.name dimension.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
 // Config defines the configuration options for spanmetricsprocessor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -69,6 +91,13 @@ type Config struct {
 
 	AggregationTemporality string `mapstructure:"aggregation_temporality"`
 
+	// ExponentialHistogram, if set, makes the latency to be recorded as an exponential histogram
+	// instead of an explicit bucket histogram. It can't be used together with LatencyHistogramBuckets.
+	ExponentialHistogram *ExponentialHistogramConfig `mapstructure:"exponential_histogram"`
+
+	// Events defines the counting of span events.
+	Events EventsConfig `mapstructure:"events"`
+
 	// skipSanitizeLabel if enabled, labels that start with _ are not sanitized
 	skipSanitizeLabel bool
 }
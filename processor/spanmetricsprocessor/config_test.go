@@ -44,6 +44,8 @@ func TestLoadConfig(t *testing.T) {
 		wantDimensions              []Dimension
 		wantDimensionsCacheSize     int
 		wantAggregationTemporality  string
+		wantExponentialHistogram    *ExponentialHistogramConfig
+		wantEvents                  EventsConfig
 	}{
 		{
 			configFile:                 "config-2-pipelines.yaml",
@@ -76,6 +78,18 @@ func TestLoadConfig(t *testing.T) {
 			wantDimensionsCacheSize:    1500,
 			wantAggregationTemporality: delta,
 		},
+		{
+			configFile:                 "config-exponential-histogram-events.yaml",
+			wantMetricsExporter:        "otlp/spanmetrics",
+			wantAggregationTemporality: cumulative,
+			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
+			wantExponentialHistogram:   &ExponentialHistogramConfig{MaxSize: 80},
+			wantEvents: EventsConfig{
+				Enabled:    true,
+				Names:      []string{"exception"},
+				Dimensions: []Dimension{{Name: "exception.type"}},
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.configFile, func(t *testing.T) {
@@ -107,6 +121,8 @@ func TestLoadConfig(t *testing.T) {
 					Dimensions:              tc.wantDimensions,
 					DimensionsCacheSize:     tc.wantDimensionsCacheSize,
 					AggregationTemporality:  tc.wantAggregationTemporality,
+					ExponentialHistogram:    tc.wantExponentialHistogram,
+					Events:                  tc.wantEvents,
 				},
 				cfg.Processors[component.NewID(typeStr)],
 			)
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// maxExponentialScale is the scale new exponential histograms start with,
+	// before being reduced to fit the recorded values.
+	maxExponentialScale = 20
+	// minExponentialScale is the lowest scale, at which the buckets of all
+	// the float64 values fit in a couple of thousands of buckets.
+	minExponentialScale = -10
+)
+
+// exponentialHistogram records positive values into base-2 exponential
+// buckets, reducing its scale whenever the values don't fit in maxSize buckets.
+type exponentialHistogram struct {
+	maxSize   int32
+	scale     int32
+	zeroCount uint64
+	// offset is the index of the first of the bucket counts.
+	offset       int32
+	bucketCounts []uint64
+}
+
+func newExponentialHistogram(maxSize int32) *exponentialHistogram {
+	return &exponentialHistogram{
+		maxSize: maxSize,
+		scale:   maxExponentialScale,
+	}
+}
+
+// record adds the value to the histogram. Values lower or equal to zero are
+// counted in the zero bucket.
+func (h *exponentialHistogram) record(value float64) {
+	if value <= 0 || math.IsNaN(value) {
+		h.zeroCount++
+		return
+	}
+
+	index := mapToIndex(value, h.scale)
+	if len(h.bucketCounts) == 0 {
+		h.offset = index
+		h.bucketCounts = []uint64{1}
+		return
+	}
+
+	low, high := h.offset, h.offset+int32(len(h.bucketCounts))-1
+	if index < low {
+		low = index
+	}
+	if index > high {
+		high = index
+	}
+	var change int32
+	for h.scale-change > minExponentialScale && (high>>change)-(low>>change)+1 > h.maxSize {
+		change++
+	}
+	if change > 0 {
+		h.downscale(change)
+		index = mapToIndex(value, h.scale)
+	}
+
+	switch last := h.offset + int32(len(h.bucketCounts)) - 1; {
+	case index < h.offset:
+		counts := make([]uint64, int(last-index)+1)
+		copy(counts[h.offset-index:], h.bucketCounts)
+		h.bucketCounts = counts
+		h.offset = index
+	case index > last:
+		counts := make([]uint64, int(index-h.offset)+1)
+		copy(counts, h.bucketCounts)
+		h.bucketCounts = counts
+	}
+	h.bucketCounts[index-h.offset]++
+}
+
+// downscale reduces the scale of the histogram by change, merging each group
+// of 2^change consecutive buckets into one.
+func (h *exponentialHistogram) downscale(change int32) {
+	h.scale -= change
+	if len(h.bucketCounts) == 0 {
+		return
+	}
+
+	offset := h.offset >> change
+	last := (h.offset + int32(len(h.bucketCounts)) - 1) >> change
+	counts := make([]uint64, int(last-offset)+1)
+	for i, count := range h.bucketCounts {
+		counts[((h.offset+int32(i))>>change)-offset] += count
+	}
+	h.offset = offset
+	h.bucketCounts = counts
+}
+
+// copyTo writes the buckets of the histogram to the data point.
+func (h *exponentialHistogram) copyTo(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetScale(h.scale)
+	dp.SetZeroCount(h.zeroCount)
+	dp.Positive().SetOffset(h.offset)
+	dp.Positive().BucketCounts().FromRaw(h.bucketCounts)
+}
+
+// mapToIndex returns the index of the bucket the positive value falls in at
+// the given scale. Bucket i holds the values in (base^i, base^(i+1)], with
+// base = 2^(2^-scale).
+func mapToIndex(value float64, scale int32) int32 {
+	return int32(math.Ceil(math.Log2(value)*math.Ldexp(1, int(scale)))) - 1
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMapToIndex(t *testing.T) {
+	for _, tc := range []struct {
+		value    float64
+		scale    int32
+		expected int32
+	}{
+		{value: 1, scale: 0, expected: -1},
+		{value: 1.5, scale: 0, expected: 0},
+		{value: 2, scale: 0, expected: 0},
+		{value: 3, scale: 0, expected: 1},
+		{value: 4, scale: 0, expected: 1},
+		{value: 4, scale: 1, expected: 3},
+		{value: 5, scale: 1, expected: 4},
+		{value: 16, scale: -1, expected: 1},
+		{value: 17, scale: -1, expected: 2},
+		{value: 0.5, scale: 0, expected: -2},
+	} {
+		assert.Equal(t, tc.expected, mapToIndex(tc.value, tc.scale), "value %v at scale %d", tc.value, tc.scale)
+	}
+}
+
+func TestExponentialHistogramRecord(t *testing.T) {
+	h := newExponentialHistogram(4)
+	h.record(0)
+	h.record(-1)
+	h.record(1)
+	assert.Equal(t, uint64(2), h.zeroCount)
+	assert.Equal(t, int32(maxExponentialScale), h.scale)
+	assert.Equal(t, []uint64{1}, h.bucketCounts)
+
+	// Values spanning several powers of two force the histogram to downscale.
+	for _, v := range []float64{2, 3, 4, 8, 100} {
+		h.record(v)
+	}
+	assert.LessOrEqual(t, len(h.bucketCounts), 4)
+	assert.Less(t, h.scale, int32(maxExponentialScale))
+
+	var total uint64
+	for _, c := range h.bucketCounts {
+		total += c
+	}
+	assert.Equal(t, uint64(6), total)
+	for _, v := range []float64{1, 2, 3, 4, 8, 100} {
+		index := mapToIndex(v, h.scale)
+		assert.GreaterOrEqual(t, index, h.offset)
+		assert.Less(t, index, h.offset+int32(len(h.bucketCounts)))
+	}
+}
+
+func TestExponentialHistogramRecordLowerValues(t *testing.T) {
+	h := newExponentialHistogram(160)
+	h.record(1000)
+	h.record(0.001)
+	h.record(math.MaxFloat64)
+	assert.LessOrEqual(t, len(h.bucketCounts), 160)
+	assert.Equal(t, mapToIndex(0.001, h.scale), h.offset)
+	assert.Equal(t, uint64(1), h.bucketCounts[0])
+	assert.Equal(t, uint64(1), h.bucketCounts[len(h.bucketCounts)-1])
+}
+
+func TestExponentialHistogramDownscale(t *testing.T) {
+	h := &exponentialHistogram{maxSize: 10, scale: 2, offset: -3, bucketCounts: []uint64{1, 2, 3, 4, 5}}
+	h.downscale(1)
+	assert.Equal(t, int32(1), h.scale)
+	assert.Equal(t, int32(-2), h.offset)
+	assert.Equal(t, []uint64{1, 5, 9}, h.bucketCounts)
+}
+
+func TestExponentialHistogramCopyTo(t *testing.T) {
+	h := newExponentialHistogram(160)
+	h.record(0)
+	h.record(10)
+	h.record(10)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.copyTo(dp)
+	assert.Equal(t, int32(maxExponentialScale), dp.Scale())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, mapToIndex(10, maxExponentialScale), dp.Positive().Offset())
+	require.Equal(t, 1, dp.Positive().BucketCounts().Len())
+	assert.Equal(t, uint64(2), dp.Positive().BucketCounts().At(0))
+}
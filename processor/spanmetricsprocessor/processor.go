@@ -42,15 +42,20 @@ const (
 	operationKey       = "operation"   // OpenTelemetry non-standard constant.
 	spanKindKey        = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
+	eventNameKey       = "event.name"  // OpenTelemetry non-standard constant.
 	metricKeySeparator = string(byte(0))
 
-	defaultDimensionsCacheSize = 1000
+	defaultDimensionsCacheSize         = 1000
+	defaultExponentialHistogramMaxSize = 160
 )
 
 var (
 	defaultLatencyHistogramBucketsMs = []float64{
 		2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 	}
+
+	// emptyAttributes is used to look up dimensions that only come from a single set of attributes.
+	emptyAttributes = pcommon.NewMap()
 )
 
 type exemplarData struct {
@@ -78,6 +83,14 @@ type processorImp struct {
 	// Histogram.
 	histograms    map[metricKey]*histogramData
 	latencyBounds []float64
+	// The maximum number of buckets of the exponential histograms, or 0 to
+	// use explicit bucket histograms.
+	expHistogramMaxSize int32
+
+	// Span events counters.
+	events          map[metricKey]*eventData
+	eventNames      map[string]struct{}
+	eventDimensions []dimension
 
 	keyBuf *bytes.Buffer
 
@@ -110,9 +123,15 @@ type histogramData struct {
 	count         uint64
 	sum           float64
 	bucketCounts  []uint64
+	expHistogram  *exponentialHistogram
 	exemplarsData []exemplarData
 }
 
+type eventData struct {
+	count      uint64
+	dimensions pcommon.Map
+}
+
 func newProcessor(logger *zap.Logger, config component.Config, nextConsumer consumer.Traces) (*processorImp, error) {
 	logger.Info("Building spanmetricsprocessor")
 	pConfig := config.(*Config)
@@ -126,6 +145,35 @@ func newProcessor(logger *zap.Logger, config component.Config, nextConsumer cons
 		return nil, err
 	}
 
+	var expHistogramMaxSize int32
+	if pConfig.ExponentialHistogram != nil {
+		if pConfig.LatencyHistogramBuckets != nil {
+			return nil, fmt.Errorf("latency_histogram_buckets can't be used with exponential_histogram")
+		}
+		expHistogramMaxSize = pConfig.ExponentialHistogram.MaxSize
+		if expHistogramMaxSize == 0 {
+			expHistogramMaxSize = defaultExponentialHistogramMaxSize
+		}
+		if expHistogramMaxSize < 0 {
+			return nil, fmt.Errorf("invalid exponential histogram max size: %v, it should be positive", expHistogramMaxSize)
+		}
+	}
+
+	var eventNames map[string]struct{}
+	if pConfig.Events.Enabled {
+		// The event dimensions are added to the ones of the span, so they all need to be unique.
+		eventDims := append([]Dimension{{Name: eventNameKey}}, pConfig.Dimensions...)
+		if err := validateDimensions(append(eventDims, pConfig.Events.Dimensions...), pConfig.skipSanitizeLabel); err != nil {
+			return nil, err
+		}
+		if len(pConfig.Events.Names) > 0 {
+			eventNames = make(map[string]struct{}, len(pConfig.Events.Names))
+			for _, name := range pConfig.Events.Names {
+				eventNames[name] = struct{}{}
+			}
+		}
+	}
+
 	if pConfig.DimensionsCacheSize <= 0 {
 		return nil, fmt.Errorf(
 			"invalid cache size: %v, the maximum number of the items in the cache should be positive",
@@ -142,7 +190,11 @@ func newProcessor(logger *zap.Logger, config component.Config, nextConsumer cons
 		config:                *pConfig,
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		latencyBounds:         bounds,
+		expHistogramMaxSize:   expHistogramMaxSize,
 		histograms:            make(map[metricKey]*histogramData),
+		events:                make(map[metricKey]*eventData),
+		eventNames:            eventNames,
+		eventDimensions:       newDimensions(pConfig.Events.Dimensions),
 		nextConsumer:          nextConsumer,
 		dimensions:            newDimensions(pConfig.Dimensions),
 		keyBuf:                bytes.NewBuffer(make([]byte, 0, 1024)),
@@ -285,6 +337,10 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 		return pmetric.Metrics{}, err
 	}
 
+	if p.config.Events.Enabled {
+		p.collectEventMetrics(ilm)
+	}
+
 	p.metricKeyToDimensions.RemoveEvictedItems()
 
 	// If delta metrics, reset accumulated data
@@ -299,6 +355,10 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 // collectLatencyMetrics collects the raw latency metrics, writing the data
 // into the given instrumentation library metrics.
 func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	if p.expHistogramMaxSize > 0 {
+		return p.collectExponentialLatencyMetrics(ilm)
+	}
+
 	mLatency := ilm.Metrics().AppendEmpty()
 	mLatency.SetName("latency")
 	mLatency.SetUnit("ms")
@@ -327,6 +387,56 @@ func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
+// collectExponentialLatencyMetrics collects the raw latency metrics as
+// exponential histograms, writing the data into the given instrumentation
+// library metrics.
+func (p *processorImp) collectExponentialLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	mLatency := ilm.Metrics().AppendEmpty()
+	mLatency.SetName("latency")
+	mLatency.SetUnit("ms")
+	mLatency.SetEmptyExponentialHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
+	dps := mLatency.ExponentialHistogram().DataPoints()
+	dps.EnsureCapacity(len(p.histograms))
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	for key, hist := range p.histograms {
+		dpLatency := dps.AppendEmpty()
+		dpLatency.SetStartTimestamp(p.startTimestamp)
+		dpLatency.SetTimestamp(timestamp)
+		hist.expHistogram.copyTo(dpLatency)
+		dpLatency.SetCount(hist.count)
+		dpLatency.SetSum(hist.sum)
+		setExemplars(hist.exemplarsData, timestamp, dpLatency.Exemplars())
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			p.logger.Error(err.Error())
+			return err
+		}
+
+		dimensions.CopyTo(dpLatency.Attributes())
+	}
+	return nil
+}
+
+// collectEventMetrics collects the raw span event count metrics, writing the
+// data into the given instrumentation library metrics.
+func (p *processorImp) collectEventMetrics(ilm pmetric.ScopeMetrics) {
+	mEvents := ilm.Metrics().AppendEmpty()
+	mEvents.SetName("events_total")
+	mEvents.SetEmptySum().SetIsMonotonic(true)
+	mEvents.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
+	dps := mEvents.Sum().DataPoints()
+	dps.EnsureCapacity(len(p.events))
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	for _, event := range p.events {
+		dpEvents := dps.AppendEmpty()
+		dpEvents.SetStartTimestamp(p.startTimestamp)
+		dpEvents.SetTimestamp(timestamp)
+		dpEvents.SetIntValue(int64(event.count))
+		event.dimensions.CopyTo(dpEvents.Attributes())
+	}
+}
+
 // collectCallMetrics collects the raw call count metrics, writing the data
 // into the given instrumentation library metrics.
 func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
@@ -393,15 +503,57 @@ func (p *processorImp) aggregateMetrics(traces ptrace.Traces) {
 				key := metricKey(p.keyBuf.String())
 				p.cache(serviceName, span, key, resourceAttr)
 				p.updateHistogram(key, latencyInMilliseconds, span.TraceID(), span.SpanID())
+				if p.config.Events.Enabled {
+					p.aggregateEvents(serviceName, span, resourceAttr)
+				}
 			}
 		}
 	}
 }
 
+// aggregateEvents counts the events of the span. It must be called right after
+// building the metric key of the span, which is the prefix of the event keys.
+func (p *processorImp) aggregateEvents(serviceName string, span ptrace.Span, resourceAttr pcommon.Map) {
+	spanKeyLen := p.keyBuf.Len()
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if p.eventNames != nil {
+			if _, ok := p.eventNames[event.Name()]; !ok {
+				continue
+			}
+		}
+
+		p.keyBuf.Truncate(spanKeyLen)
+		concatDimensionValue(p.keyBuf, event.Name(), true)
+		for _, d := range p.eventDimensions {
+			if v, ok := getDimensionValue(d, event.Attributes(), emptyAttributes); ok {
+				concatDimensionValue(p.keyBuf, v.AsString(), true)
+			}
+		}
+		key := metricKey(p.keyBuf.String())
+
+		data, ok := p.events[key]
+		if !ok {
+			dims := p.buildDimensionKVs(serviceName, span, resourceAttr)
+			dims.PutStr(eventNameKey, event.Name())
+			for _, d := range p.eventDimensions {
+				if v, ok := getDimensionValue(d, event.Attributes(), emptyAttributes); ok {
+					v.CopyTo(dims.PutEmpty(d.name))
+				}
+			}
+			data = &eventData{dimensions: dims}
+			p.events[key] = data
+		}
+		data.count++
+	}
+}
+
 // resetAccumulatedMetrics resets the internal maps used to store created metric data. Also purge the cache for
 // metricKeyToDimensions.
 func (p *processorImp) resetAccumulatedMetrics() {
 	p.histograms = make(map[metricKey]*histogramData)
+	p.events = make(map[metricKey]*eventData)
 	p.metricKeyToDimensions.Purge()
 }
 
@@ -409,17 +561,24 @@ func (p *processorImp) resetAccumulatedMetrics() {
 func (p *processorImp) updateHistogram(key metricKey, latency float64, traceID pcommon.TraceID, spanID pcommon.SpanID) {
 	histo, ok := p.histograms[key]
 	if !ok {
-		histo = &histogramData{
-			bucketCounts: make([]uint64, len(p.latencyBounds)+1),
+		histo = &histogramData{}
+		if p.expHistogramMaxSize > 0 {
+			histo.expHistogram = newExponentialHistogram(p.expHistogramMaxSize)
+		} else {
+			histo.bucketCounts = make([]uint64, len(p.latencyBounds)+1)
 		}
 		p.histograms[key] = histo
 	}
 
 	histo.sum += latency
 	histo.count++
-	// Binary search to find the latencyInMilliseconds bucket index.
-	index := sort.SearchFloat64s(p.latencyBounds, latency)
-	histo.bucketCounts[index]++
+	if histo.expHistogram != nil {
+		histo.expHistogram.record(latency)
+	} else {
+		// Binary search to find the latencyInMilliseconds bucket index.
+		index := sort.SearchFloat64s(p.latencyBounds, latency)
+		histo.bucketCounts[index]++
+	}
 	histo.exemplarsData = append(histo.exemplarsData, exemplarData{traceID: traceID, spanID: spanID, value: latency})
 }
 
@@ -880,3 +880,152 @@ func TestProcessorUpdateExemplars(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, p.histograms[key].exemplarsData)
 }
+
+func TestSetExemplarsKeepsEmptyTraceID(t *testing.T) {
+	exemplarSlice := pmetric.NewExemplarSlice()
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	ed := []exemplarData{
+		{traceID: pcommon.NewTraceIDEmpty(), spanID: pcommon.NewSpanIDEmpty(), value: 1},
+		{traceID: pcommon.TraceID([16]byte{1}), spanID: pcommon.SpanID([8]byte{1}), value: 2},
+	}
+
+	setExemplars(ed, timestamp, exemplarSlice)
+
+	// exemplars without a trace ID are kept as empty placeholders
+	require.Equal(t, 2, exemplarSlice.Len())
+	empty := exemplarSlice.At(0)
+	assert.True(t, empty.TraceID().IsEmpty())
+	assert.True(t, empty.SpanID().IsEmpty())
+	assert.Equal(t, pcommon.Timestamp(0), empty.Timestamp())
+	assert.Equal(t, float64(0), empty.DoubleValue())
+
+	exemplar := exemplarSlice.At(1)
+	assert.Equal(t, pcommon.TraceID([16]byte{1}), exemplar.TraceID())
+	assert.Equal(t, pcommon.SpanID([8]byte{1}), exemplar.SpanID())
+	assert.Equal(t, timestamp, exemplar.Timestamp())
+	assert.Equal(t, float64(2), exemplar.DoubleValue())
+}
+
+func TestProcessorExponentialHistogramErrors(t *testing.T) {
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.LatencyHistogramBuckets = []time.Duration{time.Millisecond}
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+	_, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	assert.EqualError(t, err, "latency_histogram_buckets can't be used with exponential_histogram")
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: -1}
+	_, err = newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	assert.EqualError(t, err, "invalid exponential histogram max size: -1, it should be positive")
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+	assert.Equal(t, int32(defaultExponentialHistogramMaxSize), p.expHistogramMaxSize)
+}
+
+func TestProcessorExponentialHistogram(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 10}
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+
+	// Test
+	p.aggregateMetrics(buildSampleTrace())
+	md, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	// Verify
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	latency := metrics.At(1)
+	assert.Equal(t, "latency", latency.Name())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, latency.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, latency.ExponentialHistogram().AggregationTemporality())
+
+	dps := latency.ExponentialHistogram().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		assert.Equal(t, sampleLatency*float64(dp.Count()), dp.Sum())
+		assert.Equal(t, int32(maxExponentialScale), dp.Scale())
+		assert.Equal(t, mapToIndex(sampleLatency, maxExponentialScale), dp.Positive().Offset())
+		assert.Equal(t, []uint64{dp.Count()}, dp.Positive().BucketCounts().AsRaw())
+		assert.Equal(t, int(dp.Count()), dp.Exemplars().Len())
+		assert.NotEmpty(t, dp.Attributes().AsRaw())
+	}
+}
+
+func TestProcessorEvents(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.AggregationTemporality = delta
+	cfg.Events = EventsConfig{
+		Enabled:    true,
+		Names:      []string{"exception"},
+		Dimensions: []Dimension{{Name: "exception.type"}},
+	}
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, "service-a")
+	s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	s.SetName("operation")
+	for _, exceptionType := range []string{"Timeout", "Timeout", "Canceled"} {
+		event := s.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutStr("exception.type", exceptionType)
+	}
+	s.Events().AppendEmpty().SetName("retry")
+
+	// Test
+	p.aggregateMetrics(traces)
+	md, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	// Verify
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+	events := metrics.At(2)
+	assert.Equal(t, "events_total", events.Name())
+	assert.True(t, events.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, events.Sum().AggregationTemporality())
+
+	counts := map[string]int64{}
+	dps := events.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		attrs := dp.Attributes().AsRaw()
+		assert.Equal(t, "service-a", attrs[serviceNameKey])
+		assert.Equal(t, "operation", attrs[operationKey])
+		assert.Equal(t, "exception", attrs[eventNameKey])
+		counts[attrs["exception.type"].(string)] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"Timeout": 2, "Canceled": 1}, counts)
+
+	// Delta counters are reset after each export.
+	md, err = p.buildMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2).Sum().DataPoints().Len())
+}
+
+func TestProcessorEventsDuplicateDimensions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Events = EventsConfig{
+		Enabled:    true,
+		Dimensions: []Dimension{{Name: eventNameKey}},
+	}
+
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, new(consumertest.TracesSink))
+	assert.Error(t, err)
+	assert.Nil(t, p)
+}
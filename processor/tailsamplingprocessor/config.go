@@ -48,6 +48,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// OTTLCondition sample traces that have a span or span event matching any of the given OTTL conditions.
+	OTTLCondition PolicyType = "ottl_condition"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -73,6 +75,8 @@ type sharedPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for OTTL condition filter sampling policy evaluator.
+	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -194,6 +198,15 @@ type SpanCountCfg struct {
 	MinSpans int32 `mapstructure:"min_spans"`
 }
 
+// OTTLConditionCfg holds the configurable settings to create an OTTL condition filter
+// sampling policy evaluator.
+type OTTLConditionCfg struct {
+	// SpanConditions is a list of OTTL conditions evaluated in the ottlspan context.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions is a list of OTTL conditions evaluated in the ottlspanevent context.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+type ottlConditionFilter struct {
+	sampleSpanExpr      []*ottl.Statement[ottlspan.TransformContext]
+	sampleSpanEventExpr []*ottl.Statement[ottlspanevent.TransformContext]
+	logger              *zap.Logger
+}
+
+var _ PolicyEvaluator = (*ottlConditionFilter)(nil)
+
+// NewOTTLConditionFilter creates a policy evaluator that samples all traces with
+// at least one span or span event matching any of the given OTTL conditions.
+func NewOTTLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string) (PolicyEvaluator, error) {
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+		return nil, errors.New("at least one span or span event condition is required")
+	}
+
+	set := component.TelemetrySettings{Logger: logger}
+	filter := &ottlConditionFilter{logger: logger}
+
+	var err error
+	if len(spanConditions) > 0 {
+		parser := ottlspan.NewParser(ottlFunctions[ottlspan.TransformContext](), set)
+		if filter.sampleSpanExpr, err = parser.ParseStatements(conditionsToStatements(spanConditions)); err != nil {
+			return nil, err
+		}
+	}
+	if len(spanEventConditions) > 0 {
+		parser := ottlspanevent.NewParser(ottlFunctions[ottlspanevent.TransformContext](), set)
+		if filter.sampleSpanEventExpr, err = parser.ParseStatements(conditionsToStatements(spanEventConditions)); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (ocf *ottlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	ctx := context.Background()
+	for i := 0; i < batches.ResourceSpans().Len(); i++ {
+		rs := batches.ResourceSpans().At(i)
+		resource := rs.Resource()
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scope := ss.Scope()
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+
+				if len(ocf.sampleSpanExpr) > 0 {
+					matched, err := anyStatementMatches(ctx, ocf.sampleSpanExpr, ottlspan.NewTransformContext(span, scope, resource))
+					if err != nil {
+						return Error, err
+					}
+					if matched {
+						return Sampled, nil
+					}
+				}
+
+				for l := 0; l < span.Events().Len() && len(ocf.sampleSpanEventExpr) > 0; l++ {
+					tCtx := ottlspanevent.NewTransformContext(span.Events().At(l), span, scope, resource)
+					matched, err := anyStatementMatches(ctx, ocf.sampleSpanEventExpr, tCtx)
+					if err != nil {
+						return Error, err
+					}
+					if matched {
+						return Sampled, nil
+					}
+				}
+			}
+		}
+	}
+	return NotSampled, nil
+}
+
+func anyStatementMatches[K any](ctx context.Context, statements []*ottl.Statement[K], tCtx K) (bool, error) {
+	for _, statement := range statements {
+		_, matched, err := statement.Execute(ctx, tCtx)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// conditionsToStatements wraps each condition in a statement invoking the
+// no-op sample function, so that it can be parsed by the OTTL parser.
+func conditionsToStatements(conditions []string) []string {
+	statements := make([]string, len(conditions))
+	for i, condition := range conditions {
+		statements[i] = "sample() where " + condition
+	}
+	return statements
+}
+
+func ottlFunctions[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		"TraceID":     ottlfuncs.TraceID[K],
+		"SpanID":      ottlfuncs.SpanID[K],
+		"IsMatch":     ottlfuncs.IsMatch[K],
+		"Concat":      ottlfuncs.Concat[K],
+		"Split":       ottlfuncs.Split[K],
+		"Int":         ottlfuncs.Int[K],
+		"ConvertCase": ottlfuncs.ConvertCase[K],
+		"sample": func() (ottl.ExprFunc[K], error) {
+			return func(context.Context, K) (interface{}, error) {
+				return true, nil
+			}, nil
+		},
+	}
+}
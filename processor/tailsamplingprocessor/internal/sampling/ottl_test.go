@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestOTTLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Spans               []spanWithAttributes
+		Decision            Decision
+	}{
+		{
+			Desc:           "OTTL conditions not matched",
+			SpanConditions: []string{"attributes[\"attr_k_1\"] == \"attr_v_1\""},
+			Spans:          []spanWithAttributes{{SpanAttributes: map[string]string{"attr_k_1": "attr_v_2"}}},
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "OTTL span condition matched",
+			SpanConditions: []string{"attributes[\"attr_k_1\"] == \"attr_v_1\""},
+			Spans:          []spanWithAttributes{{SpanAttributes: map[string]string{"attr_k_1": "attr_v_1"}}},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "OTTL span condition matched on second span",
+			SpanConditions: []string{"attributes[\"attr_k_1\"] == \"attr_v_1\""},
+			Spans: []spanWithAttributes{
+				{SpanAttributes: map[string]string{"attr_k_1": "attr_v_2"}},
+				{SpanAttributes: map[string]string{"attr_k_1": "attr_v_1"}},
+			},
+			Decision: Sampled,
+		},
+		{
+			Desc:           "OTTL span condition matched on any of multiple conditions",
+			SpanConditions: []string{"name == \"unknown\"", "attributes[\"attr_k_1\"] == \"attr_v_1\""},
+			Spans:          []spanWithAttributes{{SpanAttributes: map[string]string{"attr_k_1": "attr_v_1"}}},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "OTTL span condition on status and duration",
+			SpanConditions: []string{"status.code == STATUS_CODE_ERROR and end_time_unix_nano - start_time_unix_nano > 1000"},
+			Spans:          []spanWithAttributes{{SpanAttributes: map[string]string{}, Error: true}},
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "OTTL span event condition matched",
+			SpanEventConditions: []string{"name == \"exception\""},
+			Spans:               []spanWithAttributes{{SpanAttributes: map[string]string{}, EventName: "exception"}},
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "OTTL span event condition not matched",
+			SpanEventConditions: []string{"name == \"exception\""},
+			Spans:               []spanWithAttributes{{SpanAttributes: map[string]string{}, EventName: "retry"}},
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "OTTL span event condition on event attributes",
+			SpanConditions:      []string{"name == \"unknown\""},
+			SpanEventConditions: []string{"attributes[\"exception.type\"] == \"Timeout\""},
+			Spans: []spanWithAttributes{{
+				SpanAttributes:  map[string]string{},
+				EventName:       "exception",
+				EventAttributes: map[string]string{"exception.type": "Timeout"},
+			}},
+			Decision: Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewOTTLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions)
+			require.NoError(t, err)
+
+			u, err := uuid.NewRandom()
+			require.NoError(t, err)
+			decision, err := filter.Evaluate(pcommon.TraceID(u), newTraceWithSpanAttributes(c.Spans))
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestOTTLConditionFilterErrors(t *testing.T) {
+	_, err := NewOTTLConditionFilter(zap.NewNop(), nil, nil)
+	assert.EqualError(t, err, "at least one span or span event condition is required")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{"attributes[\"attr_k_1\"] =="}, nil)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), nil, []string{"unknown_path == 1"})
+	assert.Error(t, err)
+}
+
+type spanWithAttributes struct {
+	SpanAttributes  map[string]string
+	Error           bool
+	EventName       string
+	EventAttributes map[string]string
+}
+
+func newTraceWithSpanAttributes(spans []spanWithAttributes) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	ils := rs.ScopeSpans().AppendEmpty()
+
+	for _, s := range spans {
+		span := ils.Spans().AppendEmpty()
+		span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+		span.SetStartTimestamp(pcommon.Timestamp(1000))
+		span.SetEndTimestamp(pcommon.Timestamp(5000))
+		for k, v := range s.SpanAttributes {
+			span.Attributes().PutStr(k, v)
+		}
+		if s.Error {
+			span.Status().SetCode(ptrace.StatusCodeError)
+		}
+		if s.EventName != "" {
+			event := span.Events().AppendEmpty()
+			event.SetName(s.EventName)
+			for k, v := range s.EventAttributes {
+				event.Attributes().PutStr(k, v)
+			}
+		}
+	}
+
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
@@ -155,6 +155,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case OTTLCondition:
+		ocfCfg := cfg.OTTLConditionCfg
+		return sampling.NewOTTLConditionFilter(logger, ocfCfg.SpanConditions, ocfCfg.SpanEventConditions)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -30,6 +30,14 @@ func Functions[K any]() map[string]interface{} {
 		"Int":                  ottlfuncs.Int[K],
 		"ConvertCase":          ottlfuncs.ConvertCase[K],
 		"ParseJSON":            ottlfuncs.ParseJSON[K],
+		"Double":               ottlfuncs.Double[K],
+		"String":               ottlfuncs.String[K],
+		"Time":                 ottlfuncs.Time[K],
+		"UnixNano":             ottlfuncs.UnixNano[K],
+		"SHA256":               ottlfuncs.SHA256[K],
+		"FNV":                  ottlfuncs.FNV[K],
+		"ParseKeyValue":        ottlfuncs.ParseKeyValue[K],
+		"ExtractPatterns":      ottlfuncs.ExtractPatterns[K],
 		"keep_keys":            ottlfuncs.KeepKeys[K],
 		"set":                  ottlfuncs.Set[K],
 		"truncate_all":         ottlfuncs.TruncateAll[K],
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxBodySize is the number of bytes of a response body that are read to check
+// the body assertions. Anything beyond it is ignored, so a large body can fail
+// the body_regex or json assertions.
+const maxBodySize = 1 << 20
+
+// assertions checks responses against an AssertionsConfig.
+type assertions struct {
+	statusCodes []int
+	bodyRegex   *regexp.Regexp
+	json        []jsonAssertion
+}
+
+// assertionFailure is an assertion that a response failed.
+type assertionFailure struct {
+	// assertion identifies the failed assertion. It only depends on the
+	// configuration, so it is bounded and can be recorded as an attribute.
+	assertion string
+	// detail describes why the response failed the assertion.
+	detail string
+}
+
+type jsonAssertion struct {
+	JSONAssertion
+	path []interface{}
+}
+
+func newAssertions(cfg AssertionsConfig) (*assertions, error) {
+	a := &assertions{statusCodes: cfg.StatusCodes}
+
+	if cfg.BodyRegex != "" {
+		bodyRegex, err := regexp.Compile(cfg.BodyRegex)
+		if err != nil {
+			return nil, err
+		}
+		a.bodyRegex = bodyRegex
+	}
+
+	for _, assertion := range cfg.JSON {
+		path, err := parseJSONPath(assertion.Path)
+		if err != nil {
+			return nil, err
+		}
+		a.json = append(a.json, jsonAssertion{JSONAssertion: assertion, path: path})
+	}
+
+	return a, nil
+}
+
+// needsBody returns whether the response body is needed to check the assertions.
+func (a *assertions) needsBody() bool {
+	return a.bodyRegex != nil || len(a.json) > 0
+}
+
+// check returns each assertion the response failed.
+func (a *assertions) check(resp *http.Response) []assertionFailure {
+	var failures []assertionFailure
+
+	if len(a.statusCodes) > 0 && !containsStatusCode(a.statusCodes, resp.StatusCode) {
+		failures = append(failures, assertionFailure{
+			assertion: "status_codes",
+			detail:    fmt.Sprintf("status code %d is not one of %v", resp.StatusCode, a.statusCodes),
+		})
+	}
+
+	if !a.needsBody() {
+		return failures
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return append(failures, assertionFailure{
+			assertion: "body",
+			detail:    fmt.Sprintf("failed to read response body: %s", err),
+		})
+	}
+
+	if a.bodyRegex != nil && !a.bodyRegex.Match(body) {
+		failures = append(failures, assertionFailure{
+			assertion: "body_regex",
+			detail:    fmt.Sprintf("body does not match regex %q", a.bodyRegex),
+		})
+	}
+
+	if len(a.json) == 0 {
+		return failures
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return append(failures, assertionFailure{
+			assertion: "json",
+			detail:    fmt.Sprintf("failed to parse body as JSON: %s", err),
+		})
+	}
+
+	for _, assertion := range a.json {
+		value, ok := lookupJSONPath(doc, assertion.path)
+		switch {
+		case !ok:
+			failures = append(failures, assertionFailure{
+				assertion: "json " + assertion.Path,
+				detail:    fmt.Sprintf("JSON path %q not found", assertion.Path),
+			})
+		case assertion.Value != "" && formatJSONValue(value) != assertion.Value:
+			failures = append(failures, assertionFailure{
+				assertion: "json " + assertion.Path,
+				detail:    fmt.Sprintf("JSON path %q is %q, expected %q", assertion.Path, formatJSONValue(value), assertion.Value),
+			})
+		}
+	}
+
+	return failures
+}
+
+func containsStatusCode(statusCodes []int, statusCode int) bool {
+	for _, code := range statusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJSONPath parses a path such as `$.data.items[0].name` into its object
+// keys (strings) and array indices (ints). The leading `$` is optional.
+func parseJSONPath(path string) ([]interface{}, error) {
+	p := strings.TrimPrefix(path, "$")
+	if p != "" && p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+
+	var segments []interface{}
+	for p != "" {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end == -1 {
+				end = len(p)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in path %q", path)
+			}
+			segments = append(segments, p[:end])
+			p = p[end:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated index in path %q", path)
+			}
+			index, err := strconv.Atoi(p[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q in path %q", p[1:end], path)
+			}
+			segments = append(segments, index)
+			p = p[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected character %q in path %q", p[0], path)
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, errors.New("path is empty")
+	}
+	return segments, nil
+}
+
+// lookupJSONPath returns the value at path in a decoded JSON document.
+func lookupJSONPath(doc interface{}, path []interface{}) (interface{}, bool) {
+	value := doc
+	for _, segment := range path {
+		switch segment := segment.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[segment]; !ok {
+				return nil, false
+			}
+		case int:
+			array, ok := value.([]interface{})
+			if !ok || segment >= len(array) {
+				return nil, false
+			}
+			value = array[segment]
+		}
+	}
+	return value, true
+}
+
+// formatJSONValue formats a decoded JSON value for comparison with an expected value.
+func formatJSONValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	case nil:
+		return "null"
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	testCases := []struct {
+		path        string
+		expected    []interface{}
+		expectedErr string
+	}{
+		{path: "status", expected: []interface{}{"status"}},
+		{path: "$.data.items[0].name", expected: []interface{}{"data", "items", 0, "name"}},
+		{path: "[1][2]", expected: []interface{}{1, 2}},
+		{path: "$[0].id", expected: []interface{}{0, "id"}},
+		{path: "", expectedErr: "path is empty"},
+		{path: "a.", expectedErr: `empty key in path "a."`},
+		{path: "a[0", expectedErr: `unterminated index in path "a[0"`},
+		{path: "a[-1]", expectedErr: `invalid index "-1" in path "a[-1]"`},
+		{path: "a[0]b", expectedErr: `unexpected character 'b' in path "a[0]b"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, err := parseJSONPath(tc.path)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestAssertionsCheck(t *testing.T) {
+	body := `{"status":"up","count":3,"ratio":0.5,"ready":true,"error":null,"items":[{"id":"a"}],"meta":{"region":"eu"}}`
+	testCases := []struct {
+		desc       string
+		cfg        AssertionsConfig
+		statusCode int
+		body       string
+		expected   []assertionFailure
+	}{
+		{
+			desc:       "no assertions",
+			statusCode: http.StatusInternalServerError,
+			body:       body,
+		},
+		{
+			desc: "all assertions pass",
+			cfg: AssertionsConfig{
+				StatusCodes: []int{200, 204},
+				BodyRegex:   `"status":\s*"up"`,
+				JSON: []JSONAssertion{
+					{Path: "status", Value: "up"},
+					{Path: "count", Value: "3"},
+					{Path: "ratio", Value: "0.5"},
+					{Path: "ready", Value: "true"},
+					{Path: "error", Value: "null"},
+					{Path: "items[0].id", Value: "a"},
+					{Path: "meta", Value: `{"region":"eu"}`},
+					{Path: "items"},
+				},
+			},
+			statusCode: http.StatusOK,
+			body:       body,
+		},
+		{
+			desc: "all assertions fail",
+			cfg: AssertionsConfig{
+				StatusCodes: []int{204},
+				BodyRegex:   "down",
+				JSON: []JSONAssertion{
+					{Path: "status", Value: "down"},
+					{Path: "items[1]"},
+					{Path: "status.code"},
+				},
+			},
+			statusCode: http.StatusOK,
+			body:       body,
+			expected: []assertionFailure{
+				{assertion: "status_codes", detail: "status code 200 is not one of [204]"},
+				{assertion: "body_regex", detail: `body does not match regex "down"`},
+				{assertion: "json status", detail: `JSON path "status" is "up", expected "down"`},
+				{assertion: "json items[1]", detail: `JSON path "items[1]" not found`},
+				{assertion: "json status.code", detail: `JSON path "status.code" not found`},
+			},
+		},
+		{
+			desc: "body is not JSON",
+			cfg: AssertionsConfig{
+				JSON: []JSONAssertion{{Path: "status"}},
+			},
+			statusCode: http.StatusOK,
+			body:       "OK",
+			expected: []assertionFailure{
+				{assertion: "json", detail: "failed to parse body as JSON: invalid character 'O' looking for beginning of value"},
+			},
+		},
+		{
+			desc: "body is larger than the limit",
+			cfg: AssertionsConfig{
+				BodyRegex: "end$",
+			},
+			statusCode: http.StatusOK,
+			body:       strings.Repeat("a", maxBodySize) + "end",
+			expected: []assertionFailure{
+				{assertion: "body_regex", detail: `body does not match regex "end$"`},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := newAssertions(tc.cfg)
+			require.NoError(t, err)
+			resp := &http.Response{
+				StatusCode: tc.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+			}
+			assert.Equal(t, tc.expected, a.check(resp))
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -28,7 +29,11 @@ import (
 
 // Predefined error responses for configuration validation failures
 var (
-	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errInvalidEndpoint   = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errMissingEndpoint   = errors.New(`"endpoint" must be specified`)
+	errInvalidStatusCode = errors.New(`"status_codes" must be between 100 and 599`)
+	errInvalidBodyRegex  = errors.New(`"body_regex" must be a valid regular expression`)
+	errInvalidJSONPath   = errors.New(`"json" assertions must have a valid path`)
 )
 
 const defaultEndpoint = "http://localhost:80"
@@ -39,17 +44,111 @@ type Config struct {
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
 	Method                                  string                   `mapstructure:"method"`
+	// Body is sent as the body of the request to the endpoint.
+	Body string `mapstructure:"body"`
+	// Assertions are checked against the response of the endpoint.
+	Assertions AssertionsConfig `mapstructure:"assertions"`
+	// Targets is a list of endpoints to check. If set, the endpoint, headers,
+	// body and assertions of the receiver itself are not used, while its method
+	// and timeout are used for targets that do not set their own.
+	Targets []TargetConfig `mapstructure:"targets"`
+}
+
+// TargetConfig defines an endpoint to check and how to check it.
+type TargetConfig struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	Method                        string           `mapstructure:"method"`
+	Body                          string           `mapstructure:"body"`
+	Assertions                    AssertionsConfig `mapstructure:"assertions"`
+}
+
+// AssertionsConfig defines the conditions a response must meet for the check
+// to succeed. Each failed assertion is recorded as an error.
+type AssertionsConfig struct {
+	// StatusCodes are the accepted response status codes. Any status code is
+	// accepted if empty.
+	StatusCodes []int `mapstructure:"status_codes"`
+	// BodyRegex is a regular expression the response body must match.
+	BodyRegex string `mapstructure:"body_regex"`
+	// JSON are assertions on values in the JSON response body.
+	JSON []JSONAssertion `mapstructure:"json"`
+}
+
+// JSONAssertion checks a value in a JSON response body.
+type JSONAssertion struct {
+	// Path of the value, e.g. `status` or `data.items[0].name`.
+	Path string `mapstructure:"path"`
+	// Value is the expected value, compared to the JSON value formatted as a
+	// string. If empty, the value only has to exist.
+	Value string `mapstructure:"value"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
 func (cfg *Config) Validate() error {
 	var err error
 
-	_, parseErr := url.Parse(cfg.Endpoint)
-	if parseErr != nil {
+	if len(cfg.Targets) == 0 {
+		err = multierr.Append(err, validateTarget(cfg.Endpoint, cfg.Assertions))
+	}
+	for i, target := range cfg.Targets {
+		if targetErr := validateTarget(target.Endpoint, target.Assertions); targetErr != nil {
+			err = multierr.Append(err, fmt.Errorf("targets[%d]: %w", i, targetErr))
+		}
+	}
+
+	return err
+}
+
+func validateTarget(endpoint string, assertions AssertionsConfig) error {
+	var err error
+
+	if endpoint == "" {
+		err = multierr.Append(err, errMissingEndpoint)
+	} else if _, parseErr := url.Parse(endpoint); parseErr != nil {
 		wrappedErr := fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), parseErr)
 		err = multierr.Append(err, wrappedErr)
 	}
 
+	for _, statusCode := range assertions.StatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			err = multierr.Append(err, fmt.Errorf("%w: %d", errInvalidStatusCode, statusCode))
+		}
+	}
+
+	if _, regexErr := regexp.Compile(assertions.BodyRegex); regexErr != nil {
+		err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidBodyRegex.Error(), regexErr))
+	}
+
+	for _, jsonAssertion := range assertions.JSON {
+		if _, pathErr := parseJSONPath(jsonAssertion.Path); pathErr != nil {
+			err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidJSONPath.Error(), pathErr))
+		}
+	}
+
 	return err
 }
+
+// targets returns the targets to check, which is only the endpoint of the
+// receiver itself if no targets are configured.
+func (cfg *Config) targets() []TargetConfig {
+	if len(cfg.Targets) == 0 {
+		return []TargetConfig{{
+			HTTPClientSettings: cfg.HTTPClientSettings,
+			Method:             cfg.Method,
+			Body:               cfg.Body,
+			Assertions:         cfg.Assertions,
+		}}
+	}
+
+	targets := make([]TargetConfig, 0, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		if target.Method == "" {
+			target.Method = cfg.Method
+		}
+		if target.Timeout == 0 {
+			target.Timeout = cfg.Timeout
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
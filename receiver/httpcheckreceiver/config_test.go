@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -50,6 +51,69 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			desc: "invalid assertions",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: defaultEndpoint,
+				},
+				Assertions: AssertionsConfig{
+					StatusCodes: []int{200, 700},
+					BodyRegex:   "(",
+					JSON:        []JSONAssertion{{Path: "a..b"}, {Path: "a[x]"}},
+				},
+			},
+			expectedErr: multierr.Combine(
+				fmt.Errorf("%w: %d", errInvalidStatusCode, 700),
+				fmt.Errorf("%s: %w", errInvalidBodyRegex, errors.New("error parsing regexp: missing closing ): `(`")),
+				fmt.Errorf("%s: %w", errInvalidJSONPath, errors.New(`empty key in path "a..b"`)),
+				fmt.Errorf("%s: %w", errInvalidJSONPath, errors.New(`invalid index "x" in path "a[x]"`)),
+			),
+		},
+		{
+			desc: "invalid targets",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "invalid://endpoint:  12efg",
+				},
+				Targets: []TargetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: defaultEndpoint,
+						},
+					},
+					{
+						Assertions: AssertionsConfig{
+							JSON: []JSONAssertion{{Path: "$"}},
+						},
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				fmt.Errorf("targets[1]: %w", multierr.Combine(
+					errMissingEndpoint,
+					fmt.Errorf("%s: %w", errInvalidJSONPath, errors.New("path is empty")),
+				)),
+			),
+		},
+		{
+			desc: "valid targets",
+			cfg: &Config{
+				Targets: []TargetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: defaultEndpoint,
+						},
+						Assertions: AssertionsConfig{
+							StatusCodes: []int{200},
+							BodyRegex:   "ok",
+							JSON:        []JSONAssertion{{Path: "$.items[0].status", Value: "up"}},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -64,3 +128,35 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestTargets(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Body = "ping"
+	require.Equal(t, []TargetConfig{
+		{
+			HTTPClientSettings: cfg.HTTPClientSettings,
+			Method:             "GET",
+			Body:               "ping",
+		},
+	}, cfg.targets())
+
+	cfg.Targets = []TargetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8080"},
+		},
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8081", Timeout: time.Second},
+			Method:             "POST",
+		},
+	}
+	require.Equal(t, []TargetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8080", Timeout: 10 * time.Second},
+			Method:             "GET",
+		},
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8081", Timeout: time.Second},
+			Method:             "POST",
+		},
+	}, cfg.targets())
+}
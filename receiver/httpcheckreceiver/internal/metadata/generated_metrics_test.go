@@ -24,9 +24,15 @@ func TestDefaultMetrics(t *testing.T) {
 	enabledMetrics["httpcheck.error"] = true
 	mb.RecordHttpcheckErrorDataPoint(ts, 1, "attr-val", "attr-val")
 
+	enabledMetrics["httpcheck.phase.duration"] = true
+	mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "attr-val", AttributeHTTPPhase(1))
+
 	enabledMetrics["httpcheck.status"] = true
 	mb.RecordHttpcheckStatusDataPoint(ts, 1, "attr-val", 1, "attr-val", "attr-val")
 
+	enabledMetrics["httpcheck.tls.cert_remaining"] = true
+	mb.RecordHttpcheckTLSCertRemainingDataPoint(ts, 1, "attr-val", "attr-val", "attr-val")
+
 	metrics := mb.Emit()
 
 	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
@@ -46,15 +52,19 @@ func TestAllMetrics(t *testing.T) {
 	start := pcommon.Timestamp(1_000_000_000)
 	ts := pcommon.Timestamp(1_000_001_000)
 	settings := MetricsSettings{
-		HttpcheckDuration: MetricSettings{Enabled: true},
-		HttpcheckError:    MetricSettings{Enabled: true},
-		HttpcheckStatus:   MetricSettings{Enabled: true},
+		HttpcheckDuration:         MetricSettings{Enabled: true},
+		HttpcheckError:            MetricSettings{Enabled: true},
+		HttpcheckPhaseDuration:    MetricSettings{Enabled: true},
+		HttpcheckStatus:           MetricSettings{Enabled: true},
+		HttpcheckTLSCertRemaining: MetricSettings{Enabled: true},
 	}
 	mb := NewMetricsBuilder(settings, component.BuildInfo{}, WithStartTime(start))
 
 	mb.RecordHttpcheckDurationDataPoint(ts, 1, "attr-val")
 	mb.RecordHttpcheckErrorDataPoint(ts, 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "attr-val", AttributeHTTPPhase(1))
 	mb.RecordHttpcheckStatusDataPoint(ts, 1, "attr-val", 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckTLSCertRemainingDataPoint(ts, 1, "attr-val", "attr-val", "attr-val")
 
 	metrics := mb.Emit()
 
@@ -103,6 +113,23 @@ func TestAllMetrics(t *testing.T) {
 			assert.True(t, ok)
 			assert.EqualValues(t, "attr-val", attrVal.Str())
 			validatedMetrics["httpcheck.error"] = struct{}{}
+		case "httpcheck.phase.duration":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Measures the duration of each phase of the HTTP check. The ttfb phase is the time from writing the request to receiving the first byte of the response.", ms.At(i).Description())
+			assert.Equal(t, "ms", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("http.url")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("http.phase")
+			assert.True(t, ok)
+			assert.Equal(t, "dns", attrVal.Str())
+			validatedMetrics["httpcheck.phase.duration"] = struct{}{}
 		case "httpcheck.status":
 			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
 			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
@@ -128,6 +155,26 @@ func TestAllMetrics(t *testing.T) {
 			assert.True(t, ok)
 			assert.EqualValues(t, "attr-val", attrVal.Str())
 			validatedMetrics["httpcheck.status"] = struct{}{}
+		case "httpcheck.tls.cert_remaining":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Time in seconds until the earliest expiring certificate presented by the endpoint expires.", ms.At(i).Description())
+			assert.Equal(t, "s", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("http.url")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("http.tls.issuer")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("http.tls.cn")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["httpcheck.tls.cert_remaining"] = struct{}{}
 		}
 	}
 	assert.Equal(t, allMetricsCount, len(validatedMetrics))
@@ -137,14 +184,18 @@ func TestNoMetrics(t *testing.T) {
 	start := pcommon.Timestamp(1_000_000_000)
 	ts := pcommon.Timestamp(1_000_001_000)
 	settings := MetricsSettings{
-		HttpcheckDuration: MetricSettings{Enabled: false},
-		HttpcheckError:    MetricSettings{Enabled: false},
-		HttpcheckStatus:   MetricSettings{Enabled: false},
+		HttpcheckDuration:         MetricSettings{Enabled: false},
+		HttpcheckError:            MetricSettings{Enabled: false},
+		HttpcheckPhaseDuration:    MetricSettings{Enabled: false},
+		HttpcheckStatus:           MetricSettings{Enabled: false},
+		HttpcheckTLSCertRemaining: MetricSettings{Enabled: false},
 	}
 	mb := NewMetricsBuilder(settings, component.BuildInfo{}, WithStartTime(start))
 	mb.RecordHttpcheckDurationDataPoint(ts, 1, "attr-val")
 	mb.RecordHttpcheckErrorDataPoint(ts, 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "attr-val", AttributeHTTPPhase(1))
 	mb.RecordHttpcheckStatusDataPoint(ts, 1, "attr-val", 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckTLSCertRemainingDataPoint(ts, 1, "attr-val", "attr-val", "attr-val")
 
 	metrics := mb.Emit()
 
@@ -16,13 +16,19 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
@@ -33,52 +39,136 @@ var (
 )
 
 type httpcheckScraper struct {
-	client   *http.Client
+	targets  []*target
 	cfg      *Config
 	settings component.TelemetrySettings
-	mb       *metadata.MetricsBuilder
+	// mu guards mb, which is recorded to by the checks of all targets concurrently.
+	mu sync.Mutex
+	mb *metadata.MetricsBuilder
 }
 
-// start starts the scraper by creating a new HTTP Client on the scraper
-func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err error) {
-	h.client, err = h.cfg.ToClient(host, h.settings)
-	return
+// target is an endpoint checked by the scraper.
+type target struct {
+	cfg        TargetConfig
+	client     *http.Client
+	assertions *assertions
 }
 
-// scrape connects to the endpoint and produces metrics based on the response
+// start starts the scraper by creating a new HTTP Client for each target
+func (h *httpcheckScraper) start(ctx context.Context, host component.Host) error {
+	var targets []*target
+	for _, targetCfg := range h.cfg.targets() {
+		client, err := targetCfg.ToClient(host, h.settings)
+		if err != nil {
+			return err
+		}
+		assertions, err := newAssertions(targetCfg.Assertions)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, &target{cfg: targetCfg, client: client, assertions: assertions})
+	}
+	h.targets = targets
+	return nil
+}
+
+// scrape checks all targets concurrently and produces metrics based on the responses
 func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	if h.client == nil {
+	if len(h.targets) == 0 {
 		return pmetric.NewMetrics(), errClientNotInit
 	}
 
 	now := pcommon.NewTimestampFromTime(time.Now())
 
-	req, err := http.NewRequestWithContext(ctx, h.cfg.Method, h.cfg.Endpoint, http.NoBody)
+	var wg sync.WaitGroup
+	wg.Add(len(h.targets))
+	for _, t := range h.targets {
+		go func(t *target) {
+			defer wg.Done()
+			h.check(ctx, now, t)
+		}(t)
+	}
+	wg.Wait()
+
+	return h.mb.Emit(), nil
+}
+
+// check sends a request to the target and records the outcome
+func (h *httpcheckScraper) check(ctx context.Context, now pcommon.Timestamp, t *target) {
+	endpoint := t.cfg.Endpoint
+
+	body := io.Reader(http.NoBody)
+	if t.cfg.Body != "" {
+		body = strings.NewReader(t.cfg.Body)
+	}
+
+	timings := newPhaseTimings()
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, timings.clientTrace()), t.cfg.Method, endpoint, body)
 	if err != nil {
-		return pmetric.Metrics{}, err
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), endpoint, err.Error())
+		return
 	}
+	// Use a new connection for every check, so that all phases are timed
+	// and the certificates are always presented.
+	req.Close = true
 
 	start := time.Now()
-	resp, err := h.client.Do(req)
-	h.mb.RecordHttpcheckDurationDataPoint(now, time.Since(start).Milliseconds(), h.cfg.Endpoint)
+	resp, err := t.client.Do(req)
+	duration := time.Since(start)
 
 	statusCode := 0
-	if err != nil {
-		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), h.cfg.Endpoint, err.Error())
-	} else {
+	var failures []assertionFailure
+	var connState *tls.ConnectionState
+	if err == nil {
+		defer resp.Body.Close()
 		statusCode = resp.StatusCode
+		connState = resp.TLS
+		failures = t.assertions.check(resp)
+	}
+
+	for _, failure := range failures {
+		h.settings.Logger.Warn("Response failed assertion",
+			zap.String("endpoint", endpoint),
+			zap.String("assertion", failure.assertion),
+			zap.String("detail", failure.detail))
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.mb.RecordHttpcheckDurationDataPoint(now, duration.Milliseconds(), endpoint)
+
+	if err != nil {
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), endpoint, err.Error())
+	}
+	for _, failure := range failures {
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), endpoint, failure.assertion)
 	}
 
 	for class, intVal := range httpResponseClasses {
 		if statusCode/100 == intVal {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), endpoint, int64(statusCode), req.Method, class)
 		} else {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), endpoint, int64(statusCode), req.Method, class)
 		}
+	}
 
+	for phase, phaseDuration := range timings.get() {
+		h.mb.RecordHttpcheckPhaseDurationDataPoint(now, phaseDuration.Milliseconds(), endpoint, phase)
 	}
 
-	return h.mb.Emit(), nil
+	if connState != nil && len(connState.PeerCertificates) > 0 {
+		earliest := connState.PeerCertificates[0]
+		for _, cert := range connState.PeerCertificates[1:] {
+			if cert.NotAfter.Before(earliest.NotAfter) {
+				earliest = cert
+			}
+		}
+		remaining := int64(time.Until(earliest.NotAfter).Seconds())
+		h.mb.RecordHttpcheckTLSCertRemainingDataPoint(now, remaining, endpoint, earliest.Issuer.String(), earliest.Subject.CommonName)
+	}
 }
 
 func newScraper(conf *Config, settings component.ReceiverCreateSettings) *httpcheckScraper {
@@ -88,3 +178,67 @@ func newScraper(conf *Config, settings component.ReceiverCreateSettings) *httpch
 		mb:       metadata.NewMetricsBuilder(conf.Metrics, settings.BuildInfo),
 	}
 }
+
+// phaseTimings measures the duration of the phases of a request.
+// Only phases that completed successfully are measured.
+type phaseTimings struct {
+	// mu guards the maps below, as the trace hooks may be called concurrently.
+	mu        sync.Mutex
+	starts    map[metadata.AttributeHTTPPhase]time.Time
+	durations map[metadata.AttributeHTTPPhase]time.Duration
+}
+
+func newPhaseTimings() *phaseTimings {
+	return &phaseTimings{
+		starts:    map[metadata.AttributeHTTPPhase]time.Time{},
+		durations: map[metadata.AttributeHTTPPhase]time.Duration{},
+	}
+}
+
+func (p *phaseTimings) begin(phase metadata.AttributeHTTPPhase) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.starts[phase] = time.Now()
+}
+
+func (p *phaseTimings) end(phase metadata.AttributeHTTPPhase, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if start, ok := p.starts[phase]; ok && err == nil {
+		p.durations[phase] = time.Since(start)
+	}
+}
+
+// get returns the durations of the phases measured so far.
+func (p *phaseTimings) get() map[metadata.AttributeHTTPPhase]time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	durations := make(map[metadata.AttributeHTTPPhase]time.Duration, len(p.durations))
+	for phase, duration := range p.durations {
+		durations[phase] = duration
+	}
+	return durations
+}
+
+func (p *phaseTimings) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { p.begin(metadata.AttributeHTTPPhaseDns) },
+		DNSDone:  func(info httptrace.DNSDoneInfo) { p.end(metadata.AttributeHTTPPhaseDns, info.Err) },
+		ConnectStart: func(string, string) {
+			p.begin(metadata.AttributeHTTPPhaseConnect)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			p.end(metadata.AttributeHTTPPhaseConnect, err)
+		},
+		TLSHandshakeStart: func() { p.begin(metadata.AttributeHTTPPhaseTls) },
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			p.end(metadata.AttributeHTTPPhaseTls, err)
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				p.begin(metadata.AttributeHTTPPhaseTtfb)
+			}
+		},
+		GotFirstResponseByte: func() { p.end(metadata.AttributeHTTPPhaseTtfb, nil) },
+	}
+}
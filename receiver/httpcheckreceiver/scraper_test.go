@@ -16,15 +16,18 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
@@ -112,7 +115,7 @@ func TestScaperScrape(t *testing.T) {
 			expectedErr: nil,
 			compareOptions: []scrapertest.CompareOption{
 				scrapertest.IgnoreMetricAttributeValue("http.url"),
-				scrapertest.IgnoreMetricValues("httpcheck.duration"),
+				scrapertest.IgnoreMetricValues("httpcheck.duration", "httpcheck.phase.duration"),
 			},
 		},
 		{
@@ -127,7 +130,7 @@ func TestScaperScrape(t *testing.T) {
 			expectedErr: nil,
 			compareOptions: []scrapertest.CompareOption{
 				scrapertest.IgnoreMetricAttributeValue("http.url"),
-				scrapertest.IgnoreMetricValues("httpcheck.duration"),
+				scrapertest.IgnoreMetricValues("httpcheck.duration", "httpcheck.phase.duration"),
 			},
 		},
 		{
@@ -181,3 +184,129 @@ func TestNilClient(t *testing.T) {
 	require.NoError(t, scrapertest.CompareMetrics(pmetric.NewMetrics(), actualMetrics))
 
 }
+
+func TestScraperScrapeTargets(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		if req.Method != http.MethodPost || req.Header.Get("X-Check") != "true" || string(body) != "ping" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, err = rw.Write([]byte(`{"status":"up","items":[{"id":1,"ready":true}]}`))
+		require.NoError(t, err)
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		_, err := rw.Write([]byte(`{"status":"down","items":[]}`))
+		require.NoError(t, err)
+	}))
+	defer down.Close()
+
+	assertions := AssertionsConfig{
+		StatusCodes: []int{200},
+		BodyRegex:   `"status":"up"`,
+		JSON: []JSONAssertion{
+			{Path: "status", Value: "up"},
+			{Path: "$.items[0].ready", Value: "true"},
+			{Path: "items[0].id"},
+		},
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []TargetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: up.URL,
+				Headers:  map[string]string{"X-Check": "true"},
+			},
+			Method:     http.MethodPost,
+			Body:       "ping",
+			Assertions: assertions,
+		},
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: down.URL},
+			Assertions:         assertions,
+		},
+	}
+
+	scraper := newScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	statuses := map[string]int64{}
+	dps := getMetric(t, actualMetrics, "httpcheck.status").Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		method, _ := dps.At(i).Attributes().Get("http.method")
+		statusCode, _ := dps.At(i).Attributes().Get("http.status_code")
+		statuses[getURL(dps.At(i).Attributes())] = statusCode.Int()
+		if getURL(dps.At(i).Attributes()) == up.URL {
+			assert.Equal(t, http.MethodPost, method.Str())
+		} else {
+			assert.Equal(t, http.MethodGet, method.Str())
+		}
+	}
+	assert.Equal(t, map[string]int64{up.URL: 200, down.URL: 503}, statuses)
+
+	var errorMessages []string
+	dps = getMetric(t, actualMetrics, "httpcheck.error").Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		assert.Equal(t, down.URL, getURL(dps.At(i).Attributes()))
+		message, _ := dps.At(i).Attributes().Get("error.message")
+		errorMessages = append(errorMessages, message.Str())
+	}
+	assert.ElementsMatch(t, []string{
+		"status_codes",
+		"body_regex",
+		"json status",
+		"json $.items[0].ready",
+		"json items[0].id",
+	}, errorMessages)
+}
+
+func TestScraperScrapeTLS(t *testing.T) {
+	ms := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ms.URL
+	cfg.TLSSetting = configtls.TLSClientSetting{InsecureSkipVerify: true}
+	scraper := newScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	dps := getMetric(t, actualMetrics, "httpcheck.tls.cert_remaining").Gauge().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Greater(t, dps.At(0).IntValue(), int64(0))
+	assert.Equal(t, ms.URL, getURL(dps.At(0).Attributes()))
+	issuer, _ := dps.At(0).Attributes().Get("http.tls.issuer")
+	assert.Equal(t, ms.Certificate().Issuer.String(), issuer.Str())
+
+	var phases []string
+	dps = getMetric(t, actualMetrics, "httpcheck.phase.duration").Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		phase, _ := dps.At(i).Attributes().Get("http.phase")
+		phases = append(phases, phase.Str())
+	}
+	assert.ElementsMatch(t, []string{"connect", "tls", "ttfb"}, phases)
+}
+
+func getMetric(t *testing.T, metrics pmetric.Metrics, name string) pmetric.Metric {
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == name {
+			return ms.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "metric %s not found", name)
+	return pmetric.Metric{}
+}
+
+func getURL(attributes pcommon.Map) string {
+	url, _ := attributes.Get("http.url")
+	return url.Str()
+}
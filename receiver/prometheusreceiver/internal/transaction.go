@@ -22,6 +22,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/model/value"
@@ -265,6 +266,12 @@ func (t *transaction) UpdateMetadata(ref storage.SeriesRef, l labels.Labels, m m
 	return 0, nil
 }
 
+// AppendHistogram drops native histograms, which are only scraped when the
+// native histograms feature of the scrape manager is enabled.
+func (t *transaction) AppendHistogram(ref storage.SeriesRef, l labels.Labels, atMs int64, h *histogram.Histogram) (storage.SeriesRef, error) {
+	return 0, nil
+}
+
 func (t *transaction) AddTargetInfo(labels labels.Labels) error {
 	attrs := t.nodeResource.Attributes()
 
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.uber.org/zap"
+)
+
+const (
+	writePath = "/api/v1/write"
+
+	// maxRequestBodySize bounds the snappy compressed body of a write request,
+	// and maxDecodedRequestSize the request once decompressed.
+	maxRequestBodySize    = 32 << 20
+	maxDecodedRequestSize = 128 << 20
+)
+
+type metricsReceiver struct {
+	nextConsumer       consumer.Metrics
+	httpServerSettings *confighttp.HTTPServerSettings
+	translator         *translator
+
+	server *http.Server
+	wg     sync.WaitGroup
+
+	settings component.TelemetrySettings
+}
+
+func newMetricsReceiver(config *Config, params component.ReceiverCreateSettings, nextConsumer consumer.Metrics) *metricsReceiver {
+	return &metricsReceiver{
+		nextConsumer:       nextConsumer,
+		httpServerSettings: &config.HTTPServerSettings,
+		translator:         newTranslator(params.BuildInfo, featuregate.GetRegistry()),
+		settings:           params.TelemetrySettings,
+	}
+}
+
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	router := http.NewServeMux()
+	router.HandleFunc(writePath, r.handleWrite)
+
+	server, err := r.httpServerSettings.ToServer(host, r.settings, router)
+	if err != nil {
+		return err
+	}
+
+	ln, err := r.httpServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.httpServerSettings.Endpoint, err)
+	}
+
+	r.server = server
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	compressed, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxRequestBodySize))
+	if err != nil {
+		if len(compressed) == maxRequestBodySize {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_, _ = fmt.Fprintf(w, "request body exceeds %d bytes", maxRequestBodySize)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprintf(w, "failed to read request body: %s", err)
+		return
+	}
+
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decompress request body: %s", err)
+		return
+	}
+	if decodedLen > maxDecodedRequestSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = fmt.Fprintf(w, "decompressed request exceeds %d bytes", maxDecodedRequestSize)
+		return
+	}
+
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decompress request body: %s", err)
+		return
+	}
+
+	var writeReq prompb.WriteRequest
+	if err = proto.Unmarshal(data, &writeReq); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decode write request: %s", err)
+		return
+	}
+
+	md, err := r.translator.toMetrics(&writeReq)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to translate write request: %s", err)
+		return
+	}
+	if md.DataPointCount() == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err = r.nextConsumer.ConsumeMetrics(req.Context(), md); err != nil {
+		if consumererror.IsPermanent(err) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		r.settings.Logger.Debug("failed to pass metrics to next consumer", zap.Error(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, next *consumertest.MetricsSink, consumerErr error) string {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	var nextConsumer consumer.Metrics = next
+	if consumerErr != nil {
+		nextConsumer = consumertest.NewErr(consumerErr)
+	}
+	rcvr := newMetricsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), nextConsumer)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+	return "http://" + cfg.Endpoint + writePath
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return snappy.Encode(nil, data)
+}
+
+func post(t *testing.T, url string, body []byte) *http.Response {
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestStartServerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: component.NewID("missing")}
+
+	rcvr := newMetricsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	require.Error(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// The endpoint must not be left bound.
+	ln, err := net.Listen("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	assert.NoError(t, ln.Close())
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink, nil)
+
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, "__name__", "up", "job", "api", "instance", "api-1:8080"),
+			series(2, "__name__", "latency_seconds_bucket", "job", "api", "instance", "api-1:8080", "le", "+Inf"),
+			series(0.5, "__name__", "latency_seconds_sum", "job", "api", "instance", "api-1:8080"),
+			series(2, "__name__", "latency_seconds_count", "job", "api", "instance", "api-1:8080"),
+		},
+	}
+	resp := post(t, url, encodeWriteRequest(t, req))
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.Equal(t, 2, md.MetricCount())
+	assert.Equal(t, 2, md.DataPointCount())
+}
+
+func TestReceiveErrors(t *testing.T) {
+	valid := encodeWriteRequest(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{series(1, "__name__", "up")},
+	})
+
+	// a snappy header announcing a decompressed length above the limit
+	oversized := make([]byte, binary.MaxVarintLen64)
+	oversized = oversized[:binary.PutUvarint(oversized, maxDecodedRequestSize+1)]
+
+	tests := []struct {
+		name        string
+		body        []byte
+		consumerErr error
+		status      int
+	}{
+		{
+			name:   "not snappy compressed",
+			body:   []byte("up 1"),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "not a write request",
+			body:   snappy.Encode(nil, []byte("up 1")),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "decompressed request too large",
+			body:   oversized,
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "series without a name",
+			body:   encodeWriteRequest(t, &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{series(1, "job", "api")}}),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:        "permanent consumer error",
+			body:        valid,
+			consumerErr: consumererror.NewPermanent(assert.AnError),
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "retryable consumer error",
+			body:        valid,
+			consumerErr: assert.AnError,
+			status:      http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, new(consumertest.MetricsSink), tt.consumerErr)
+			resp := post(t, url, tt.body)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestReceiveMethodNotAllowed(t *testing.T) {
+	url := startReceiver(t, new(consumertest.MetricsSink), nil)
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestReceiveBodyTooLarge(t *testing.T) {
+	rcvr := newMetricsReceiver(createDefaultConfig().(*Config), componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink))
+	body := bytes.NewReader(make([]byte, maxRequestBodySize+1))
+	rec := httptest.NewRecorder()
+	rcvr.handleWrite(rec, httptest.NewRequest(http.MethodPost, writePath, body))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
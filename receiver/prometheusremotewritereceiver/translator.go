@@ -0,0 +1,744 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	scopeName = "otelcol/prometheusremotewritereceiver"
+
+	// targetInfoMetric is the series the Prometheus remote write exporter uses to
+	// carry resource attributes which do not map to the job or instance labels.
+	targetInfoMetric = "target_info"
+
+	// minNativeSchema and maxNativeSchema bound the schemas of native histograms.
+	minNativeSchema = -4
+	maxNativeSchema = 8
+	// maxNativeBuckets bounds the bucket range of a native histogram once its spans are
+	// expanded, which covers all float64 values at the largest schema.
+	maxNativeBuckets = 1 << 20
+
+	// maxMetadataFamilies bounds the number of metric families whose metadata is
+	// kept across requests.
+	maxMetadataFamilies = 1 << 16
+)
+
+type seriesPart int
+
+const (
+	partValue seriesPart = iota
+	partBucket
+	partSum
+	partCount
+)
+
+var partSuffixes = []struct {
+	suffix string
+	part   seriesPart
+}{
+	{"_bucket", partBucket},
+	{"_sum", partSum},
+	{"_count", partCount},
+}
+
+type familyInfo struct {
+	mtype     pmetric.MetricType
+	monotonic bool
+	unit      string
+	help      string
+}
+
+// translator converts Prometheus remote write requests to metrics.
+type translator struct {
+	buildInfo  component.BuildInfo
+	normalizer *prometheustranslator.Normalizer
+
+	// metadata holds the families of the metadata received so far, as Prometheus
+	// sends metadata in requests of its own rather than along with the series.
+	metadataMu sync.Mutex
+	metadata   familySet
+}
+
+func newTranslator(buildInfo component.BuildInfo, registry *featuregate.Registry) *translator {
+	return &translator{
+		buildInfo:  buildInfo,
+		normalizer: prometheustranslator.NewNormalizer(registry),
+		metadata:   make(familySet),
+	}
+}
+
+// toMetrics converts a write request to metrics. Series sharing the same job and
+// instance labels are grouped under one resource, and the _bucket, _sum and _count
+// series of histograms and summaries are merged back into a single data point for
+// every label set and timestamp. Only the series of a single request are merged,
+// so histogram and summary points missing their count, sum or +Inf bucket are dropped.
+func (t *translator) toMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	md := pmetric.NewMetrics()
+	families := t.buildFamilies(req)
+	resources := make(map[string]*resourceBuilder)
+	var order []*resourceBuilder
+
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name, job, instance, labels := splitLabels(ts.Labels)
+		if name == "" {
+			return pmetric.Metrics{}, fmt.Errorf("time series without a %s label", model.MetricNameLabel)
+		}
+
+		key := job + "\xff" + instance
+		rb, ok := resources[key]
+		if !ok {
+			rb = t.newResourceBuilder(md.ResourceMetrics().AppendEmpty(), job, instance)
+			resources[key] = rb
+			order = append(order, rb)
+		}
+
+		if name == targetInfoMetric {
+			for _, l := range labels {
+				rb.resource.Attributes().PutStr(l.Name, l.Value)
+			}
+			continue
+		}
+
+		if len(ts.Histograms) > 0 {
+			info := families.nativeHistogramInfo(name)
+			if err := rb.metric(t.normalizer, name, info).addHistograms(ts.Histograms, labels); err != nil {
+				return pmetric.Metrics{}, fmt.Errorf("invalid time series %q: %w", name, err)
+			}
+		}
+		if len(ts.Samples) == 0 {
+			continue
+		}
+
+		family, info, part := families.lookup(name, labels)
+		if err := rb.metric(t.normalizer, family, info).addSeries(ts, labels, part); err != nil {
+			return pmetric.Metrics{}, fmt.Errorf("invalid time series %q: %w", name, err)
+		}
+	}
+
+	for _, rb := range order {
+		rb.finish()
+	}
+	// resources may only have been seen through their target_info series
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		return rm.ScopeMetrics().At(0).Metrics().Len() == 0
+	})
+	return md, nil
+}
+
+type familySet map[string]familyInfo
+
+// buildFamilies returns the metric families of a write request, from its metadata
+// and the metadata of earlier requests. Metadata is only sent when enabled on the
+// Prometheus side, so histogram and summary families are also recognised from the
+// le and quantile labels of their series.
+func (t *translator) buildFamilies(req *prompb.WriteRequest) familySet {
+	families := make(familySet)
+	t.metadataMu.Lock()
+	for _, m := range req.Metadata {
+		mtype, monotonic := metadataToMetricType(m.Type)
+		info := familyInfo{
+			mtype:     mtype,
+			monotonic: monotonic,
+			unit:      m.Unit,
+			help:      m.Help,
+		}
+		families[m.MetricFamilyName] = info
+		if _, ok := t.metadata[m.MetricFamilyName]; ok || len(t.metadata) < maxMetadataFamilies {
+			t.metadata[m.MetricFamilyName] = info
+		}
+	}
+	for i := range req.Timeseries {
+		name := getLabel(req.Timeseries[i].Labels, model.MetricNameLabel)
+		for _, family := range familyCandidates(name) {
+			if _, ok := families[family]; ok {
+				continue
+			}
+			if info, ok := t.metadata[family]; ok {
+				families[family] = info
+			}
+		}
+	}
+	t.metadataMu.Unlock()
+
+	for i := range req.Timeseries {
+		labels := req.Timeseries[i].Labels
+		name := getLabel(labels, model.MetricNameLabel)
+		switch {
+		case hasLabel(labels, model.BucketLabel) && strings.HasSuffix(name, "_bucket"):
+			base := strings.TrimSuffix(name, "_bucket")
+			if _, ok := families[base]; !ok {
+				families[base] = familyInfo{mtype: pmetric.MetricTypeHistogram, monotonic: true}
+			}
+		case hasLabel(labels, model.QuantileLabel):
+			if _, ok := families[name]; !ok {
+				families[name] = familyInfo{mtype: pmetric.MetricTypeSummary, monotonic: true}
+			}
+		}
+	}
+	return families
+}
+
+// familyCandidates returns the names of the families a series name may belong to.
+func familyCandidates(name string) []string {
+	candidates := []string{name}
+	for _, s := range partSuffixes {
+		if base := strings.TrimSuffix(name, s.suffix); base != name {
+			candidates = append(candidates, base)
+		}
+	}
+	if base := strings.TrimSuffix(name, "_total"); base != name {
+		candidates = append(candidates, base)
+	}
+	return candidates
+}
+
+// lookup returns the family a series belongs to and which part of it the series holds.
+// Series of unknown families ending in _total are counters, all others are gauges.
+func (fs familySet) lookup(name string, labels []prompb.Label) (string, familyInfo, seriesPart) {
+	for _, s := range partSuffixes {
+		base := strings.TrimSuffix(name, s.suffix)
+		if base == name {
+			continue
+		}
+		info, ok := fs[base]
+		if !ok {
+			continue
+		}
+		switch {
+		case info.mtype == pmetric.MetricTypeHistogram && (s.part != partBucket || hasLabel(labels, model.BucketLabel)):
+			return base, info, s.part
+		case info.mtype == pmetric.MetricTypeSummary && s.part != partBucket:
+			return base, info, s.part
+		}
+	}
+
+	if info, ok := fs[name]; ok {
+		switch info.mtype {
+		case pmetric.MetricTypeGauge, pmetric.MetricTypeSum:
+			return name, info, partValue
+		case pmetric.MetricTypeSummary:
+			if hasLabel(labels, model.QuantileLabel) {
+				return name, info, partValue
+			}
+		}
+	}
+
+	// Counter families are named without the _total suffix in OpenMetrics.
+	if base := strings.TrimSuffix(name, "_total"); base != name {
+		if info, ok := fs[base]; ok && info.mtype == pmetric.MetricTypeSum {
+			return name, info, partValue
+		}
+		return name, familyInfo{mtype: pmetric.MetricTypeSum, monotonic: true}, partValue
+	}
+	return name, familyInfo{mtype: pmetric.MetricTypeGauge}, partValue
+}
+
+// nativeHistogramInfo returns the family of a native histogram series, which
+// keeps the unit and help of the metadata sent for it.
+func (fs familySet) nativeHistogramInfo(name string) familyInfo {
+	info := fs[name]
+	return familyInfo{
+		mtype:     pmetric.MetricTypeExponentialHistogram,
+		monotonic: true,
+		unit:      info.unit,
+		help:      info.help,
+	}
+}
+
+func metadataToMetricType(metricType prompb.MetricMetadata_MetricType) (pmetric.MetricType, bool) {
+	switch metricType {
+	case prompb.MetricMetadata_COUNTER:
+		return pmetric.MetricTypeSum, true
+	case prompb.MetricMetadata_HISTOGRAM:
+		return pmetric.MetricTypeHistogram, true
+	case prompb.MetricMetadata_SUMMARY:
+		return pmetric.MetricTypeSummary, true
+	case prompb.MetricMetadata_INFO, prompb.MetricMetadata_STATESET:
+		return pmetric.MetricTypeSum, false
+	default:
+		// including gauge histograms, which have no equivalent yet, and untyped metrics
+		return pmetric.MetricTypeGauge, false
+	}
+}
+
+type resourceBuilder struct {
+	resource pcommon.Resource
+	metrics  pmetric.MetricSlice
+	builders map[string]*metricBuilder
+	order    []*metricBuilder
+}
+
+func (t *translator) newResourceBuilder(rm pmetric.ResourceMetrics, job, instance string) *resourceBuilder {
+	populateResource(rm.Resource(), job, instance)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	sm.Scope().SetVersion(t.buildInfo.Version)
+	return &resourceBuilder{
+		resource: rm.Resource(),
+		metrics:  sm.Metrics(),
+		builders: make(map[string]*metricBuilder),
+	}
+}
+
+// populateResource maps the job and instance labels to resource attributes, the
+// same way the Prometheus receiver does for scraped targets.
+func populateResource(resource pcommon.Resource, job, instance string) {
+	attrs := resource.Attributes()
+	if job != "" {
+		attrs.PutStr(conventions.AttributeServiceName, job)
+	}
+	if instance == "" {
+		return
+	}
+	attrs.PutStr(conventions.AttributeServiceInstanceID, instance)
+	host, port, err := net.SplitHostPort(instance)
+	if err != nil {
+		host = instance
+	}
+	if isDiscernibleHost(host) {
+		attrs.PutStr(conventions.AttributeNetHostName, host)
+	}
+	if port != "" {
+		attrs.PutStr(conventions.AttributeNetHostPort, port)
+	}
+}
+
+// isDiscernibleHost checks if a host can be used as a value for the 'net.host.name' key.
+// localhost-like hosts and unspecified (0.0.0.0) hosts are not discernible.
+func isDiscernibleHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return !ip.IsLoopback() && !ip.IsUnspecified()
+	}
+	return host != "localhost"
+}
+
+func (rb *resourceBuilder) metric(normalizer *prometheustranslator.Normalizer, family string, info familyInfo) *metricBuilder {
+	key := family + "\xff" + info.mtype.String()
+	if mb, ok := rb.builders[key]; ok {
+		return mb
+	}
+
+	metric := rb.metrics.AppendEmpty()
+	metric.SetName(normalizer.TrimPromSuffixes(family, info.mtype, info.unit))
+	metric.SetUnit(info.unit)
+	metric.SetDescription(info.help)
+	switch info.mtype {
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(info.monotonic)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	default:
+		metric.SetEmptyGauge()
+	}
+
+	mb := &metricBuilder{
+		metric: metric,
+		groups: make(map[string]*pointGroup),
+	}
+	rb.builders[key] = mb
+	rb.order = append(rb.order, mb)
+	return mb
+}
+
+func (rb *resourceBuilder) finish() {
+	for _, mb := range rb.order {
+		mb.finish()
+	}
+	// histograms and summaries may only have had incomplete points
+	rb.metrics.RemoveIf(func(m pmetric.Metric) bool {
+		switch m.Type() {
+		case pmetric.MetricTypeHistogram:
+			return m.Histogram().DataPoints().Len() == 0
+		case pmetric.MetricTypeSummary:
+			return m.Summary().DataPoints().Len() == 0
+		}
+		return false
+	})
+}
+
+type metricBuilder struct {
+	metric pmetric.Metric
+	groups map[string]*pointGroup
+	order  []*pointGroup
+}
+
+// pointGroup collects the series values making up one histogram or summary data point.
+type pointGroup struct {
+	labels    []prompb.Label
+	timestamp int64
+	stale     bool
+	hasSum    bool
+	sum       float64
+	hasCount  bool
+	count     float64
+	// bounds holds the cumulative count per upper bound for histograms,
+	// and the value per quantile for summaries.
+	bounds map[float64]float64
+}
+
+func (mb *metricBuilder) addSeries(ts *prompb.TimeSeries, labels []prompb.Label, part seriesPart) error {
+	switch mb.metric.Type() {
+	case pmetric.MetricTypeGauge:
+		addNumberDataPoints(mb.metric.Gauge().DataPoints(), ts.Samples, labels)
+		return nil
+	case pmetric.MetricTypeSum:
+		addNumberDataPoints(mb.metric.Sum().DataPoints(), ts.Samples, labels)
+		return nil
+	}
+
+	boundLabel := model.BucketLabel
+	if mb.metric.Type() == pmetric.MetricTypeSummary {
+		boundLabel = model.QuantileLabel
+	}
+	var bound float64
+	if part == partBucket || part == partValue {
+		var err error
+		if bound, err = strconv.ParseFloat(getLabel(labels, boundLabel), 64); err != nil {
+			return fmt.Errorf("invalid %s label: %w", boundLabel, err)
+		}
+	}
+	labels = withoutLabel(labels, boundLabel)
+	signature := labelSignature(labels)
+
+	for _, s := range ts.Samples {
+		key := signature + "\xff" + strconv.FormatInt(s.Timestamp, 10)
+		group, ok := mb.groups[key]
+		if !ok {
+			group = &pointGroup{
+				labels:    labels,
+				timestamp: s.Timestamp,
+				bounds:    make(map[float64]float64),
+			}
+			mb.groups[key] = group
+			mb.order = append(mb.order, group)
+		}
+
+		if value.IsStaleNaN(s.Value) {
+			group.stale = true
+			continue
+		}
+		switch part {
+		case partSum:
+			group.hasSum = true
+			group.sum = s.Value
+		case partCount:
+			group.hasCount = true
+			group.count = s.Value
+		default:
+			group.bounds[bound] = s.Value
+		}
+	}
+	return nil
+}
+
+func (mb *metricBuilder) addHistograms(histograms []prompb.Histogram, labels []prompb.Label) error {
+	dest := mb.metric.ExponentialHistogram().DataPoints()
+	for i := range histograms {
+		if err := toExponentialHistogramDataPoint(&histograms[i], labels, dest.AppendEmpty()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mb *metricBuilder) finish() {
+	for _, group := range mb.order {
+		if !group.complete(mb.metric.Type()) {
+			continue
+		}
+		switch mb.metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			group.toHistogramDataPoint(mb.metric.Histogram().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeSummary:
+			group.toSummaryDataPoint(mb.metric.Summary().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+func addNumberDataPoints(dest pmetric.NumberDataPointSlice, samples []prompb.Sample, labels []prompb.Label) {
+	for _, s := range samples {
+		dp := dest.AppendEmpty()
+		dp.SetTimestamp(timestampFromMs(s.Timestamp))
+		populateAttributes(dp.Attributes(), labels)
+		if value.IsStaleNaN(s.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		} else {
+			dp.SetDoubleValue(s.Value)
+		}
+	}
+}
+
+// complete returns whether the group holds all series of a data point. The series of
+// a family may be split across requests, in which case the group is incomplete.
+func (g *pointGroup) complete(mtype pmetric.MetricType) bool {
+	if g.stale {
+		return true
+	}
+	if !g.hasCount || !g.hasSum {
+		return false
+	}
+	if mtype == pmetric.MetricTypeHistogram {
+		_, ok := g.bounds[math.Inf(1)]
+		return ok
+	}
+	return true
+}
+
+func (g *pointGroup) sortedBounds() []float64 {
+	bounds := make([]float64, 0, len(g.bounds))
+	for b := range g.bounds {
+		bounds = append(bounds, b)
+	}
+	sort.Float64s(bounds)
+	return bounds
+}
+
+func (g *pointGroup) toHistogramDataPoint(dp pmetric.HistogramDataPoint) {
+	dp.SetTimestamp(timestampFromMs(g.timestamp))
+	populateAttributes(dp.Attributes(), g.labels)
+	if g.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetCount(uint64(g.count))
+	dp.SetSum(g.sum)
+
+	// OTLP bounds never include +Inf, the overflow bucket is implied.
+	bounds := g.sortedBounds()
+	bounds = bounds[:len(bounds)-1]
+	bucketCounts := make([]uint64, len(bounds)+1)
+	previous := 0.0
+	for i, b := range bounds {
+		bucketCounts[i] = uint64(math.Max(g.bounds[b]-previous, 0))
+		previous = g.bounds[b]
+	}
+	bucketCounts[len(bounds)] = uint64(math.Max(g.count-previous, 0))
+
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(bucketCounts)
+}
+
+// toExponentialHistogramDataPoint converts a native histogram. The schema of a native histogram
+// is the scale of an exponential histogram, both using the same base-2 bucket boundaries. Float
+// histograms are converted with their counts rounded to integers. The zero threshold cannot be
+// represented and is dropped.
+func toExponentialHistogramDataPoint(h *prompb.Histogram, labels []prompb.Label, dp pmetric.ExponentialHistogramDataPoint) error {
+	if h.Schema < minNativeSchema || h.Schema > maxNativeSchema {
+		return fmt.Errorf("unsupported native histogram schema %d", h.Schema)
+	}
+
+	dp.SetTimestamp(timestampFromMs(h.Timestamp))
+	populateAttributes(dp.Attributes(), labels)
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return nil
+	}
+
+	dp.SetScale(h.Schema)
+	dp.SetSum(h.Sum)
+	if _, ok := h.Count.(*prompb.Histogram_CountFloat); ok {
+		dp.SetCount(roundCount(h.GetCountFloat()))
+	} else {
+		dp.SetCount(h.GetCountInt())
+	}
+	if _, ok := h.ZeroCount.(*prompb.Histogram_ZeroCountFloat); ok {
+		dp.SetZeroCount(roundCount(h.GetZeroCountFloat()))
+	} else {
+		dp.SetZeroCount(h.GetZeroCountInt())
+	}
+
+	if err := convertNativeBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dp.Positive()); err != nil {
+		return fmt.Errorf("invalid positive buckets: %w", err)
+	}
+	if err := convertNativeBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dp.Negative()); err != nil {
+		return fmt.Errorf("invalid negative buckets: %w", err)
+	}
+	return nil
+}
+
+// convertNativeBuckets expands the spans of a native histogram to the dense bucket counts of an
+// exponential histogram. Integer histograms carry the difference to the previous bucket count in
+// deltas, while float histograms carry the absolute counts.
+//
+// The bucket with index i of a native histogram covers (base^(i-1), base^i], while the bucket with
+// index i of an exponential histogram covers (base^i, base^(i+1)], so the indices are shifted by one.
+func convertNativeBuckets(spans []*prompb.BucketSpan, deltas []int64, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	var populated, dense int64
+	for i, span := range spans {
+		if i > 0 {
+			if span.Offset < 0 {
+				return fmt.Errorf("negative offset %d between spans", span.Offset)
+			}
+			dense += int64(span.Offset)
+		}
+		populated += int64(span.Length)
+		dense += int64(span.Length)
+	}
+	isFloat := len(counts) > 0
+	if isFloat && len(deltas) > 0 {
+		return errors.New("both deltas and counts were sent")
+	}
+	if values := len(deltas) + len(counts); int64(values) != populated {
+		return fmt.Errorf("spans cover %d buckets but %d counts were sent", populated, values)
+	}
+	if dense > maxNativeBuckets {
+		return fmt.Errorf("spans cover a range of %d buckets, the maximum is %d", dense, maxNativeBuckets)
+	}
+
+	bucketCounts := make([]uint64, 0, dense)
+	var pos int
+	var count int64
+	for i, span := range spans {
+		if i > 0 {
+			bucketCounts = append(bucketCounts, make([]uint64, span.Offset)...)
+		}
+		for j := uint32(0); j < span.Length; j++ {
+			if isFloat {
+				bucketCounts = append(bucketCounts, roundCount(counts[pos]))
+			} else {
+				count += deltas[pos]
+				if count < 0 {
+					return fmt.Errorf("negative bucket count %d", count)
+				}
+				bucketCounts = append(bucketCounts, uint64(count))
+			}
+			pos++
+		}
+	}
+
+	buckets.SetOffset(spans[0].Offset - 1)
+	buckets.BucketCounts().FromRaw(bucketCounts)
+	return nil
+}
+
+func roundCount(count float64) uint64 {
+	return uint64(math.Max(math.Round(count), 0))
+}
+
+func (g *pointGroup) toSummaryDataPoint(dp pmetric.SummaryDataPoint) {
+	dp.SetTimestamp(timestampFromMs(g.timestamp))
+	populateAttributes(dp.Attributes(), g.labels)
+	if g.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetCount(uint64(g.count))
+	dp.SetSum(g.sum)
+	for _, q := range g.sortedBounds() {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(g.bounds[q])
+	}
+}
+
+// splitLabels separates the metric name, job and instance from the remaining labels.
+func splitLabels(labels []prompb.Label) (name, job, instance string, rest []prompb.Label) {
+	rest = make([]prompb.Label, 0, len(labels))
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel:
+			name = l.Value
+		case model.JobLabel:
+			job = l.Value
+		case model.InstanceLabel:
+			instance = l.Value
+		default:
+			rest = append(rest, l)
+		}
+	}
+	return name, job, instance, rest
+}
+
+func getLabel(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutLabel(labels []prompb.Label, name string) []prompb.Label {
+	rest := make([]prompb.Label, 0, len(labels))
+	for _, l := range labels {
+		if l.Name != name {
+			rest = append(rest, l)
+		}
+	}
+	return rest
+}
+
+// labelSignature returns a key identifying a label set regardless of label order.
+func labelSignature(labels []prompb.Label) string {
+	pairs := make([]string, len(labels))
+	for i, l := range labels {
+		pairs[i] = l.Name + "\xff" + l.Value
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\xfe")
+}
+
+func populateAttributes(attrs pcommon.Map, labels []prompb.Label) {
+	attrs.EnsureCapacity(len(labels))
+	for _, l := range labels {
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+func timestampFromMs(timeAtMs int64) pcommon.Timestamp {
+	return pcommon.Timestamp(timeAtMs * 1e6)
+}
@@ -0,0 +1,429 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const testTimestamp = int64(1670000000000)
+
+func series(value float64, labels ...string) prompb.TimeSeries {
+	ts := prompb.TimeSeries{
+		Samples: []prompb.Sample{{Value: value, Timestamp: testTimestamp}},
+	}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return ts
+}
+
+func newTestTranslator() *translator {
+	return newTranslator(component.NewDefaultBuildInfo(), featuregate.NewRegistry())
+}
+
+func metricsByName(t *testing.T, rm pmetric.ResourceMetrics) map[string]pmetric.Metric {
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, scopeName, sm.Scope().Name())
+	metrics := make(map[string]pmetric.Metric)
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		metrics[sm.Metrics().At(i).Name()] = sm.Metrics().At(i)
+	}
+	return metrics
+}
+
+func TestTranslateGaugesAndCounters(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(12.5, "__name__", "temperature", "job", "node", "instance", "10.0.0.1:9100", "room", "a"),
+			series(3, "__name__", "requests_total", "job", "node", "instance", "10.0.0.1:9100", "code", "200"),
+			series(1, "__name__", "up", "job", "api", "instance", "localhost:8080"),
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	node := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":        "node",
+		"service.instance.id": "10.0.0.1:9100",
+		"net.host.name":       "10.0.0.1",
+		"net.host.port":       "9100",
+	}, node.Resource().Attributes().AsRaw())
+
+	metrics := metricsByName(t, node)
+	require.Len(t, metrics, 2)
+
+	gauge := metrics["temperature"]
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	dp := gauge.Gauge().DataPoints().At(0)
+	assert.Equal(t, 12.5, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(testTimestamp*1e6), dp.Timestamp())
+	assert.Equal(t, map[string]interface{}{"room": "a"}, dp.Attributes().AsRaw())
+
+	counter := metrics["requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, counter.Type())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, counter.Sum().AggregationTemporality())
+	assert.Equal(t, 3.0, counter.Sum().DataPoints().At(0).DoubleValue())
+
+	api := md.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":        "api",
+		"service.instance.id": "localhost:8080",
+		"net.host.port":       "8080",
+	}, api.Resource().Attributes().AsRaw())
+	assert.Contains(t, metricsByName(t, api), "up")
+}
+
+func TestTranslateHistogram(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(2, "__name__", "latency_seconds_bucket", "job", "api", "le", "0.1", "path", "/"),
+			series(5, "__name__", "latency_seconds_bucket", "job", "api", "le", "1", "path", "/"),
+			series(6, "__name__", "latency_seconds_bucket", "job", "api", "le", "+Inf", "path", "/"),
+			series(3.2, "__name__", "latency_seconds_sum", "job", "api", "path", "/"),
+			series(6, "__name__", "latency_seconds_count", "job", "api", "path", "/"),
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 1)
+
+	histogram := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, histogram.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, histogram.Histogram().AggregationTemporality())
+	require.Equal(t, 1, histogram.Histogram().DataPoints().Len())
+
+	dp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"path": "/"}, dp.Attributes().AsRaw())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 3.2, dp.Sum())
+	assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 3, 1}, dp.BucketCounts().AsRaw())
+}
+
+func TestTranslateIncompletePoints(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			// the _count series is missing
+			series(1, "__name__", "latency_seconds_bucket", "le", "+Inf", "path", "/health"),
+			series(0.1, "__name__", "latency_seconds_sum", "path", "/health"),
+			// the +Inf bucket is missing
+			series(2, "__name__", "latency_seconds_bucket", "le", "0.1", "path", "/"),
+			series(3.2, "__name__", "latency_seconds_sum", "path", "/"),
+			series(6, "__name__", "latency_seconds_count", "path", "/"),
+			// the _sum series is missing
+			series(0.9, "__name__", "rpc_duration_seconds", "quantile", "0.99"),
+			series(400, "__name__", "rpc_duration_seconds_count"),
+			series(1, "__name__", "up"),
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	assert.Len(t, metrics, 1)
+	assert.Contains(t, metrics, "up")
+}
+
+func TestTranslateMetadataAcrossRequests(t *testing.T) {
+	tr := newTestTranslator()
+
+	// Prometheus sends metadata in requests of its own.
+	md, err := tr.toMetrics(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "latency_seconds", Type: prompb.MetricMetadata_HISTOGRAM, Help: "Request latency"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// Without the buckets, the sum and count are neither gauges nor a histogram point.
+	md, err = tr.toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(3.2, "__name__", "latency_seconds_sum"),
+			series(6, "__name__", "latency_seconds_count"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md, err = tr.toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(6, "__name__", "latency_seconds_bucket", "le", "+Inf"),
+			series(3.2, "__name__", "latency_seconds_sum"),
+			series(6, "__name__", "latency_seconds_count"),
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 1)
+	histogram := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, histogram.Type())
+	assert.Equal(t, "Request latency", histogram.Description())
+	require.Equal(t, 1, histogram.Histogram().DataPoints().Len())
+	assert.Equal(t, uint64(6), histogram.Histogram().DataPoints().At(0).Count())
+}
+
+func TestTranslateSummary(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(0.9, "__name__", "rpc_duration_seconds", "quantile", "0.99"),
+			series(0.2, "__name__", "rpc_duration_seconds", "quantile", "0.5"),
+			series(100, "__name__", "rpc_duration_seconds_sum"),
+			series(400, "__name__", "rpc_duration_seconds_count"),
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, 0, rm.Resource().Attributes().Len())
+	metrics := metricsByName(t, rm)
+	require.Len(t, metrics, 1)
+
+	summary := metrics["rpc_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeSummary, summary.Type())
+	require.Equal(t, 1, summary.Summary().DataPoints().Len())
+	dp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(400), dp.Count())
+	assert.Equal(t, 100.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestTranslateMetadata(t *testing.T) {
+	registry := featuregate.NewRegistry()
+	require.NoError(t, registry.RegisterID("pkg.translator.prometheus.NormalizeName", featuregate.StageBeta))
+	tr := newTranslator(component.NewDefaultBuildInfo(), registry)
+
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1024, "__name__", "memory_usage_bytes"),
+			series(7, "__name__", "disk_io_bytes_total"),
+			series(1, "__name__", "build_info", "version", "1.0"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "memory_usage_bytes", Unit: "bytes", Help: "Memory in use."},
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "disk_io_bytes", Unit: "bytes"},
+			{Type: prompb.MetricMetadata_INFO, MetricFamilyName: "build_info"},
+		},
+	}
+
+	md, err := tr.toMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 3)
+
+	gauge := metrics["memory_usage"]
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	assert.Equal(t, "bytes", gauge.Unit())
+	assert.Equal(t, "Memory in use.", gauge.Description())
+
+	counter := metrics["disk_io"]
+	require.Equal(t, pmetric.MetricTypeSum, counter.Type())
+	assert.True(t, counter.Sum().IsMonotonic())
+
+	info := metrics["build_info"]
+	require.Equal(t, pmetric.MetricTypeSum, info.Type())
+	assert.False(t, info.Sum().IsMonotonic())
+}
+
+func TestTranslateTargetInfo(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, "__name__", "up", "job", "api", "instance", "api-1"),
+			series(1, "__name__", "target_info", "job", "api", "instance", "api-1", "deployment_environment", "prod"),
+			series(1, "__name__", "target_info", "job", "db", "instance", "db-1", "region", "eu"),
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	attrs := md.ResourceMetrics().At(0).Resource().Attributes()
+	v, ok := attrs.Get("deployment_environment")
+	require.True(t, ok)
+	assert.Equal(t, "prod", v.Str())
+	assert.Contains(t, metricsByName(t, md.ResourceMetrics().At(0)), "up")
+}
+
+func TestTranslateStaleMarkers(t *testing.T) {
+	stale := math.Float64frombits(value.StaleNaN)
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(stale, "__name__", "temperature"),
+			series(stale, "__name__", "latency_seconds_bucket", "le", "+Inf"),
+			series(stale, "__name__", "latency_seconds_count"),
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	assert.True(t, metrics["temperature"].Gauge().DataPoints().At(0).Flags().NoRecordedValue())
+	assert.True(t, metrics["latency_seconds"].Histogram().DataPoints().At(0).Flags().NoRecordedValue())
+}
+
+func nativeHistogramSeries(h prompb.Histogram, labels ...string) prompb.TimeSeries {
+	ts := series(0, labels...)
+	ts.Samples = nil
+	ts.Histograms = []prompb.Histogram{h}
+	return ts
+}
+
+func TestTranslateNativeHistogram(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			nativeHistogramSeries(prompb.Histogram{
+				Count:         &prompb.Histogram_CountInt{CountInt: 12},
+				Sum:           18.4,
+				Schema:        1,
+				ZeroThreshold: 0.001,
+				ZeroCount:     &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+				// buckets 0, 1, 4 and 5 hold 2, 3, 1 and 2
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 2, Length: 2}},
+				PositiveDeltas: []int64{2, 1, -2, 1},
+				NegativeSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 1}},
+				NegativeDeltas: []int64{2},
+				Timestamp:      testTimestamp,
+			}, "__name__", "latency_seconds", "job", "api", "path", "/"),
+			nativeHistogramSeries(prompb.Histogram{
+				Count:          &prompb.Histogram_CountFloat{CountFloat: 3},
+				Sum:            1.5,
+				Schema:         0,
+				ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 0},
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: -1, Length: 2}},
+				PositiveCounts: []float64{1, 2},
+				Timestamp:      testTimestamp,
+			}, "__name__", "latency_seconds", "job", "api", "path", "/health"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "latency_seconds", Type: prompb.MetricMetadata_HISTOGRAM, Unit: "s", Help: "Request latency"},
+		},
+	}
+
+	md, err := newTestTranslator().toMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 1)
+
+	histogram := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, histogram.Type())
+	assert.Equal(t, "s", histogram.Unit())
+	assert.Equal(t, "Request latency", histogram.Description())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, histogram.ExponentialHistogram().AggregationTemporality())
+	require.Equal(t, 2, histogram.ExponentialHistogram().DataPoints().Len())
+
+	dp := histogram.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"path": "/"}, dp.Attributes().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(testTimestamp*1e6), dp.Timestamp())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(12), dp.Count())
+	assert.Equal(t, 18.4, dp.Sum())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 3, 0, 0, 1, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(0), dp.Negative().Offset())
+	assert.Equal(t, []uint64{2}, dp.Negative().BucketCounts().AsRaw())
+
+	dp = histogram.ExponentialHistogram().DataPoints().At(1)
+	assert.Equal(t, map[string]interface{}{"path": "/health"}, dp.Attributes().AsRaw())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, int32(-2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, 0, dp.Negative().BucketCounts().Len())
+}
+
+func TestTranslateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		series prompb.TimeSeries
+		err    string
+	}{
+		{
+			name:   "missing metric name",
+			series: series(1, "job", "api"),
+			err:    "time series without a __name__ label",
+		},
+		{
+			name:   "invalid bucket bound",
+			series: series(1, "__name__", "latency_seconds_bucket", "le", "fast"),
+			err:    `invalid time series "latency_seconds_bucket": invalid le label`,
+		},
+		{
+			name: "native histogram schema out of range",
+			series: nativeHistogramSeries(prompb.Histogram{
+				Count:  &prompb.Histogram_CountInt{CountInt: 1},
+				Schema: 9,
+			}, "__name__", "latency_seconds"),
+			err: "unsupported native histogram schema 9",
+		},
+		{
+			name: "native histogram spans not matching deltas",
+			series: nativeHistogramSeries(prompb.Histogram{
+				Count:          &prompb.Histogram_CountInt{CountInt: 1},
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 2}},
+				PositiveDeltas: []int64{1},
+			}, "__name__", "latency_seconds"),
+			err: "invalid positive buckets: spans cover 2 buckets but 1 counts were sent",
+		},
+		{
+			name: "native histogram with negative bucket count",
+			series: nativeHistogramSeries(prompb.Histogram{
+				Count:          &prompb.Histogram_CountInt{CountInt: 1},
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 2}},
+				PositiveDeltas: []int64{1, -2},
+			}, "__name__", "latency_seconds"),
+			err: "negative bucket count -1",
+		},
+		{
+			name: "native histogram spans too wide",
+			series: nativeHistogramSeries(prompb.Histogram{
+				Count:          &prompb.Histogram_CountInt{CountInt: 2},
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: math.MaxInt32, Length: 1}},
+				PositiveDeltas: []int64{1, 0},
+			}, "__name__", "latency_seconds"),
+			err: "spans cover a range of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestTranslator().toMetrics(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{tt.series}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -33,6 +33,10 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultMode               = modeScrape
+
+	modeScrape = "scrape"
+	modeTrap   = "trap"
 )
 
 var (
@@ -58,32 +62,45 @@ var (
 	errMsgColumnAttributeBadValue          = `metric '%s' column_oid attribute '%s' value '%s' must match one of the possible enum values for the attribute config`
 	errMsgColumnResourceAttributeBadName   = `metric '%s' column_oid resource_attribute '%s' must match a resource_attribute config`
 	errMsgColumnIndexedAttributeRequired   = `metric '%s' column_oid must either have a resource_attribute or an indexed_value_prefix/oid attribute`
+	errMsgTrapMetricColumnOIDs             = `metric '%s' cannot have column_oids in trap mode`
+	errMsgMetricTrapOIDsNotTrapMode        = `metric '%s' can only have trap_oids in trap mode`
 
 	// Config errors
-	errEmptyEndpoint        = errors.New("endpoint must be specified")
-	errEndpointBadScheme    = errors.New("endpoint scheme must be either tcp, tcp4, tcp6, udp, udp4, or udp6")
-	errEmptyVersion         = errors.New("version must specified")
-	errBadVersion           = errors.New("version must be either v1, v2c, or v3")
-	errEmptyUser            = errors.New("user must be specified when version is v3")
-	errEmptySecurityLevel   = errors.New("security_level must be specified when version is v3")
-	errBadSecurityLevel     = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
-	errEmptyAuthType        = errors.New("auth_type must be specified when security_level is auth_no_priv or auth_priv")
-	errBadAuthType          = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
-	errEmptyAuthPassword    = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
-	errEmptyPrivacyType     = errors.New("privacy_type must be specified when security_level is auth_priv")
-	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
-	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
-	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errEmptyEndpoint         = errors.New("endpoint must be specified")
+	errEndpointBadScheme     = errors.New("endpoint scheme must be either tcp, tcp4, tcp6, udp, udp4, or udp6")
+	errTrapEndpointBadScheme = errors.New("endpoint scheme must be either tcp or udp in trap mode")
+	errBadMode               = errors.New("mode must be either scrape or trap")
+	errEmptyVersion          = errors.New("version must specified")
+	errBadVersion            = errors.New("version must be either v1, v2c, or v3")
+	errEmptyUser             = errors.New("user must be specified when version is v3")
+	errEmptySecurityLevel    = errors.New("security_level must be specified when version is v3")
+	errBadSecurityLevel      = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
+	errEmptyAuthType         = errors.New("auth_type must be specified when security_level is auth_no_priv or auth_priv")
+	errBadAuthType           = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
+	errEmptyAuthPassword     = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
+	errEmptyPrivacyType      = errors.New("privacy_type must be specified when security_level is auth_priv")
+	errBadPrivacyType        = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
+	errEmptyPrivacyPassword  = errors.New("privacy_password must be specified when security_level is auth_priv")
+	errMetricRequired        = errors.New("must have at least one config under metrics")
 )
 
 // Config defines the configuration for the various elements of the receiver.
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 
+	// Mode is how SNMP data is received.
+	// Valid options: scrape, trap.
+	// "scrape" requests data from the SNMP target at Endpoint on every collection interval.
+	// "trap" listens on Endpoint for SNMP traps and informs, which are turned into log records
+	// and, for the trap varbinds matching the metric configs, into metrics.
+	// Default: scrape
+	Mode string `mapstructure:"mode"`
+
 	// Endpoint is the SNMP target to request data from. Must be formatted as [udp|tcp|][4|6|]://{host}:{port}.
+	// In trap mode, this is the address to listen on for traps instead, formatted as [udp|tcp]://{host}:{port}.
 	// Default: udp://localhost:161
 	// If no scheme is given, udp4 is assumed.
-	// If no port is given, 161 is assumed.
+	// If no port is given, 161 is assumed, or 162 in trap mode.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// Version is the version of SNMP to use for this connection.
@@ -135,7 +152,7 @@ type Config struct {
 	Attributes map[string]*AttributeConfig `mapstructure:"attributes"`
 
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
-	// names along with their metric configurations
+	// names along with their metric configurations. It is optional in trap mode.
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
 }
 
@@ -188,6 +205,10 @@ type MetricConfig struct {
 	// for this metric.
 	ScalarOIDs []ScalarOID `mapstructure:"scalar_oids"`
 	ColumnOIDs []ColumnOID `mapstructure:"column_oids"`
+	// TrapOIDs is optional and only valid in trap mode.
+	// In trap mode, the values of the scalar OIDs are taken from the varbinds of the received traps.
+	// TrapOIDs restricts this to the traps with one of the given trap OIDs.
+	TrapOIDs []string `mapstructure:"trap_oids"`
 }
 
 // GaugeMetric contains info about the value of the gauge metric
@@ -244,6 +265,7 @@ type Attribute struct {
 func (cfg *Config) Validate() error {
 	var combinedErr error
 
+	combinedErr = multierr.Append(combinedErr, validateMode(cfg))
 	combinedErr = multierr.Append(combinedErr, validateEndpoint(cfg))
 	combinedErr = multierr.Append(combinedErr, validateVersion(cfg))
 	if strings.ToUpper(cfg.Version) == "V3" {
@@ -254,6 +276,17 @@ func (cfg *Config) Validate() error {
 	return combinedErr
 }
 
+// validateMode validates the Mode
+func validateMode(cfg *Config) error {
+	switch cfg.Mode {
+	case "", modeScrape, modeTrap: // ok
+	default:
+		return errBadMode
+	}
+
+	return nil
+}
+
 // validateEndpoint validates the Endpoint
 func validateEndpoint(cfg *Config) error {
 	if cfg.Endpoint == "" {
@@ -276,6 +309,15 @@ func validateEndpoint(cfg *Config) error {
 		return errEndpointBadScheme
 	}
 
+	// The trap listener only supports plain tcp and udp
+	if cfg.Mode == modeTrap {
+		switch strings.ToUpper(u.Scheme) {
+		case "TCP", "UDP": // ok
+		default:
+			return errTrapEndpointBadScheme
+		}
+	}
+
 	return nil
 }
 
@@ -378,9 +420,12 @@ func validateMetricConfigs(cfg *Config) error {
 	combinedErr = multierr.Append(combinedErr, validateAttributeConfigs(cfg))
 	combinedErr = multierr.Append(combinedErr, validateResourceAttributeConfigs(cfg))
 
-	// Ensure there is at least one MetricConfig
+	// Ensure there is at least one MetricConfig, traps are turned into log records even without any
 	metrics := cfg.Metrics
 	if len(metrics) == 0 {
+		if cfg.Mode == modeTrap {
+			return combinedErr
+		}
 		return multierr.Append(combinedErr, errMetricRequired)
 	}
 
@@ -413,6 +458,15 @@ func validateMetricConfigs(cfg *Config) error {
 		for _, columnOID := range metricCfg.ColumnOIDs {
 			combinedErr = multierr.Append(combinedErr, validateColumnOID(metricName, columnOID, cfg))
 		}
+
+		// Traps only carry scalar values
+		if cfg.Mode == modeTrap && len(metricCfg.ColumnOIDs) > 0 {
+			combinedErr = multierr.Append(combinedErr, fmt.Errorf(errMsgTrapMetricColumnOIDs, metricName))
+		}
+
+		if cfg.Mode != modeTrap && len(metricCfg.TrapOIDs) > 0 {
+			combinedErr = multierr.Append(combinedErr, fmt.Errorf(errMsgMetricTrapOIDsNotTrapMode, metricName))
+		}
 	}
 
 	return combinedErr
@@ -139,6 +139,28 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 	expectedConfigV3NoPrivacyPassword.AuthPassword = "p"
 	expectedConfigV3NoPrivacyPassword.Metrics = metrics
 
+	expectedConfigTrap := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrap.Mode = modeTrap
+	expectedConfigTrap.Endpoint = "udp://0.0.0.0:162"
+	expectedConfigTrap.Metrics = map[string]*MetricConfig{
+		"link.status": {
+			Unit: "1",
+			Gauge: &GaugeMetric{
+				ValueType: "int",
+			},
+			ScalarOIDs: []ScalarOID{
+				{
+					OID: "1.3.6.1.2.1.2.2.1.8.3",
+				},
+			},
+			TrapOIDs: []string{"1.3.6.1.6.3.1.1.5.3", "1.3.6.1.6.3.1.1.5.4"},
+		},
+	}
+
+	expectedConfigTrapNoMetrics := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapNoMetrics.Mode = modeTrap
+	expectedConfigTrapNoMetrics.Endpoint = "tcp://0.0.0.0:162"
+
 	testCases := []testCase{
 		{
 			name:        "NoEndpointUsesDefault",
@@ -254,6 +276,18 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 			expectedCfg: expectedConfigV3Simple,
 			expectedErr: "",
 		},
+		{
+			name:        "GoodTrapNoErrors",
+			nameVal:     "trap_good",
+			expectedCfg: expectedConfigTrap,
+			expectedErr: "",
+		},
+		{
+			name:        "TrapNoMetricConfigsNoErrors",
+			nameVal:     "trap_no_metrics",
+			expectedCfg: expectedConfigTrapNoMetrics,
+			expectedErr: "",
+		},
 	}
 
 	for _, test := range testCases {
@@ -981,6 +1015,78 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errEmptyPrivacyType.Error(),
 		},
+		{
+			name: "BadModeErrors",
+			cfg: &Config{
+				Mode:      "poll",
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+			},
+			expectedErr: errBadMode.Error(),
+		},
+		{
+			name: "TrapBadEndpointSchemeErrors",
+			cfg: &Config{
+				Mode:      modeTrap,
+				Endpoint:  "udp6://localhost:162",
+				Version:   "v2c",
+				Community: "public",
+			},
+			expectedErr: errTrapEndpointBadScheme.Error(),
+		},
+		{
+			name: "TrapColumnOIDsErrors",
+			cfg: &Config{
+				Mode:      modeTrap,
+				Endpoint:  "udp://localhost:162",
+				Version:   "v2c",
+				Community: "public",
+				ResourceAttributes: map[string]*ResourceAttributeConfig{
+					"ra1": {
+						IndexedValuePrefix: "p",
+					},
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ColumnOIDs: []ColumnOID{
+							{
+								OID:                "1",
+								ResourceAttributes: []string{"ra1"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf(errMsgTrapMetricColumnOIDs, "m3"),
+		},
+		{
+			name: "TrapOIDsNotTrapModeErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+						TrapOIDs: []string{"1.3.6.1.6.3.1.1.5.3"},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf(errMsgMetricTrapOIDsNotTrapMode, "m3"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -25,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
 
 const (
@@ -32,14 +34,18 @@ const (
 	stability = component.StabilityLevelAlpha
 )
 
-var errConfigNotSNMP = errors.New("config was not a SNMP receiver config")
+var (
+	errConfigNotSNMP       = errors.New("config was not a SNMP receiver config")
+	errLogsRequireTrapMode = errors.New("logs are only supported in trap mode")
+)
 
 // NewFactory creates a new receiver factory for SNMP
 func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithMetricsReceiver(createMetricsReceiver, stability))
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -49,6 +55,7 @@ func createDefaultConfig() component.Config {
 			ReceiverSettings:   config.NewReceiverSettings(component.NewID(typeStr)),
 			CollectionInterval: defaultCollectionInterval,
 		},
+		Mode:          defaultMode,
 		Endpoint:      defaultEndpoint,
 		Version:       defaultVersion,
 		Community:     defaultCommunity,
@@ -74,6 +81,12 @@ func createMetricsReceiver(
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
+	if snmpConfig.Mode == modeTrap {
+		r := getOrAddTrapReceiver(params, snmpConfig)
+		r.Unwrap().(*trapReceiver).metricsConsumer = consumer
+		return r, nil
+	}
+
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	scraper, err := scraperhelper.NewScraper(typeStr, snmpScraper.scrape, scraperhelper.WithStart(snmpScraper.start))
 	if err != nil {
@@ -83,6 +96,42 @@ func createMetricsReceiver(
 	return scraperhelper.NewScraperControllerReceiver(&snmpConfig.ScraperControllerSettings, params, consumer, scraperhelper.AddScraper(scraper))
 }
 
+// createLogsReceiver creates the log receiver for SNMP traps
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	config component.Config,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	if snmpConfig.Mode != modeTrap {
+		return nil, errLogsRequireTrapMode
+	}
+
+	r := getOrAddTrapReceiver(params, snmpConfig)
+	r.Unwrap().(*trapReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+// getOrAddTrapReceiver returns the trap receiver for the given config, so that the metrics
+// and logs receivers created from it share a single listener
+func getOrAddTrapReceiver(params component.ReceiverCreateSettings, cfg *Config) *sharedcomponent.SharedComponent {
+	return trapReceivers.GetOrAdd(cfg, func() component.Component {
+		return newTrapReceiver(params, cfg)
+	})
+}
+
+// trapReceivers is the map of already created trap receivers for particular configurations
+var trapReceivers = sharedcomponent.NewSharedComponents()
+
 // addMissingConfigDefaults adds any missing comfig parameters that have defaults
 func addMissingConfigDefaults(cfg *Config) error {
 	// Add the schema prefix to the endpoint if it doesn't contain one
@@ -94,6 +143,9 @@ func addMissingConfigDefaults(cfg *Config) error {
 	u, err := url.Parse(cfg.Endpoint)
 	if err == nil && u.Port() == "" {
 		portSuffix := "161"
+		if cfg.Mode == modeTrap {
+			portSuffix = "162"
+		}
 		if cfg.Endpoint[len(cfg.Endpoint)-1:] != ":" {
 			portSuffix = ":" + portSuffix
 		}
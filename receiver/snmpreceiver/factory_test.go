@@ -48,6 +48,7 @@ func TestNewFactory(t *testing.T) {
 						ReceiverSettings:   config.NewReceiverSettings(component.NewID(typeStr)),
 						CollectionInterval: defaultCollectionInterval,
 					},
+					Mode:          defaultMode,
 					Endpoint:      defaultEndpoint,
 					Version:       defaultVersion,
 					Community:     defaultCommunity,
@@ -270,6 +271,56 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "CreateLogsReceiver returns error in scrape mode",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Metrics = map[string]*MetricConfig{
+					"m1": {
+						Gauge: &GaugeMetric{ValueType: "int"},
+						ScalarOIDs: []ScalarOID{{
+							OID: ".1",
+						}},
+					},
+				}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					componenttest.NewNopReceiverCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errLogsRequireTrapMode)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver and CreateMetricsReceiver share the trap receiver",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Mode = modeTrap
+				snmpCfg.Endpoint = "localhost"
+				logsReceiver, err := factory.CreateLogsReceiver(
+					context.Background(),
+					componenttest.NewNopReceiverCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, "udp://localhost:162", snmpCfg.Endpoint)
+
+				metricsReceiver, err := factory.CreateMetricsReceiver(
+					context.Background(),
+					componenttest.NewNopReceiverCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Same(t, logsReceiver, metricsReceiver)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
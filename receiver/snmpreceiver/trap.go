@@ -0,0 +1,293 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	// snmpTrapOID is the OID of the varbind holding the trap OID in SNMPv2c and v3 traps
+	snmpTrapOID = ".1.3.6.1.6.3.1.1.4.1.0"
+	// snmpTrapsOID is the prefix of the generic trap OIDs, used to translate SNMPv1 traps (RFC 3584)
+	snmpTrapsOID = ".1.3.6.1.6.3.1.1.5"
+	// enterpriseSpecificTrap is the SNMPv1 generic trap type of enterprise specific traps
+	enterpriseSpecificTrap = 6
+
+	attributeTrapOID          = "snmp.trap.oid"
+	attributeTrapVersion      = "snmp.version"
+	attributeTrapEnterprise   = "snmp.trap.enterprise"
+	attributeTrapAgentAddress = "snmp.trap.agent_address"
+)
+
+// trapReceiver listens for SNMP traps and informs and turns them into log records and metrics
+type trapReceiver struct {
+	cfg             *Config
+	settings        component.ReceiverCreateSettings
+	logger          *zap.Logger
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+	listener        *gosnmp.TrapListener
+	params          *gosnmp.GoSNMP
+	configHelper    *configHelper
+	// metricTrapOIDs holds the trap OIDs a metric is restricted to, keyed by metric name
+	metricTrapOIDs map[string]map[string]bool
+	// converter is only used to convert the received varbinds to SNMPData
+	converter *snmpClient
+}
+
+// newTrapReceiver creates an initialized trapReceiver
+// Relies on config being validated thoroughly
+func newTrapReceiver(settings component.ReceiverCreateSettings, cfg *Config) *trapReceiver {
+	metricTrapOIDs := map[string]map[string]bool{}
+	for name, metricCfg := range cfg.Metrics {
+		if len(metricCfg.TrapOIDs) == 0 {
+			continue
+		}
+		metricTrapOIDs[name] = map[string]bool{}
+		for _, oid := range metricCfg.TrapOIDs {
+			// Trap OIDs are received with the '.' prefix
+			if !strings.HasPrefix(oid, ".") {
+				oid = "." + oid
+			}
+			metricTrapOIDs[name][oid] = true
+		}
+	}
+
+	return &trapReceiver{
+		cfg:            cfg,
+		settings:       settings,
+		logger:         settings.Logger,
+		params:         newTrapParams(cfg),
+		configHelper:   newConfigHelper(cfg),
+		metricTrapOIDs: metricTrapOIDs,
+		converter:      &snmpClient{logger: settings.Logger},
+	}
+}
+
+// newTrapParams creates the gosnmp parameters used to decode the received traps based on config
+func newTrapParams(cfg *Config) *gosnmp.GoSNMP {
+	goSNMP := &otelGoSNMPWrapper{}
+
+	switch cfg.Version {
+	case "v3":
+		goSNMP.SetVersion(gosnmp.Version3)
+		setV3ClientConfigs(goSNMP, cfg)
+	case "v1":
+		goSNMP.SetVersion(gosnmp.Version1)
+		goSNMP.SetCommunity(cfg.Community)
+	default:
+		goSNMP.SetVersion(gosnmp.Version2c)
+		goSNMP.SetCommunity(cfg.Community)
+	}
+
+	return &goSNMP.GoSNMP
+}
+
+// Start starts listening for traps on the configured endpoint
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	// Checked in config
+	u, _ := url.Parse(r.cfg.Endpoint)
+	address := strings.ToLower(u.Scheme) + "://" + u.Host
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = r.params
+	listener.OnNewTrap = r.handleTrap
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- listener.Listen(address)
+	}()
+
+	select {
+	case <-listener.Listening():
+		r.listener = listener
+		return nil
+	case err := <-listenErr:
+		return fmt.Errorf("failed to listen for SNMP traps on '%s': %w", r.cfg.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *trapReceiver) Shutdown(_ context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	return nil
+}
+
+// handleTrap turns a received trap into a log record and metrics and sends them to the consumers
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if !r.isAllowed(packet) {
+		r.logger.Debug("Dropping SNMP trap not matching the configured version or credentials", zap.Stringer("address", addr))
+		return
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	trapOID := getTrapOID(packet)
+
+	if r.logsConsumer != nil {
+		logs := r.trapToLogs(packet, trapOID, addr, now)
+		if err := r.logsConsumer.ConsumeLogs(context.Background(), logs); err != nil {
+			r.logger.Error("Failed to consume SNMP trap logs", zap.Error(err))
+		}
+	}
+
+	if r.metricsConsumer != nil {
+		metricHelper := r.trapToMetrics(packet, trapOID, addr, now)
+		if metricHelper.metrics.DataPointCount() == 0 {
+			return
+		}
+		if err := r.metricsConsumer.ConsumeMetrics(context.Background(), metricHelper.metrics); err != nil {
+			r.logger.Error("Failed to consume SNMP trap metrics", zap.Error(err))
+		}
+	}
+}
+
+// isAllowed checks that a trap matches the configured version and credentials.
+// SNMPv1 and v2c traps are accepted with the configured community when version is v1 or v2c.
+// SNMPv3 traps are accepted from the configured user with at least the configured security level.
+func (r *trapReceiver) isAllowed(packet *gosnmp.SnmpPacket) bool {
+	if r.params.Version != gosnmp.Version3 {
+		return packet.Version != gosnmp.Version3 && packet.Community == r.params.Community
+	}
+
+	if packet.Version != gosnmp.Version3 {
+		return false
+	}
+	securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok || securityParams.UserName != r.cfg.User {
+		return false
+	}
+	return packet.MsgFlags&gosnmp.AuthPriv >= r.params.MsgFlags&gosnmp.AuthPriv
+}
+
+// getTrapOID returns the OID identifying a trap. SNMPv1 traps are translated to
+// the equivalent SNMPv2 trap OID as described in RFC 3584.
+func getTrapOID(packet *gosnmp.SnmpPacket) string {
+	if packet.Version == gosnmp.Version1 {
+		if packet.GenericTrap != enterpriseSpecificTrap {
+			return snmpTrapsOID + "." + strconv.Itoa(packet.GenericTrap+1)
+		}
+		return packet.Enterprise + ".0." + strconv.Itoa(packet.SpecificTrap)
+	}
+
+	for _, variable := range packet.Variables {
+		if variable.Name == snmpTrapOID {
+			return toString(variable.Value)
+		}
+	}
+	return ""
+}
+
+// getVersionName returns the config name of a SNMP version
+func getVersionName(version gosnmp.SnmpVersion) string {
+	switch version {
+	case gosnmp.Version1:
+		return "v1"
+	case gosnmp.Version3:
+		return "v3"
+	default:
+		return "v2c"
+	}
+}
+
+// trapToLogs creates a log record for a trap, with an attribute for each of its varbinds keyed by OID
+func (r *trapReceiver) trapToLogs(packet *gosnmp.SnmpPacket, trapOID string, addr *net.UDPAddr, now pcommon.Timestamp) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	if addr != nil {
+		resourceLogs.Resource().Attributes().PutStr(conventions.AttributeNetPeerIP, addr.IP.String())
+	}
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName("otelcol/snmpreceiver")
+	scopeLogs.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	logRecord.SetObservedTimestamp(now)
+	logRecord.SetTimestamp(now)
+	logRecord.Body().SetStr(trapOID)
+
+	attributes := logRecord.Attributes()
+	attributes.PutStr(attributeTrapOID, trapOID)
+	attributes.PutStr(attributeTrapVersion, getVersionName(packet.Version))
+	if packet.Version == gosnmp.Version1 {
+		attributes.PutStr(attributeTrapEnterprise, packet.Enterprise)
+		attributes.PutStr(attributeTrapAgentAddress, packet.AgentAddress)
+	}
+
+	for _, variable := range packet.Variables {
+		if variable.Name == snmpTrapOID {
+			continue
+		}
+		data := r.converter.convertSnmpPDUToSnmpData(variable)
+		// Not explicitly checking these casts as this should be made safe in the client
+		switch data.valueType {
+		case integerVal:
+			attributes.PutInt(data.oid, data.value.(int64))
+		case floatVal:
+			attributes.PutDouble(data.oid, data.value.(float64))
+		case stringVal:
+			attributes.PutStr(data.oid, data.value.(string))
+		default:
+			attributes.PutStr(data.oid, fmt.Sprintf("%v", variable.Value))
+		}
+	}
+
+	return logs
+}
+
+// trapToMetrics creates a datapoint for each of the trap varbinds matching a metric scalar OID
+func (r *trapReceiver) trapToMetrics(packet *gosnmp.SnmpPacket, trapOID string, addr *net.UDPAddr, now pcommon.Timestamp) *otelMetricHelper {
+	metricHelper := newOTELMetricHelper(r.settings)
+	metricHelper.dataPointTime = now
+	resourceAttributes := map[string]string{}
+	if addr != nil {
+		resourceAttributes[conventions.AttributeNetPeerIP] = addr.IP.String()
+	}
+	metricHelper.createResource(generalResourceKey, resourceAttributes)
+
+	for _, variable := range packet.Variables {
+		metricName := r.configHelper.getMetricName(variable.Name)
+		if metricName == "" {
+			continue
+		}
+		if trapOIDs, ok := r.metricTrapOIDs[metricName]; ok && !trapOIDs[trapOID] {
+			continue
+		}
+
+		data := r.converter.convertSnmpPDUToSnmpData(variable)
+		dataPointAttributes := getScalarDataPointAttributes(r.configHelper, data.oid)
+		if err := addMetricDataPointToResource(data, metricHelper, r.configHelper, metricName, generalResourceKey, dataPointAttributes); err != nil {
+			r.logger.Warn("Failed to create metric from SNMP trap", zap.String("oid", data.oid), zap.Error(err))
+		}
+	}
+
+	return metricHelper
+}
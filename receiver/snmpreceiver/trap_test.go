@@ -0,0 +1,375 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	linkDownTrapOID  = ".1.3.6.1.6.3.1.1.5.3"
+	ifIndexOID       = ".1.3.6.1.2.1.2.2.1.1.3"
+	ifOperStatusOID  = ".1.3.6.1.2.1.2.2.1.8.3"
+	ifDescrOID       = ".1.3.6.1.2.1.2.2.1.2.3"
+	sysUpTimeOIDTest = ".1.3.6.1.2.1.1.3.0"
+)
+
+func newTestTrapConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = modeTrap
+	cfg.Endpoint = "udp://localhost:162"
+	cfg.Attributes = map[string]*AttributeConfig{
+		"direction": {
+			Enum: []string{"down", "up"},
+		},
+	}
+	cfg.Metrics = map[string]*MetricConfig{
+		"if.oper.status": {
+			Unit:  "1",
+			Gauge: &GaugeMetric{ValueType: "int"},
+			ScalarOIDs: []ScalarOID{{
+				OID:        ifOperStatusOID,
+				Attributes: []Attribute{{Name: "direction", Value: "down"}},
+			}},
+			TrapOIDs: []string{linkDownTrapOID},
+		},
+	}
+	return cfg
+}
+
+func newLinkDownTrap() *gosnmp.SnmpPacket {
+	return &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "public",
+		PDUType:   gosnmp.SNMPv2Trap,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: sysUpTimeOIDTest, Type: gosnmp.TimeTicks, Value: uint32(1000)},
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: linkDownTrapOID},
+			{Name: ifIndexOID, Type: gosnmp.Integer, Value: 3},
+			{Name: ifOperStatusOID, Type: gosnmp.Integer, Value: 2},
+			{Name: ifDescrOID, Type: gosnmp.OctetString, Value: []byte("eth0")},
+		},
+	}
+}
+
+func TestGetTrapOID(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		packet   *gosnmp.SnmpPacket
+		expected string
+	}{
+		{
+			desc: "v1 generic trap",
+			packet: &gosnmp.SnmpPacket{
+				Version: gosnmp.Version1,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:  ".1.3.6.1.4.1.9",
+					GenericTrap: 2,
+				},
+			},
+			expected: linkDownTrapOID,
+		},
+		{
+			desc: "v1 enterprise specific trap",
+			packet: &gosnmp.SnmpPacket{
+				Version: gosnmp.Version1,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:   ".1.3.6.1.4.1.9",
+					GenericTrap:  enterpriseSpecificTrap,
+					SpecificTrap: 42,
+				},
+			},
+			expected: ".1.3.6.1.4.1.9.0.42",
+		},
+		{
+			desc:     "v2c trap",
+			packet:   newLinkDownTrap(),
+			expected: linkDownTrapOID,
+		},
+		{
+			desc: "v2c trap without trap OID",
+			packet: &gosnmp.SnmpPacket{
+				Version: gosnmp.Version2c,
+			},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, getTrapOID(tc.packet))
+		})
+	}
+}
+
+func TestTrapReceiverIsAllowed(t *testing.T) {
+	v3Cfg := newTestTrapConfig()
+	v3Cfg.Version = "v3"
+	v3Cfg.User = "u"
+	v3Cfg.SecurityLevel = "auth_no_priv"
+	v3Cfg.AuthPassword = "password"
+
+	testCases := []struct {
+		desc     string
+		cfg      *Config
+		packet   *gosnmp.SnmpPacket
+		expected bool
+	}{
+		{
+			desc:     "v2c trap with configured community",
+			cfg:      newTestTrapConfig(),
+			packet:   newLinkDownTrap(),
+			expected: true,
+		},
+		{
+			desc:     "v1 trap with configured community",
+			cfg:      newTestTrapConfig(),
+			packet:   &gosnmp.SnmpPacket{Version: gosnmp.Version1, Community: "public"},
+			expected: true,
+		},
+		{
+			desc:     "v2c trap with other community",
+			cfg:      newTestTrapConfig(),
+			packet:   &gosnmp.SnmpPacket{Version: gosnmp.Version2c, Community: "private"},
+			expected: false,
+		},
+		{
+			desc:     "v3 trap when v2c is configured",
+			cfg:      newTestTrapConfig(),
+			packet:   &gosnmp.SnmpPacket{Version: gosnmp.Version3},
+			expected: false,
+		},
+		{
+			desc:     "v2c trap when v3 is configured",
+			cfg:      v3Cfg,
+			packet:   newLinkDownTrap(),
+			expected: false,
+		},
+		{
+			desc: "v3 trap with configured user and security level",
+			cfg:  v3Cfg,
+			packet: &gosnmp.SnmpPacket{
+				Version:            gosnmp.Version3,
+				MsgFlags:           gosnmp.AuthPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "u"},
+			},
+			expected: true,
+		},
+		{
+			desc: "v3 trap with other user",
+			cfg:  v3Cfg,
+			packet: &gosnmp.SnmpPacket{
+				Version:            gosnmp.Version3,
+				MsgFlags:           gosnmp.AuthNoPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "other"},
+			},
+			expected: false,
+		},
+		{
+			desc: "v3 trap with lower security level",
+			cfg:  v3Cfg,
+			packet: &gosnmp.SnmpPacket{
+				Version:            gosnmp.Version3,
+				MsgFlags:           gosnmp.NoAuthNoPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "u"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			receiver := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), tc.cfg)
+			require.Equal(t, tc.expected, receiver.isAllowed(tc.packet))
+		})
+	}
+}
+
+func TestTrapReceiverHandleTrap(t *testing.T) {
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	receiver := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), newTestTrapConfig())
+	receiver.logsConsumer = logsSink
+	receiver.metricsConsumer = metricsSink
+
+	addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 10162}
+	receiver.handleTrap(newLinkDownTrap(), addr)
+
+	require.Len(t, logsSink.AllLogs(), 1)
+	logs := logsSink.AllLogs()[0]
+	require.Equal(t, 1, logs.LogRecordCount())
+	resourceLogs := logs.ResourceLogs().At(0)
+	peerIP, ok := resourceLogs.Resource().Attributes().Get("net.peer.ip")
+	require.True(t, ok)
+	assert.Equal(t, "192.0.2.1", peerIP.Str())
+
+	logRecord := resourceLogs.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, linkDownTrapOID, logRecord.Body().Str())
+	assert.Equal(t, map[string]interface{}{
+		attributeTrapOID:     linkDownTrapOID,
+		attributeTrapVersion: "v2c",
+		sysUpTimeOIDTest:     int64(1000),
+		ifIndexOID:           int64(3),
+		ifOperStatusOID:      int64(2),
+		ifDescrOID:           "eth0",
+	}, logRecord.Attributes().AsRaw())
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	metrics := metricsSink.AllMetrics()[0]
+	require.Equal(t, 1, metrics.MetricCount())
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "if.oper.status", metric.Name())
+	assert.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	dataPoint := metric.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(2), dataPoint.IntValue())
+	assert.Equal(t, map[string]interface{}{"direction": "down"}, dataPoint.Attributes().AsRaw())
+}
+
+func TestTrapReceiverHandleTrapNotMatchingMetrics(t *testing.T) {
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	receiver := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), newTestTrapConfig())
+	receiver.logsConsumer = logsSink
+	receiver.metricsConsumer = metricsSink
+
+	// linkUp traps are not mapped to the metric
+	packet := newLinkDownTrap()
+	packet.Variables[1].Value = ".1.3.6.1.6.3.1.1.5.4"
+	receiver.handleTrap(packet, nil)
+
+	require.Len(t, logsSink.AllLogs(), 1)
+	require.Len(t, metricsSink.AllMetrics(), 0)
+
+	// Traps from another community are dropped
+	packet = newLinkDownTrap()
+	packet.Community = "private"
+	receiver.handleTrap(packet, nil)
+
+	require.Len(t, logsSink.AllLogs(), 1)
+	require.Len(t, metricsSink.AllMetrics(), 0)
+}
+
+func TestTrapReceiverListen(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		configureReceiver func(cfg *Config)
+		configureSender   func(sender *gosnmp.GoSNMP)
+	}{
+		{
+			desc:              "v2c",
+			configureReceiver: func(cfg *Config) {},
+			configureSender: func(sender *gosnmp.GoSNMP) {
+				sender.Version = gosnmp.Version2c
+				sender.Community = "public"
+			},
+		},
+		{
+			desc: "v3 auth_priv",
+			configureReceiver: func(cfg *Config) {
+				cfg.Version = "v3"
+				cfg.User = "u"
+				cfg.SecurityLevel = "auth_priv"
+				cfg.AuthType = "SHA"
+				cfg.AuthPassword = "authpassword"
+				cfg.PrivacyType = "AES"
+				cfg.PrivacyPassword = "privpassword"
+			},
+			configureSender: func(sender *gosnmp.GoSNMP) {
+				sender.Version = gosnmp.Version3
+				sender.SecurityModel = gosnmp.UserSecurityModel
+				sender.MsgFlags = gosnmp.AuthPriv
+				sender.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:                 "u",
+					AuthoritativeEngineID:    string([]byte{0x80, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04}),
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "authpassword",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "privpassword",
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			addr := getAvailableUDPAddr(t)
+			cfg := newTestTrapConfig()
+			cfg.Endpoint = "udp://" + addr.String()
+			tc.configureReceiver(cfg)
+			require.NoError(t, cfg.Validate())
+
+			logsSink := new(consumertest.LogsSink)
+			receiver := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), cfg)
+			receiver.logsConsumer = logsSink
+			require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, receiver.Shutdown(context.Background()))
+			}()
+
+			sender := &gosnmp.GoSNMP{
+				Target:  "127.0.0.1",
+				Port:    uint16(addr.Port),
+				Timeout: 2 * time.Second,
+				MaxOids: gosnmp.MaxOids,
+			}
+			tc.configureSender(sender)
+			require.NoError(t, sender.Connect())
+			defer sender.Conn.Close()
+
+			trap := newLinkDownTrap()
+			_, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: trap.Variables})
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				return logsSink.LogRecordCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			logRecord := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			value, ok := logRecord.Attributes().Get(ifDescrOID)
+			require.True(t, ok)
+			assert.Equal(t, pcommon.NewValueStr("eth0"), value)
+		})
+	}
+}
+
+func TestTrapReceiverStartError(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cfg := newTestTrapConfig()
+	cfg.Endpoint = "udp://" + conn.LocalAddr().String()
+	receiver := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.Error(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+// getAvailableUDPAddr finds a free local UDP address to listen on
+func getAvailableUDPAddr(t *testing.T) *net.UDPAddr {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
@@ -30,6 +30,9 @@ type Config struct {
 	Driver                                  string  `mapstructure:"driver"`
 	DataSource                              string  `mapstructure:"datasource"`
 	Queries                                 []Query `mapstructure:"queries"`
+	// StorageID is the ID of the storage extension persisting the tracking values of the logs queries.
+	// The tracking values are kept in memory only if not set.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 func (c Config) Validate() error {
@@ -53,6 +56,12 @@ func (c Config) Validate() error {
 type Query struct {
 	SQL     string      `mapstructure:"sql"`
 	Metrics []MetricCfg `mapstructure:"metrics"`
+	Logs    []LogsCfg   `mapstructure:"logs"`
+	// TrackingColumn is the column whose value in the last returned row is passed as the
+	// parameter of the next execution of the query, so that only new rows are returned.
+	TrackingColumn string `mapstructure:"tracking_column"`
+	// TrackingStartValue is the parameter of the query until a row has been returned.
+	TrackingStartValue string `mapstructure:"tracking_start_value"`
 }
 
 func (q Query) Validate() error {
@@ -60,17 +69,41 @@ func (q Query) Validate() error {
 	if q.SQL == "" {
 		errs = multierr.Append(errs, errors.New("'query.sql' cannot be empty"))
 	}
-	if len(q.Metrics) == 0 {
-		errs = multierr.Append(errs, errors.New("'query.metrics' cannot be empty"))
+	if len(q.Metrics) == 0 && len(q.Logs) == 0 {
+		errs = multierr.Append(errs, errors.New("'query.metrics' and 'query.logs' cannot both be empty"))
+	}
+	if q.TrackingColumn != "" && len(q.Metrics) != 0 {
+		errs = multierr.Append(errs, errors.New("'query.tracking_column' is only supported by logs queries"))
+	}
+	if q.TrackingColumn == "" && q.TrackingStartValue != "" {
+		errs = multierr.Append(errs, errors.New("'query.tracking_start_value' requires 'query.tracking_column'"))
 	}
 	for _, metric := range q.Metrics {
 		if err := metric.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
 		}
 	}
+	for _, logs := range q.Logs {
+		if err := logs.Validate(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
 	return errs
 }
 
+type LogsCfg struct {
+	BodyColumn       string   `mapstructure:"body_column"`
+	AttributeColumns []string `mapstructure:"attribute_columns"`
+	TimestampColumn  string   `mapstructure:"timestamp_column"`
+}
+
+func (c LogsCfg) Validate() error {
+	if c.BodyColumn == "" {
+		return errors.New("'body_column' cannot be empty")
+	}
+	return nil
+}
+
 type MetricCfg struct {
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
@@ -30,6 +30,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
+
 	tests := []struct {
 		fname        string
 		id           component.ID
@@ -65,6 +67,33 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id:    component.NewIDWithName(typeStr, ""),
+			fname: "config-logs.yaml",
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					ReceiverSettings:   config.NewReceiverSettings(component.NewID(typeStr)),
+					CollectionInterval: 10 * time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				StorageID:  &storageID,
+				Queries: []Query{
+					{
+						SQL:                "select * from audit where id > ? order by id",
+						TrackingColumn:     "id",
+						TrackingStartValue: "100",
+						Logs: []LogsCfg{
+							{
+								BodyColumn:       "message",
+								AttributeColumns: []string{"user"},
+								TimestampColumn:  "created_at",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			fname:        "config-invalid-datatype.yaml",
 			id:           component.NewIDWithName(typeStr, ""),
@@ -108,7 +137,17 @@ func TestLoadConfig(t *testing.T) {
 		{
 			fname:        "config-invalid-missing-metrics.yaml",
 			id:           component.NewIDWithName(typeStr, ""),
-			errorMessage: "'query.metrics' cannot be empty",
+			errorMessage: "'query.metrics' and 'query.logs' cannot both be empty",
+		},
+		{
+			fname:        "config-invalid-missing-bodycolumn.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'body_column' cannot be empty",
+		},
+		{
+			fname:        "config-invalid-tracking-metrics.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'query.tracking_column' is only supported by logs queries",
 		},
 		{
 			fname:        "config-invalid-missing-datasource.yaml",
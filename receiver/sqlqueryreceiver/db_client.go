@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"time"
 
 	// register db drivers
 	_ "github.com/SAP/go-hdb/driver"
@@ -31,13 +32,15 @@ import (
 )
 
 type dbClient interface {
-	metricRows(ctx context.Context) ([]metricRow, error)
+	queryRows(ctx context.Context, args ...interface{}) ([]stringMap, error)
 }
 
 type dbSQLClient struct {
 	db     *sql.DB
 	logger *zap.Logger
 	sql    string
+	// timeLayout is the layout time values are rendered with, if set.
+	timeLayout string
 }
 
 func newDbClient(db *sql.DB, sql string, logger *zap.Logger) dbClient {
@@ -48,14 +51,25 @@ func newDbClient(db *sql.DB, sql string, logger *zap.Logger) dbClient {
 	}
 }
 
-type metricRow map[string]string
+// newLogsDbClient returns a client rendering time values in a format that can be parsed back,
+// e.g. to be used as the tracking value of the next query.
+func newLogsDbClient(db *sql.DB, sql string, logger *zap.Logger) dbClient {
+	return dbSQLClient{
+		db:         db,
+		sql:        sql,
+		logger:     logger,
+		timeLayout: time.RFC3339Nano,
+	}
+}
 
-func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
-	sqlRows, err := cl.db.QueryContext(ctx, cl.sql)
+type stringMap map[string]string
+
+func (cl dbSQLClient) queryRows(ctx context.Context, args ...interface{}) ([]stringMap, error) {
+	sqlRows, err := cl.db.QueryContext(ctx, cl.sql, args...)
 	if err != nil {
 		return nil, err
 	}
-	var out []metricRow
+	var out []stringMap
 	row := reusableRow{
 		attrs: map[string]func() string{},
 	}
@@ -67,6 +81,13 @@ func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
 		colName := sqlType.Name()
 		var v interface{}
 		row.attrs[colName] = func() string {
+			if v == nil {
+				// NULL values are rendered as empty strings.
+				return ""
+			}
+			if t, ok := v.(time.Time); ok && cl.timeLayout != "" {
+				return t.Format(cl.timeLayout)
+			}
 			format := "%v"
 			if reflect.TypeOf(v).Kind() == reflect.Slice {
 				// The Postgres driver returns a []uint8 (a string) for decimal and numeric types,
@@ -83,7 +104,7 @@ func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
 		if err != nil {
 			return nil, err
 		}
-		out = append(out, row.toMetricRow())
+		out = append(out, row.toStringMap())
 	}
 	return out, nil
 }
@@ -93,8 +114,8 @@ type reusableRow struct {
 	scanDest []interface{}
 }
 
-func (row reusableRow) toMetricRow() metricRow {
-	out := metricRow{}
+func (row reusableRow) toStringMap() stringMap {
+	out := stringMap{}
 	for k, f := range row.attrs {
 		out[k] = f()
 	}
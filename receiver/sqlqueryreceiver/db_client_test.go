@@ -18,11 +18,13 @@ import "context"
 
 type fakeDBClient struct {
 	requestCounter int
-	responses      [][]metricRow
+	responses      [][]stringMap
 	err            error
+	requestArgs    [][]interface{}
 }
 
-func (c *fakeDBClient) metricRows(context.Context) ([]metricRow, error) {
+func (c *fakeDBClient) queryRows(_ context.Context, args ...interface{}) ([]stringMap, error) {
+	c.requestArgs = append(c.requestArgs, args)
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -30,5 +30,6 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiver(createReceiverFunc(sql.Open, newDbClient), stability),
+		component.WithLogsReceiver(createLogsReceiverFunc(sql.Open, newLogsDbClient), stability),
 	)
 }
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// timestampLayouts are the layouts tried in order to parse the values of the timestamp columns,
+// covering the rendering of the time values returned by the drivers and of the usual text columns.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+func rowToLog(row stringMap, cfg LogsCfg, dest plog.LogRecord, ts pcommon.Timestamp) error {
+	dest.SetObservedTimestamp(ts)
+	body, found := row[cfg.BodyColumn]
+	if !found {
+		return fmt.Errorf("rowToLog: body_column '%s' not found in result set", cfg.BodyColumn)
+	}
+	dest.Body().SetStr(body)
+	if cfg.TimestampColumn != "" {
+		value, found := row[cfg.TimestampColumn]
+		if !found {
+			return fmt.Errorf("rowToLog: timestamp_column '%s' not found in result set", cfg.TimestampColumn)
+		}
+		timestamp, err := parseTimestamp(value)
+		if err != nil {
+			return fmt.Errorf("rowToLog: %w", err)
+		}
+		dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	}
+	attrs := dest.Attributes()
+	for _, columnName := range cfg.AttributeColumns {
+		if attrVal, found := row[columnName]; found {
+			attrs.PutStr(columnName, attrVal)
+		} else {
+			return fmt.Errorf("rowToLog: attribute_column not found: '%s'", columnName)
+		}
+	}
+	return nil
+}
+
+// parseTimestamp parses the value of a timestamp column, either a date and time or a number
+// of seconds since the epoch.
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("parseTimestamp: unsupported timestamp format: '%s'", value)
+}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// trackingValueKeyPrefix prefixes the SQL of the query in the key of its tracking value in the storage.
+const trackingValueKeyPrefix = "tracking_value:"
+
+func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) component.CreateLogsReceiverFunc {
+	return func(
+		ctx context.Context,
+		settings component.ReceiverCreateSettings,
+		cfg component.Config,
+		consumer consumer.Logs,
+	) (component.LogsReceiver, error) {
+		sqlCfg := cfg.(*Config)
+		return &logsReceiver{
+			config:             sqlCfg,
+			settings:           settings,
+			nextConsumer:       consumer,
+			sqlOpenerFunc:      sqlOpenerFunc,
+			clientProviderFunc: clientProviderFunc,
+		}, nil
+	}
+}
+
+// logsReceiver runs the queries with logs at each collection interval, emitting one log record
+// per returned row and log configuration.
+type logsReceiver struct {
+	config             *Config
+	settings           component.ReceiverCreateSettings
+	nextConsumer       consumer.Logs
+	sqlOpenerFunc      sqlOpenerFunc
+	clientProviderFunc clientProviderFunc
+
+	db             *sql.DB
+	storageClient  storage.Client
+	queryReceivers []*logsQueryReceiver
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+var _ component.LogsReceiver = (*logsReceiver)(nil)
+
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.db, err = r.sqlOpenerFunc(r.config.Driver, r.config.DataSource)
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+	r.storageClient, err = getStorageClient(ctx, host, r.config.StorageID, r.settings.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+	for _, query := range r.config.Queries {
+		if len(query.Logs) == 0 {
+			continue
+		}
+		queryReceiver := &logsQueryReceiver{
+			query:         query,
+			client:        r.clientProviderFunc(r.db, query.SQL, r.settings.Logger),
+			storageClient: r.storageClient,
+			trackingValue: query.TrackingStartValue,
+		}
+		if err = queryReceiver.loadTrackingValue(ctx); err != nil {
+			return err
+		}
+		r.queryReceivers = append(r.queryReceivers, queryReceiver)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.run(runCtx)
+	return nil
+}
+
+func (r *logsReceiver) run(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.config.CollectionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.collect(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect runs each query, sending its logs to the next consumer before saving its tracking
+// value, so that the rows are queried again if they could not be consumed. The tracking value
+// is saved even if no row could be converted, so that these rows are not queried again.
+func (r *logsReceiver) collect(ctx context.Context) {
+	for _, queryReceiver := range r.queryReceivers {
+		logs, err := queryReceiver.collect(ctx)
+		if err != nil {
+			r.settings.Logger.Error("Error collecting logs", zap.String("query", queryReceiver.query.SQL), zap.Error(err))
+		}
+		if logs.LogRecordCount() > 0 {
+			if err = r.nextConsumer.ConsumeLogs(ctx, logs); err != nil {
+				r.settings.Logger.Error("Error consuming logs", zap.String("query", queryReceiver.query.SQL), zap.Error(err))
+				continue
+			}
+		}
+		if err = queryReceiver.commitTrackingValue(ctx); err != nil {
+			r.settings.Logger.Error("Error saving the tracking value", zap.String("query", queryReceiver.query.SQL), zap.Error(err))
+		}
+	}
+}
+
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	var errs error
+	if r.storageClient != nil {
+		errs = multierr.Append(errs, r.storageClient.Close(ctx))
+	}
+	if r.db != nil {
+		errs = multierr.Append(errs, r.db.Close())
+	}
+	return errs
+}
+
+type logsQueryReceiver struct {
+	query         Query
+	client        dbClient
+	storageClient storage.Client
+	// trackingValue is the parameter of the query, and pendingTrackingValue the one to use once
+	// the logs of the last collection have been consumed.
+	trackingValue        string
+	pendingTrackingValue string
+}
+
+func (q *logsQueryReceiver) trackingValueKey() string {
+	return trackingValueKeyPrefix + q.query.SQL
+}
+
+func (q *logsQueryReceiver) loadTrackingValue(ctx context.Context) error {
+	if q.query.TrackingColumn == "" {
+		return nil
+	}
+	value, err := q.storageClient.Get(ctx, q.trackingValueKey())
+	if err != nil {
+		return fmt.Errorf("failed to load the tracking value: %w", err)
+	}
+	if value != nil {
+		q.trackingValue = string(value)
+	}
+	return nil
+}
+
+func (q *logsQueryReceiver) collect(ctx context.Context) (plog.Logs, error) {
+	out := plog.NewLogs()
+	q.pendingTrackingValue = q.trackingValue
+	var args []interface{}
+	if q.query.TrackingColumn != "" {
+		args = append(args, q.trackingValue)
+	}
+	rows, err := q.client.queryRows(ctx, args...)
+	if err != nil {
+		return out, fmt.Errorf("logsQueryReceiver: %w", err)
+	}
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	lrs := out.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	var errs error
+	for i, row := range rows {
+		for _, logsCfg := range q.query.Logs {
+			// the record is only added once converted, so that rows that fail to convert are skipped
+			lr := plog.NewLogRecord()
+			if err = rowToLog(row, logsCfg, lr, ts); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("row %d: %w", i, err))
+				continue
+			}
+			lr.MoveTo(lrs.AppendEmpty())
+		}
+	}
+	if q.query.TrackingColumn != "" && len(rows) > 0 {
+		value, err := maxTrackingValue(rows, q.query.TrackingColumn)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		} else {
+			q.pendingTrackingValue = value
+		}
+	}
+	if errs != nil {
+		errs = fmt.Errorf("logsQueryReceiver row conversion errors: %w", errs)
+	}
+	return out, errs
+}
+
+// maxTrackingValue returns the greatest value of the tracking column in the rows, so that the
+// query does not need to sort its rows by the tracking column.
+func maxTrackingValue(rows []stringMap, column string) (string, error) {
+	var max string
+	for i, row := range rows {
+		value, found := row[column]
+		if !found {
+			return "", fmt.Errorf("tracking_column '%s' not found in result set", column)
+		}
+		if i == 0 || compareTrackingValues(value, max) > 0 {
+			max = value
+		}
+	}
+	return max, nil
+}
+
+// compareTrackingValues compares two values of the tracking column as numbers or timestamps if
+// they both are, and as strings otherwise.
+func compareTrackingValues(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, err := parseTimestamp(a); err == nil {
+		if y, err := parseTimestamp(b); err == nil {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func (q *logsQueryReceiver) commitTrackingValue(ctx context.Context) error {
+	if q.query.TrackingColumn == "" || q.pendingTrackingValue == q.trackingValue {
+		return nil
+	}
+	q.trackingValue = q.pendingTrackingValue
+	return q.storageClient.Set(ctx, q.trackingValueKey(), []byte(q.trackingValue))
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newLogsTestConfig() *Config {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			CollectionInterval: time.Hour,
+		},
+		Driver:     "mydriver",
+		DataSource: "my-datasource",
+		Queries: []Query{{
+			SQL:                "select * from audit where id > $1 order by id",
+			TrackingColumn:     "id",
+			TrackingStartValue: "0",
+			Logs: []LogsCfg{{
+				BodyColumn:       "message",
+				AttributeColumns: []string{"user"},
+			}},
+		}},
+	}
+}
+
+func TestLogsReceiver(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{
+			{
+				{"id": "1", "message": "logged in", "user": "alice"},
+				{"id": "2", "message": "logged out", "user": "alice"},
+			},
+			{},
+			{
+				{"id": "3", "message": "logged in", "user": "bob"},
+			},
+		},
+	}
+	cfg := newLogsTestConfig()
+	sink := new(consumertest.LogsSink)
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, func(*sql.DB, string, *zap.Logger) dbClient { return client })
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	cfg.StorageID = &storageID
+
+	rcvr, err := createReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	logsRcvr := rcvr.(*logsReceiver)
+
+	logsRcvr.collect(context.Background())
+	require.Equal(t, 1, len(sink.AllLogs()))
+	lrs := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, lrs.Len())
+	assert.Equal(t, "logged in", lrs.At(0).Body().Str())
+	assert.Equal(t, map[string]interface{}{"user": "alice"}, lrs.At(0).Attributes().AsRaw())
+	assert.Equal(t, "logged out", lrs.At(1).Body().Str())
+
+	// No new rows, the tracking value is kept.
+	logsRcvr.collect(context.Background())
+	require.Equal(t, 1, len(sink.AllLogs()))
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// The tracking value is restored from the storage after a restart.
+	rcvr, err = createReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	rcvr.(*logsReceiver).collect(context.Background())
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	assert.Equal(t, [][]interface{}{{"0"}, {"2"}, {"2"}}, client.requestArgs)
+	require.Equal(t, 2, len(sink.AllLogs()))
+	assert.Equal(t, "logged in", sink.AllLogs()[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestLogsReceiverConsumerError(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{
+			{{"id": "1", "message": "logged in", "user": "alice"}},
+			{{"id": "1", "message": "logged in", "user": "alice"}},
+		},
+	}
+	cfg := newLogsTestConfig()
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, func(*sql.DB, string, *zap.Logger) dbClient { return client })
+	rcvr, err := createReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewErr(errors.New("boom")))
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	rcvr.(*logsReceiver).collect(context.Background())
+	rcvr.(*logsReceiver).collect(context.Background())
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// The rows are queried again as they could not be consumed.
+	assert.Equal(t, [][]interface{}{{"0"}, {"0"}}, client.requestArgs)
+}
+
+func TestLogsReceiverConversionErrors(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{
+			{{"id": "1", "user": "alice"}, {"id": "2", "user": "bob"}},
+			{},
+		},
+	}
+	cfg := newLogsTestConfig()
+	sink := new(consumertest.LogsSink)
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, func(*sql.DB, string, *zap.Logger) dbClient { return client })
+	rcvr, err := createReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	rcvr.(*logsReceiver).collect(context.Background())
+	rcvr.(*logsReceiver).collect(context.Background())
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// The rows without a body are not queried again, although no record was produced.
+	assert.Equal(t, [][]interface{}{{"0"}, {"2"}}, client.requestArgs)
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestLogsReceiverStorageNotFound(t *testing.T) {
+	cfg := newLogsTestConfig()
+	storageID := component.NewIDWithName("file_storage", "missing")
+	cfg.StorageID = &storageID
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, mkFakeClient)
+	rcvr, err := createReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	err = rcvr.Start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "failed to get storage client: storage extension 'file_storage/missing' not found")
+}
+
+func TestLogsQueryReceiverWithoutTracking(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{
+			{{"message": "hello"}, {"body": "world"}},
+		},
+	}
+	queryReceiver := &logsQueryReceiver{
+		query: Query{
+			SQL:  "select * from audit",
+			Logs: []LogsCfg{{BodyColumn: "message"}},
+		},
+		client: client,
+	}
+	logs, err := queryReceiver.collect(context.Background())
+	assert.EqualError(t, err, "logsQueryReceiver row conversion errors: row 1: rowToLog: body_column 'message' not found in result set")
+	assert.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, [][]interface{}{nil}, client.requestArgs)
+}
+
+func TestLogsQueryReceiverTracksMaxValue(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{
+			{{"id": "9", "message": "a"}, {"id": "10", "message": "b"}, {"id": "2", "message": "c"}},
+		},
+	}
+	queryReceiver := &logsQueryReceiver{
+		query: Query{
+			SQL:            "select * from audit where id > $1",
+			TrackingColumn: "id",
+			Logs:           []LogsCfg{{BodyColumn: "message"}},
+		},
+		client:        client,
+		trackingValue: "1",
+	}
+	logs, err := queryReceiver.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, logs.LogRecordCount())
+	assert.Equal(t, "10", queryReceiver.pendingTrackingValue)
+}
+
+func TestCompareTrackingValues(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "10", b: "9", expected: 1},
+		{a: "1.5", b: "1.50", expected: 0},
+		{a: "2022-12-01T10:00:00Z", b: "2022-12-01 11:00:00+01:00", expected: 0},
+		{a: "2022-12-01T09:00:00Z", b: "2022-12-01T10:00:00Z", expected: -1},
+		{a: "b", b: "a", expected: 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, compareTrackingValues(tt.a, tt.b), "%s <=> %s", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestRowToLog(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	lr := plog.NewLogRecord()
+	err := rowToLog(stringMap{
+		"id":         "42",
+		"message":    "user logged in",
+		"user":       "alice",
+		"created_at": "2022-12-01T10:11:12.5Z",
+	}, LogsCfg{
+		BodyColumn:       "message",
+		AttributeColumns: []string{"user"},
+		TimestampColumn:  "created_at",
+	}, lr, ts)
+	require.NoError(t, err)
+	assert.Equal(t, "user logged in", lr.Body().Str())
+	assert.Equal(t, map[string]interface{}{"user": "alice"}, lr.Attributes().AsRaw())
+	assert.Equal(t, time.Date(2022, 12, 1, 10, 11, 12, 500000000, time.UTC), lr.Timestamp().AsTime())
+	assert.Equal(t, ts, lr.ObservedTimestamp())
+}
+
+func TestRowToLogErrors(t *testing.T) {
+	row := stringMap{"message": "hello", "created_at": "yesterday"}
+	for _, tc := range []struct {
+		cfg         LogsCfg
+		expectedErr string
+	}{
+		{
+			cfg:         LogsCfg{BodyColumn: "body"},
+			expectedErr: "rowToLog: body_column 'body' not found in result set",
+		},
+		{
+			cfg:         LogsCfg{BodyColumn: "message", TimestampColumn: "ts"},
+			expectedErr: "rowToLog: timestamp_column 'ts' not found in result set",
+		},
+		{
+			cfg:         LogsCfg{BodyColumn: "message", TimestampColumn: "created_at"},
+			expectedErr: "rowToLog: parseTimestamp: unsupported timestamp format: 'yesterday'",
+		},
+		{
+			cfg:         LogsCfg{BodyColumn: "message", AttributeColumns: []string{"user"}},
+			expectedErr: "rowToLog: attribute_column not found: 'user'",
+		},
+	} {
+		err := rowToLog(row, tc.cfg, plog.NewLogRecord(), 0)
+		assert.EqualError(t, err, tc.expectedErr)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.Date(2022, 12, 1, 10, 11, 12, 0, time.UTC)
+	for _, value := range []string{
+		"2022-12-01T10:11:12Z",
+		"2022-12-01 10:11:12+00:00",
+		"2022-12-01 10:11:12",
+		"2022-12-01T10:11:12",
+		"1669889472",
+	} {
+		ts, err := parseTimestamp(value)
+		require.NoError(t, err, value)
+		assert.True(t, expected.Equal(ts), value)
+	}
+}
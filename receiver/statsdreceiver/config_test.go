@@ -53,6 +53,7 @@ func TestLoadConfig(t *testing.T) {
 					Transport: "custom_transport",
 				},
 				AggregationInterval: 70 * time.Second,
+				MaxConnections:      50,
 				TimerHistogramMapping: []protocol.TimerHistogramMapping{
 					{
 						StatsdType:   "histogram",
@@ -97,6 +98,7 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		negativeMaxConnectionsErr      = "max_connections must not be negative"
 	)
 
 	tests := []test{
@@ -110,6 +112,17 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: negativeAggregationIntervalErr,
 		},
+		{
+			name: "negativeMaxConnections",
+			cfg: &Config{
+				AggregationInterval: 10,
+				MaxConnections:      -1,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timing", ObserverType: "gauge"},
+				},
+			},
+			expectedErr: negativeMaxConnectionsErr,
+		},
 		{
 			name: "emptyStatsdType",
 			cfg: &Config{
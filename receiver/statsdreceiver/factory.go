@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -35,6 +36,7 @@ const (
 	defaultAggregationInterval = 60 * time.Second
 	defaultEnableMetricType    = false
 	defaultIsMonotonicCounter  = false
+	defaultMaxConnections      = 100
 )
 
 var (
@@ -47,6 +49,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability),
 	)
 }
 
@@ -61,6 +64,7 @@ func createDefaultConfig() component.Config {
 		EnableMetricType:      defaultEnableMetricType,
 		IsMonotonicCounter:    defaultIsMonotonicCounter,
 		TimerHistogramMapping: defaultTimerHistogramMapping,
+		MaxConnections:        defaultMaxConnections,
 	}
 }
 
@@ -70,10 +74,52 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+func getOrAddReceiver(params component.ReceiverCreateSettings, cfg component.Config) (*sharedcomponent.SharedComponent, error) {
 	c := cfg.(*Config)
 	err := c.validate()
 	if err != nil {
 		return nil, err
 	}
-	return New(params, *c, consumer)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv *statsdReceiver
+		rcv, err = newReceiver(params, *c)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
 }
+
+// This is the map of already created StatsD receivers for particular configurations.
+// Metrics and logs receivers are created separately by the factory, but they must
+// share a single transport server, so one statsdReceiver is used per configuration.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -163,3 +163,35 @@ func TestCreateMetricsReceiverWithNilConsumer(t *testing.T) {
 	assert.Error(t, err, "nil consumer")
 	assert.Nil(t, receiver)
 }
+
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	logsReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, logsReceiver)
+
+	// The metrics and logs receivers created from the same config share a single listener.
+	metricsReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, logsReceiver, metricsReceiver)
+
+	assert.NoError(t, logsReceiver.Start(context.Background(), &testHost{t: t}))
+	assert.NoError(t, metricsReceiver.Start(context.Background(), &testHost{t: t}))
+	assert.NoError(t, logsReceiver.Shutdown(context.Background()))
+	assert.NoError(t, metricsReceiver.Shutdown(context.Background()))
+}
+
+func TestCreateLogsReceiverWithNilConsumer(t *testing.T) {
+	receiver, err := createLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		createDefaultConfig(),
+		nil,
+	)
+
+	assert.Error(t, err, "nil consumer")
+	assert.Nil(t, receiver)
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// DogStatsD events and service checks are described in
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	attributeEventTitle          = "event.title"
+	attributeEventPriority       = "event.priority"
+	attributeEventAlertType      = "event.alert_type"
+	attributeEventAggregationKey = "event.aggregation_key"
+	attributeEventSourceTypeName = "event.source_type_name"
+	attributeServiceCheckName    = "service_check.name"
+	attributeServiceCheckStatus  = "service_check.status"
+)
+
+var serviceCheckStatuses = []struct {
+	name     string
+	severity plog.SeverityNumber
+}{
+	{"OK", plog.SeverityNumberInfo},
+	{"WARNING", plog.SeverityNumberWarn},
+	{"CRITICAL", plog.SeverityNumberError},
+	{"UNKNOWN", plog.SeverityNumberUnspecified},
+}
+
+func (p *StatsDParser) aggregateLogRecord(lr plog.LogRecord, err error) error {
+	if err != nil {
+		return err
+	}
+	rl := p.logs.ResourceLogs()
+	if rl.Len() == 0 {
+		rl.AppendEmpty().ScopeLogs().AppendEmpty()
+	}
+	lr.MoveTo(rl.At(0).ScopeLogs().At(0).LogRecords().AppendEmpty())
+	return nil
+}
+
+// parseEvent parses an event with the format:
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|k:<aggregation key>|s:<source type name>|#<tags>|c:<container id>
+func parseEvent(line string, now time.Time) (plog.LogRecord, error) {
+	lr := plog.NewLogRecord()
+
+	header, rest, ok := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !ok {
+		return lr, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, ok := strings.Cut(header, ",")
+	if !ok {
+		return lr, fmt.Errorf("invalid event lengths: %s", header)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return lr, fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return lr, fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	if len(rest) < titleLen+1+textLen || rest[titleLen] != '|' {
+		return lr, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	title := rest[:titleLen]
+	text := rest[titleLen+1 : titleLen+1+textLen]
+	fields := rest[titleLen+1+textLen:]
+
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.Body().SetStr(unescapeNewlines(text))
+	attrs := lr.Attributes()
+	attrs.PutStr(attributeEventTitle, unescapeNewlines(title))
+	alertType := "info"
+
+	if fields != "" {
+		if fields[0] != '|' {
+			return lr, fmt.Errorf("invalid event format: %s", line)
+		}
+		for _, part := range strings.Split(fields[1:], "|") {
+			switch {
+			case strings.HasPrefix(part, "p:"):
+				attrs.PutStr(attributeEventPriority, strings.TrimPrefix(part, "p:"))
+			case strings.HasPrefix(part, "t:"):
+				alertType = strings.TrimPrefix(part, "t:")
+			case strings.HasPrefix(part, "k:"):
+				attrs.PutStr(attributeEventAggregationKey, strings.TrimPrefix(part, "k:"))
+			case strings.HasPrefix(part, "s:"):
+				attrs.PutStr(attributeEventSourceTypeName, strings.TrimPrefix(part, "s:"))
+			default:
+				if err := parseCommonField(part, lr); err != nil {
+					return lr, err
+				}
+			}
+		}
+	}
+
+	attrs.PutStr(attributeEventAlertType, alertType)
+	lr.SetSeverityText(alertType)
+	switch alertType {
+	case "error":
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+	case "warning":
+		lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	case "info", "success":
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	default:
+		return lr, fmt.Errorf("unsupported event alert type: %s", alertType)
+	}
+	return lr, nil
+}
+
+// parseServiceCheck parses a service check with the format:
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|c:<container id>|m:<message>
+func parseServiceCheck(line string, now time.Time) (plog.LogRecord, error) {
+	lr := plog.NewLogRecord()
+
+	parts := strings.Split(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if len(parts) < 2 {
+		return lr, fmt.Errorf("invalid service check format: %s", line)
+	}
+	if parts[0] == "" {
+		return lr, fmt.Errorf("empty service check name: %s", line)
+	}
+	status, err := strconv.Atoi(parts[1])
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return lr, fmt.Errorf("invalid service check status: %s", parts[1])
+	}
+
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetSeverityText(serviceCheckStatuses[status].name)
+	lr.SetSeverityNumber(serviceCheckStatuses[status].severity)
+	attrs := lr.Attributes()
+	attrs.PutStr(attributeServiceCheckName, parts[0])
+	attrs.PutStr(attributeServiceCheckStatus, serviceCheckStatuses[status].name)
+
+	for i := 2; i < len(parts); i++ {
+		part := parts[i]
+		if strings.HasPrefix(part, "m:") {
+			// The message is the last field, it may contain the separator.
+			message := strings.TrimPrefix(strings.Join(parts[i:], "|"), "m:")
+			lr.Body().SetStr(unescapeNewlines(message))
+			break
+		}
+		if err := parseCommonField(part, lr); err != nil {
+			return lr, err
+		}
+	}
+	return lr, nil
+}
+
+// parseCommonField parses the fields shared by events and service checks.
+func parseCommonField(part string, lr plog.LogRecord) error {
+	switch {
+	case strings.HasPrefix(part, "d:"):
+		ts, err := strconv.ParseInt(strings.TrimPrefix(part, "d:"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse timestamp: %s", part)
+		}
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(ts, 0)))
+	case strings.HasPrefix(part, "h:"):
+		lr.Attributes().PutStr(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+	case strings.HasPrefix(part, "c:"):
+		lr.Attributes().PutStr(conventions.AttributeContainerID, strings.TrimPrefix(part, "c:"))
+	case strings.HasPrefix(part, "#"):
+		tags, err := parseTags(strings.TrimPrefix(part, "#"))
+		if err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			lr.Attributes().PutStr(string(tag.Key), tag.Value.AsString())
+		}
+	default:
+		return fmt.Errorf("unrecognized message part: %s", part)
+	}
+	return nil
+}
+
+func unescapeNewlines(s string) string {
+	return strings.ReplaceAll(s, `\n`, "\n")
+}
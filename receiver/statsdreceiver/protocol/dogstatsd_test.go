@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestParseEvent(t *testing.T) {
+	now := time.Unix(711, 0)
+
+	tests := []struct {
+		name   string
+		input  string
+		wantLR func() plog.LogRecord
+		err    error
+	}{
+		{
+			name:  "title and text",
+			input: "_e{5,12}:title|hello\\nworld",
+			wantLR: func() plog.LogRecord {
+				lr := newTestLogRecord(now, "info", plog.SeverityNumberInfo, "hello\nworld")
+				lr.Attributes().PutStr("event.title", "title")
+				lr.Attributes().PutStr("event.alert_type", "info")
+				return lr
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_e{6,9}:deploy|v1.2|done|d:1000|h:host1|p:low|t:warning|k:key1|s:jenkins|#env:prod,team:a|c:abc123",
+			wantLR: func() plog.LogRecord {
+				lr := newTestLogRecord(now, "warning", plog.SeverityNumberWarn, "v1.2|done")
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1000, 0)))
+				lr.Attributes().PutStr("event.title", "deploy")
+				lr.Attributes().PutStr("host.name", "host1")
+				lr.Attributes().PutStr("event.priority", "low")
+				lr.Attributes().PutStr("event.aggregation_key", "key1")
+				lr.Attributes().PutStr("event.source_type_name", "jenkins")
+				lr.Attributes().PutStr("env", "prod")
+				lr.Attributes().PutStr("team", "a")
+				lr.Attributes().PutStr("container.id", "abc123")
+				lr.Attributes().PutStr("event.alert_type", "warning")
+				return lr
+			},
+		},
+		{
+			name:  "empty text",
+			input: "_e{5,0}:title|",
+			wantLR: func() plog.LogRecord {
+				lr := newTestLogRecord(now, "info", plog.SeverityNumberInfo, "")
+				lr.Attributes().PutStr("event.title", "title")
+				lr.Attributes().PutStr("event.alert_type", "info")
+				return lr
+			},
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{5}:title|text",
+			err:   errors.New("invalid event lengths: 5"),
+		},
+		{
+			name:  "lengths not matching",
+			input: "_e{10,4}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{10,4}:title|text"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:abc",
+			err:   errors.New("parse timestamp: d:abc"),
+		},
+		{
+			name:  "unsupported alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("unsupported event alert type: fatal"),
+		},
+		{
+			name:  "unrecognized field",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized message part: x:y"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, err := parseEvent(tt.input, now)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLR(), lr)
+		})
+	}
+}
+
+func TestParseServiceCheck(t *testing.T) {
+	now := time.Unix(711, 0)
+
+	tests := []struct {
+		name   string
+		input  string
+		wantLR func() plog.LogRecord
+		err    error
+	}{
+		{
+			name:  "name and status",
+			input: "_sc|db.up|0",
+			wantLR: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+				lr.SetSeverityText("OK")
+				lr.SetSeverityNumber(plog.SeverityNumberInfo)
+				lr.Attributes().PutStr("service_check.name", "db.up")
+				lr.Attributes().PutStr("service_check.status", "OK")
+				return lr
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_sc|db.up|2|d:1000|h:host1|#env:prod|c:abc123|m:connection refused|retrying",
+			wantLR: func() plog.LogRecord {
+				lr := newTestLogRecord(now, "CRITICAL", plog.SeverityNumberError, "connection refused|retrying")
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1000, 0)))
+				lr.Attributes().PutStr("service_check.name", "db.up")
+				lr.Attributes().PutStr("service_check.status", "CRITICAL")
+				lr.Attributes().PutStr("host.name", "host1")
+				lr.Attributes().PutStr("env", "prod")
+				lr.Attributes().PutStr("container.id", "abc123")
+				return lr
+			},
+		},
+		{
+			name:  "missing status",
+			input: "_sc|db.up",
+			err:   errors.New("invalid service check format: _sc|db.up"),
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty service check name: _sc||0"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|db.up|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "invalid tag",
+			input: "_sc|db.up|1|#env",
+			err:   errors.New("invalid tag format: [env]"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, err := parseServiceCheck(tt.input, now)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLR(), lr)
+		})
+	}
+}
+
+func TestStatsDParser_AggregateLogs(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	assert.NoError(t, p.Aggregate("_e{5,4}:title|text"))
+	assert.NoError(t, p.Aggregate("_sc|db.up|1"))
+	assert.Error(t, p.Aggregate("_sc|db.up|9"))
+	assert.NoError(t, p.Aggregate("test.metric:42|c"))
+
+	logs := p.GetLogs()
+	require.Equal(t, 2, logs.LogRecordCount())
+	lrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "text", lrs.At(0).Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, lrs.At(1).SeverityNumber())
+
+	// Logs are reset once they have been returned
+	assert.Equal(t, 0, p.GetLogs().LogRecordCount())
+	assert.Equal(t, 1, p.GetMetrics().ResourceMetrics().At(0).ScopeMetrics().Len())
+}
+
+func newTestLogRecord(now time.Time, severityText string, severity plog.SeverityNumber, body string) plog.LogRecord {
+	lr := plog.NewLogRecord()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetSeverityText(severityText)
+	lr.SetSeverityNumber(severity)
+	lr.Body().SetStr(body)
+	return lr
+}
@@ -15,12 +15,16 @@
 package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 
 import (
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 // Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Lines that are not metrics, such as DogStatsD events and service checks, are
+// mapped to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error
 	GetMetrics() pmetric.Metrics
+	GetLogs() plog.Logs
 	Aggregate(line string) error
 }
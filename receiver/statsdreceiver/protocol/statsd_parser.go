@@ -22,7 +22,9 @@ import (
 	"time"
 
 	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -40,16 +42,18 @@ type (
 const (
 	tagMetricType = "metric_type"
 
-	CounterType   MetricType = "c"
-	GaugeType     MetricType = "g"
-	HistogramType MetricType = "h"
-	TimingType    MetricType = "ms"
+	CounterType      MetricType = "c"
+	GaugeType        MetricType = "g"
+	HistogramType    MetricType = "h"
+	TimingType       MetricType = "ms"
+	DistributionType MetricType = "d"
 
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	DistributionTypeName TypeName = "distribution"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -78,6 +82,13 @@ var defaultObserverCategory = ObserverCategory{
 	method: DefaultObserverType,
 }
 
+// defaultDistributionObserverCategory maps distributions to exponential
+// histograms, as DogStatsD distributions are aggregated server side.
+var defaultDistributionObserverCategory = ObserverCategory{
+	method:          HistogramObserver,
+	histogramConfig: expoHistogramConfig(HistogramConfig{}),
+}
+
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
@@ -89,7 +100,9 @@ type StatsDParser struct {
 	isMonotonicCounter     bool
 	timerEvents            ObserverCategory
 	histogramEvents        ObserverCategory
+	distributionEvents     ObserverCategory
 	lastIntervalTime       time.Time
+	logs                   plog.Logs
 }
 
 type sampleValue struct {
@@ -132,6 +145,8 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case DistributionType:
+		return DistributionTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -147,9 +162,11 @@ func (p *StatsDParser) resetState(when time.Time) {
 
 func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
+	p.logs = plog.NewLogs()
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
+	p.distributionEvents = defaultDistributionObserverCategory
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).validate()
@@ -161,6 +178,9 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+		case DistributionTypeName:
+			p.distributionEvents.method = eachMap.ObserverType
+			p.distributionEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
 		}
 	}
 	return nil
@@ -217,6 +237,13 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 	return metrics
 }
 
+// GetLogs gets the logs built from events and service checks and resets them.
+func (p *StatsDParser) GetLogs() plog.Logs {
+	logs := p.logs
+	p.logs = plog.NewLogs()
+	return logs
+}
+
 var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
@@ -225,12 +252,21 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
+	case DistributionType:
+		return p.distributionEvents
 	}
 	return defaultObserverCategory
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.aggregateLogRecord(parseEvent(line, timeNowFunc()))
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.aggregateLogRecord(parseServiceCheck(line, timeNowFunc()))
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
 	if err != nil {
 		return err
@@ -260,7 +296,7 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
 		case GaugeObserver:
@@ -331,7 +367,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -353,19 +389,13 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			kvs = append(kvs, attribute.String(conventions.AttributeContainerID, strings.TrimPrefix(part, "c:")))
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
@@ -389,3 +419,20 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	return result, nil
 }
+
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+
+	tagSets := strings.Split(tagsStr, ",")
+
+	for _, tagSet := range tagSets {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if len(tagParts) != 2 {
+			return nil, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		k := tagParts[0]
+		v := tagParts[1]
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
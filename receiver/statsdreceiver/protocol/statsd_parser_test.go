@@ -79,6 +79,18 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"c", 0, nil, nil),
 		},
+		{
+			name:  "distribution with container id",
+			input: "test.metric:42|d|#key:value|c:abc123",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"d",
+				0,
+				[]string{"key", "container.id"},
+				[]string{"value", "abc123"}),
+		},
 		{
 			name:  "invalid  counter metric value",
 			input: "test.metric:42.abc|c",
@@ -1000,6 +1012,63 @@ func TestStatsDParser_Mappings(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_DistributionMappings(t *testing.T) {
+	type testCase struct {
+		name    string
+		mapping []TimerHistogramMapping
+		expect  map[string]string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "distribution-default",
+			expect: map[string]string{
+				"ExponentialHistogram": "D",
+			},
+		},
+		{
+			name: "distribution-to-summary",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "distribution", ObserverType: "summary"},
+			},
+			expect: map[string]string{
+				"Summary": "D",
+			},
+		},
+		{
+			name: "distribution-to-gauge",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "distribution", ObserverType: "gauge"},
+			},
+			expect: map[string]string{
+				"Gauge": "D",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &StatsDParser{}
+
+			assert.NoError(t, p.Initialize(false, false, tc.mapping))
+
+			assert.NoError(t, p.Aggregate("D:10|d"))
+
+			typeNames := map[string]string{}
+
+			metrics := p.GetMetrics()
+			ilm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+			for i := 0; i < ilm.Len(); i++ {
+				ilms := ilm.At(i).Metrics()
+				for j := 0; j < ilms.Len(); j++ {
+					m := ilms.At(j)
+					typeNames[m.Type().String()] = m.Name()
+				}
+			}
+
+			assert.Equal(t, tc.expect, typeNames)
+		})
+	}
+}
+
 func TestTimeNowFunc(t *testing.T) {
 	timeNow := timeNowFunc()
 	assert.NotNil(t, timeNow)
@@ -1080,6 +1149,45 @@ func TestStatsDParser_AggregateTimerWithHistogram(t *testing.T) {
 			}(),
 			mapping: normalMapping,
 		},
+		{
+			name: "distribution",
+			input: []string{
+				"expohisto:0|d|#mykey:myvalue",
+				"expohisto:1.5|d|#mykey:myvalue",
+				"expohisto:2.5|d|#mykey:myvalue",
+				"expohisto:4.5|d|#mykey:myvalue",
+				"expohisto:8.5|d|#mykey:myvalue",
+				"expohisto:16.5|d|#mykey:myvalue",
+				"expohisto:32.5|d|#mykey:myvalue",
+				"expohisto:64.5|d|#mykey:myvalue",
+				"expohisto:128.5|d|#mykey:myvalue",
+				"expohisto:256.5|d|#mykey:myvalue",
+				"expohisto:512.5|d|#mykey:myvalue",
+			},
+			expected: func() pmetric.Metrics {
+				data, dp := newPoint()
+				dp.SetCount(11)
+				dp.SetSum(1028)
+				dp.SetMin(0)
+				dp.SetMax(512.5)
+				dp.SetZeroCount(1)
+				dp.SetScale(0)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{
+					1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+				})
+				return data
+			}(),
+			mapping: []TimerHistogramMapping{
+				{
+					StatsdType:   "distribution",
+					ObserverType: "histogram",
+					Histogram: HistogramConfig{
+						MaxSize: 10,
+					},
+				},
+			},
+		},
 		{
 			name: "negative",
 			input: []string{
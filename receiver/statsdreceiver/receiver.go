@@ -41,6 +41,7 @@ type statsdReceiver struct {
 	reporter     transport.Reporter
 	parser       protocol.Parser
 	nextConsumer consumer.Metrics
+	logsConsumer consumer.Logs
 	cancel       context.CancelFunc
 }
 
@@ -54,6 +55,17 @@ func New(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	r, err := newReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.nextConsumer = nextConsumer
+	return r, nil
+}
+
+// newReceiver creates the StatsD receiver without any next consumer, they
+// are set by the factory for each pipeline the receiver is part of.
+func newReceiver(set component.ReceiverCreateSettings, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
@@ -69,27 +81,31 @@ func New(
 	}
 
 	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		server:       server,
-		reporter:     rep,
-		parser:       &protocol.StatsDParser{},
+		settings: set,
+		config:   &config,
+		server:   server,
+		reporter: rep,
+		parser:   &protocol.StatsDParser{},
 	}
 	return r, nil
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
 	case "", "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint, config.MaxConnections)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
+	case "unix":
+		return transport.NewUnixServer(config.NetAddr.Endpoint, config.MaxConnections)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q", config.NetAddr.Transport)
 }
 
-// Start starts a UDP server that can process StatsD messages.
+// Start starts the transport server that can process StatsD messages.
 func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	ctx, r.cancel = context.WithCancel(ctx)
 	var transferChan = make(chan string, 10)
@@ -98,8 +114,16 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	nextConsumer := r.nextConsumer
+	if nextConsumer == nil {
+		// The receiver is only part of logs pipelines, metrics are dropped.
+		nextConsumer, err = consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil })
+		if err != nil {
+			return err
+		}
+	}
 	go func() {
-		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.parser, nextConsumer, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				host.ReportFatalError(err)
 			}
@@ -111,7 +135,10 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			case <-ticker.C:
 				metrics := r.parser.GetMetrics()
 				if metrics.ResourceMetrics().At(0).ScopeMetrics().Len() > 0 {
-					r.Flush(ctx, metrics, r.nextConsumer)
+					r.Flush(ctx, metrics, nextConsumer)
+				}
+				if logs := r.parser.GetLogs(); logs.LogRecordCount() > 0 && r.logsConsumer != nil {
+					_ = r.logsConsumer.ConsumeLogs(ctx, logs)
 				}
 			case rawMetric := <-transferChan:
 				_ = r.parser.Aggregate(rawMetric)
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 )
 
 // StatsD defines the properties of a StatsD connection.
@@ -35,10 +36,16 @@ const (
 	TCP Transport = iota
 	// UDP Transport
 	UDP
+	// Unix Transport, using stream sockets
+	Unix
+	// Unixgram Transport, using datagram sockets
+	Unixgram
 )
 
 // NewStatsD creates a new StatsD instance to support the need for testing
 // the statsdreceiver package and is not intended/tested to be used in production.
+// For the Unix and Unixgram transports, host is the path of the socket file
+// and port is ignored.
 func NewStatsD(transport Transport, host string, port int) (*StatsD, error) {
 	statsd := &StatsD{
 		Host: host,
@@ -58,13 +65,15 @@ func (s *StatsD) connect(transport Transport) error {
 		cl.Close()
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	address := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
 
 	var err error
 	switch transport {
 	case TCP:
-		// TODO: implement TCP support
-		return fmt.Errorf("TCP unsupported")
+		s.Conn, err = net.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
 	case UDP:
 		var udpAddr *net.UDPAddr
 		udpAddr, err = net.ResolveUDPAddr("udp", address)
@@ -75,6 +84,16 @@ func (s *StatsD) connect(transport Transport) error {
 		if err != nil {
 			return err
 		}
+	case Unix:
+		s.Conn, err = net.Dial("unix", s.Host)
+		if err != nil {
+			return err
+		}
+	case Unixgram:
+		s.Conn, err = net.Dial("unixgram", s.Host)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown transport: %d", transport)
 	}
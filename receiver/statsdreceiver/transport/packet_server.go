@@ -19,6 +19,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"os"
 	"strings"
 
 	"go.opentelemetry.io/collector/consumer"
@@ -26,12 +27,19 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
-type udpServer struct {
+// maxPacketSize is the max size for udp packet body (assuming ipv6). It is
+// also used as the max size of a single line on stream transports.
+const maxPacketSize = 65527
+
+type packetServer struct {
 	packetConn net.PacketConn
+	transport  string
+	// socketPath is the path of the socket file to remove on Close, if any.
+	socketPath string
 	reporter   Reporter
 }
 
-var _ (Server) = (*udpServer)(nil)
+var _ (Server) = (*packetServer)(nil)
 
 // NewUDPServer creates a transport.Server using UDP as its transport.
 func NewUDPServer(addr string) (Server, error) {
@@ -40,13 +48,33 @@ func NewUDPServer(addr string) (Server, error) {
 		return nil, err
 	}
 
-	u := udpServer{
+	u := packetServer{
+		packetConn: packetConn,
+		transport:  "udp",
+	}
+	return &u, nil
+}
+
+// NewUnixgramServer creates a transport.Server using Unix datagram sockets
+// as its transport, listening on the socket file at path.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := packetServer{
 		packetConn: packetConn,
+		transport:  "unixgram",
+		socketPath: path,
 	}
 	return &u, nil
 }
 
-func (u *udpServer) ListenAndServe(
+func (u *packetServer) ListenAndServe(
 	parser protocol.Parser,
 	nextConsumer consumer.Metrics,
 	reporter Reporter,
@@ -58,7 +86,7 @@ func (u *udpServer) ListenAndServe(
 
 	u.reporter = reporter
 
-	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
+	buf := make([]byte, maxPacketSize)
 	for {
 		n, _, err := u.packetConn.ReadFrom(buf)
 		if n > 0 {
@@ -67,7 +95,8 @@ func (u *udpServer) ListenAndServe(
 			u.handlePacket(bufCopy, transferChan)
 		}
 		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				strings.ToUpper(u.transport),
 				u.packetConn.LocalAddr(),
 				err)
 			var netErr net.Error
@@ -81,11 +110,18 @@ func (u *udpServer) ListenAndServe(
 	}
 }
 
-func (u *udpServer) Close() error {
-	return u.packetConn.Close()
+func (u *packetServer) Close() error {
+	err := u.packetConn.Close()
+	// Unlike stream listeners, datagram sockets don't remove their file when closed.
+	if u.socketPath != "" {
+		if rmErr := os.Remove(u.socketPath); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
 }
 
-func (u *udpServer) handlePacket(
+func (u *packetServer) handlePacket(
 	data []byte,
 	transferChan chan<- string,
 ) {
@@ -15,7 +15,10 @@
 package transport
 
 import (
+	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -35,43 +38,63 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 	tests := []struct {
 		name          string
+		addrFn        func(t testing.TB) string
 		buildServerFn func(addr string) (Server, error)
-		buildClientFn func(host string, port int) (*client.StatsD, error)
+		buildClientFn func(t *testing.T, addr string) (*client.StatsD, error)
+		skipOnWindows bool
 	}{
 		{
 			name:          "udp",
+			addrFn:        udpAddr,
 			buildServerFn: NewUDPServer,
-			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name:   "tcp",
+			addrFn: testutil.GetAvailableLocalAddress,
+			buildServerFn: func(addr string) (Server, error) {
+				return NewTCPServer(addr, 10)
+			},
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name:   "unix",
+			addrFn: socketPath,
+			buildServerFn: func(addr string) (Server, error) {
+				return NewUnixServer(addr, 10)
+			},
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				return client.NewStatsD(client.Unix, addr, 0)
+			},
+			skipOnWindows: true,
+		},
+		{
+			name:          "unixgram",
+			addrFn:        socketPath,
+			buildServerFn: NewUnixgramServer,
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				return client.NewStatsD(client.Unixgram, addr, 0)
+			},
+			skipOnWindows: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
-
-			// Endpoint should be free.
-			ln0, err := net.ListenPacket("udp", addr)
-			require.NoError(t, err)
-			require.NotNil(t, ln0)
-
-			// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
-			ln1, err := net.ListenPacket("udp", addr)
-			require.Error(t, err)
-			require.Nil(t, ln1)
-
-			// Unbind the local address so the mock UDP service can use it
-			ln0.Close()
+			if tt.skipOnWindows && runtime.GOOS == "windows" {
+				t.Skip("unix sockets are not supported on windows")
+			}
+			addr := tt.addrFn(t)
 
 			srv, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
 			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
 			require.NoError(t, err)
@@ -87,7 +110,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 			runtime.Gosched()
 
-			gc, err := tt.buildClientFn(host, port)
+			gc, err := tt.buildClientFn(t, addr)
 			require.NoError(t, err)
 			require.NotNil(t, gc)
 			err = gc.SendMetric(client.Metric{
@@ -114,3 +137,150 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_StreamServer_Framing(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 10)
+	require.NoError(t, err)
+
+	transferChan := make(chan string, 10)
+	go func() {
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, new(consumertest.MetricsSink), NewMockReporter(0), transferChan))
+	}()
+	defer func() {
+		assert.NoError(t, srv.Close())
+	}()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Lines may be split across writes, and a write may hold several lines
+	_, err = conn.Write([]byte("test.metric:1|c\ntest.me"))
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("tric:2|c\n\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "test.metric:1|c", receiveLine(t, transferChan))
+	assert.Equal(t, "test.metric:2|c", receiveLine(t, transferChan))
+}
+
+func Test_StreamServer_MaxConnections(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 1)
+	require.NoError(t, err)
+
+	transferChan := make(chan string, 10)
+	go func() {
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, new(consumertest.MetricsSink), NewMockReporter(0), transferChan))
+	}()
+	defer func() {
+		assert.NoError(t, srv.Close())
+	}()
+
+	first, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer first.Close()
+	_, err = first.Write([]byte("first:1|c\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "first:1|c", receiveLine(t, transferChan))
+
+	// The second connection is closed by the server while the first one is open
+	second, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer second.Close()
+	require.NoError(t, second.SetReadDeadline(time.Now().Add(10*time.Second)))
+	_, err = second.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+
+	// Once the first connection is closed, a new one is accepted
+	require.NoError(t, first.Close())
+	assert.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		defer conn.Close()
+		if _, err = conn.Write([]byte("third:1|c\n")); err != nil {
+			return false
+		}
+		select {
+		case line := <-transferChan:
+			return line == "third:1|c"
+		case <-time.After(500 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func Test_StreamServer_CloseWithBlockedConsumer(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 10)
+	require.NoError(t, err)
+
+	// Nothing reads from the channel, as when the receiver has been shut down
+	transferChan := make(chan string)
+	served := make(chan error)
+	go func() {
+		served <- srv.ListenAndServe(&protocol.StatsDParser{}, new(consumertest.MetricsSink), NewMockReporter(0), transferChan)
+	}()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("test.metric:1|c\ntest.metric:2|c\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Close())
+	select {
+	case err = <-served:
+		assert.Error(t, err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the server to stop")
+	}
+}
+
+func receiveLine(t *testing.T, transferChan <-chan string) string {
+	select {
+	case line := <-transferChan:
+		return line
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for a line")
+		return ""
+	}
+}
+
+func udpAddr(t testing.TB) string {
+	addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+
+	// Endpoint should be free.
+	ln0, err := net.ListenPacket("udp", addr)
+	require.NoError(t, err)
+	require.NotNil(t, ln0)
+
+	// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
+	ln1, err := net.ListenPacket("udp", addr)
+	require.Error(t, err)
+	require.Nil(t, ln1)
+
+	// Unbind the local address so the mock UDP service can use it
+	ln0.Close()
+	return addr
+}
+
+// socketPath returns a path for a unix socket file. The path is kept short,
+// since socket paths are limited to about 100 characters.
+func socketPath(t testing.TB) string {
+	dir, err := os.MkdirTemp("", "statsd")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "statsd.sock")
+}
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
@@ -0,0 +1,208 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// streamServer serves connection oriented transports, on which metrics are
+// sent as newline delimited lines.
+type streamServer struct {
+	listener  net.Listener
+	transport string
+	// maxConnections is the max number of concurrent connections, zero
+	// means no limit.
+	maxConnections int
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+	// done is closed by Close, so that connection handlers blocked sending a
+	// line to a consumer that is no longer running return.
+	done chan struct{}
+}
+
+var _ (Server) = (*streamServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport. At
+// most maxConnections clients are served concurrently, zero means no limit.
+func NewTCPServer(addr string, maxConnections int) (Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return newStreamServer(listener, "tcp", maxConnections), nil
+}
+
+// NewUnixServer creates a transport.Server using Unix stream sockets as its
+// transport, listening on the socket file at path. At most maxConnections
+// clients are served concurrently, zero means no limit.
+func NewUnixServer(path string, maxConnections int) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return newStreamServer(listener, "unix", maxConnections), nil
+}
+
+func newStreamServer(listener net.Listener, transport string, maxConnections int) *streamServer {
+	return &streamServer{
+		listener:       listener,
+		transport:      transport,
+		maxConnections: maxConnections,
+		conns:          make(map[net.Conn]struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+func (s *streamServer) ListenAndServe(
+	parser protocol.Parser,
+	nextConsumer consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || nextConsumer == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			reporter.OnDebugf("%s Transport (%s) - Accept error: %v",
+				strings.ToUpper(s.transport),
+				s.listener.Addr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			s.wg.Wait()
+			return err
+		}
+
+		if !s.track(conn) {
+			reporter.OnDebugf("%s Transport (%s) - Rejecting connection from %s, limit of %d connections reached",
+				strings.ToUpper(s.transport),
+				s.listener.Addr(),
+				conn.RemoteAddr(),
+				s.maxConnections)
+			conn.Close()
+			continue
+		}
+
+		go func() {
+			defer s.wg.Done()
+			defer s.untrack(conn)
+			s.handleConn(conn, reporter, transferChan)
+		}()
+	}
+}
+
+// track registers a new connection, it returns false if the connection
+// can't be served because of the connection limit or the server is closed.
+func (s *streamServer) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || (s.maxConnections > 0 && len(s.conns) >= s.maxConnections) {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *streamServer) untrack(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, conn)
+	conn.Close()
+}
+
+// handleConn reads lines from a connection until it is closed. A line
+// exceeding maxPacketSize terminates the connection.
+func (s *streamServer) handleConn(
+	conn net.Conn,
+	reporter Reporter,
+	transferChan chan<- string,
+) {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxPacketSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		select {
+		case transferChan <- line:
+		case <-s.done:
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		reporter.OnDebugf("%s Transport (%s) - Read error from %s: %v",
+			strings.ToUpper(s.transport),
+			s.listener.Addr(),
+			conn.RemoteAddr(),
+			err)
+	}
+}
+
+// Close stops accepting connections and closes the active ones.
+func (s *streamServer) Close() error {
+	err := s.listener.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	return err
+}
+
+// removeStaleSocket removes the socket file left at path by a previous
+// process, so that a new socket can be bound to it.
+func removeStaleSocket(path string) error {
+	fi, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s already exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}